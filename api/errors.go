@@ -42,3 +42,14 @@ func Error(err error) *ErrorResponse {
 }
 
 var ErrNotFound = &ErrorResponse{Code: 404, Status: "Resource not found."}
+
+func ConflictError(err error) *ErrorResponse {
+	return &ErrorResponse{
+		Err:     err,
+		Code:    409,
+		Status:  http.StatusText(409),
+		Message: err.Error(),
+	}
+}
+
+var ErrUnauthorized = &ErrorResponse{Code: 401, Status: http.StatusText(401)}
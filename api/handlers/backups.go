@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/storage/backup"
+)
+
+var backupNameRegex = regexp.MustCompile(`^[0-9a-zA-Z_-]+$`)
+
+const (
+	backupStatusRunning   = "running"
+	backupStatusCompleted = "completed"
+	backupStatusFailed    = "failed"
+)
+
+type backupJSON struct {
+	Name     string           `json:"name"`
+	Path     string           `json:"path"`
+	Status   string           `json:"status"`
+	Error    string           `json:"error,omitempty"`
+	Manifest *backup.Manifest `json:"manifest,omitempty"`
+}
+
+// Backups triggers backups of the running node's db into a local directory.
+type Backups struct {
+	Logger *zap.Logger
+	// Dir is the directory in which backups are created, each in its own sub-directory
+	Dir string
+	// Create creates a backup into the given directory
+	Create func(dir string) (*backup.Manifest, error)
+
+	mu      sync.Mutex
+	backups map[string]*backupJSON
+}
+
+// Start starts a backup in the background, its progress can be followed with Get.
+func (h *Backups) Start(w http.ResponseWriter, r *http.Request) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, b := range h.backups {
+		if b.Status == backupStatusRunning {
+			return api.ConflictError(fmt.Errorf("backup %s is already running", b.Name))
+		}
+	}
+	if h.backups == nil {
+		h.backups = map[string]*backupJSON{}
+	}
+
+	name := time.Now().UTC().Format("20060102-150405")
+	b := &backupJSON{
+		Name:   name,
+		Path:   filepath.Join(h.Dir, name),
+		Status: backupStatusRunning,
+	}
+	h.backups[name] = b
+	go h.run(b)
+
+	render.Status(r, http.StatusAccepted)
+	return api.Render(w, r, *b)
+}
+
+// Get returns the status of a backup.
+func (h *Backups) Get(w http.ResponseWriter, r *http.Request) error {
+	name := chi.URLParam(r, "name")
+	if !backupNameRegex.MatchString(name) {
+		return api.InvalidRequestError(fmt.Errorf("invalid backup name"))
+	}
+
+	h.mu.Lock()
+	b, ok := h.backups[name]
+	var resp backupJSON
+	if ok {
+		resp = *b
+	}
+	h.mu.Unlock()
+
+	if !ok {
+		return api.ErrNotFound
+	}
+	return api.Render(w, r, resp)
+}
+
+func (h *Backups) run(b *backupJSON) {
+	start := time.Now()
+	manifest, err := h.Create(b.Path)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err != nil {
+		h.Logger.Error("could not create backup", zap.String("path", b.Path), zap.Error(err))
+		b.Status = backupStatusFailed
+		b.Error = err.Error()
+		return
+	}
+	h.Logger.Info("created backup", zap.String("path", b.Path), zap.Int64("size", manifest.Size), zap.Duration("took", time.Since(start)))
+	b.Status = backupStatusCompleted
+	b.Manifest = manifest
+}
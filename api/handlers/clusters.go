@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+)
+
+type Clusters struct {
+	Shares registrystorage.Shares
+}
+
+func (h *Clusters) Get(w http.ResponseWriter, r *http.Request) error {
+	var owner api.Hex
+	if err := owner.Bind(chi.URLParam(r, "owner")); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if len(owner) != common.AddressLength {
+		return api.InvalidRequestError(errors.New("invalid owner address"))
+	}
+	var operators api.Uint64Slice
+	if err := operators.Bind(chi.URLParam(r, "operators")); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if len(operators) == 0 {
+		return api.InvalidRequestError(errors.New("no operators given"))
+	}
+	clusterID, err := types.ComputeClusterIDHash(owner, operators)
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	shares := h.Shares.List(registrystorage.ByClusterID(clusterID))
+	if len(shares) == 0 {
+		return api.ErrNotFound
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		return bytes.Compare(shares[i].ValidatorPubKey, shares[j].ValidatorPubKey) < 0
+	})
+
+	var response struct {
+		Data *clusterJSON `json:"data"`
+	}
+	response.Data = &clusterJSON{
+		ID:         api.Hex(clusterID),
+		Owner:      owner,
+		Operators:  operators,
+		Validators: make([]*validatorJSON, len(shares)),
+	}
+	for i, share := range shares {
+		response.Data.Validators[i] = validatorFromShare(share)
+		if share.Liquidated {
+			response.Data.Liquidated = true
+		}
+		if share.HasBeaconMetadata() && share.BeaconMetadata.IsActive() {
+			response.Data.ActiveValidators++
+		}
+	}
+	return api.Render(w, r, response)
+}
+
+type clusterJSON struct {
+	ID               api.Hex          `json:"id"`
+	Owner            api.Hex          `json:"owner"`
+	Operators        []uint64         `json:"operators"`
+	Liquidated       bool             `json:"liquidated"`
+	ActiveValidators int              `json:"active_validators"`
+	Validators       []*validatorJSON `json:"validators"`
+}
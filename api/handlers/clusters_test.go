@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/logging"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestClustersGet(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.New(logger, basedb.Options{Type: "badger-memory", Path: ""})
+	require.NoError(t, err)
+	defer db.Close(logger)
+
+	owner := common.Address{1}
+	committee := []*spectypes.Operator{{OperatorID: 1}, {OperatorID: 2}, {OperatorID: 3}, {OperatorID: 4}}
+	shares, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+	for i, status := range []eth2apiv1.ValidatorState{eth2apiv1.ValidatorStateActiveOngoing, eth2apiv1.ValidatorStatePendingQueued} {
+		pk := make([]byte, 48)
+		pk[0] = byte(i)
+		require.NoError(t, shares.Save(&types.SSVShare{
+			Share: spectypes.Share{ValidatorPubKey: pk, Committee: committee},
+			Metadata: types.Metadata{
+				OwnerAddress:   owner,
+				BeaconMetadata: &beaconprotocol.ValidatorMetadata{Index: 1, Status: status},
+			},
+		}))
+	}
+
+	router := chi.NewRouter()
+	router.Get("/v1/clusters/{owner}/{operators}", api.Handler((&Clusters{Shares: shares}).Get))
+	get := func(owner, operators string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/clusters/"+owner+"/"+operators, nil))
+		return rec
+	}
+
+	rec := get(hex.EncodeToString(owner.Bytes()), "1,2,3,4")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var response struct {
+		Data struct {
+			ID               api.Hex  `json:"id"`
+			Operators        []uint64 `json:"operators"`
+			ActiveValidators int      `json:"active_validators"`
+			Validators       []struct {
+				PubKey api.Hex `json:"public_key"`
+			} `json:"validators"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	clusterID, err := types.ComputeClusterIDHash(owner.Bytes(), []uint64{1, 2, 3, 4})
+	require.NoError(t, err)
+	require.Equal(t, api.Hex(clusterID), response.Data.ID)
+	require.Equal(t, []uint64{1, 2, 3, 4}, response.Data.Operators)
+	require.Equal(t, 1, response.Data.ActiveValidators)
+	require.Len(t, response.Data.Validators, 2)
+
+	t.Run("not found", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, get(hex.EncodeToString(owner.Bytes()), "1,2,3,5").Code)
+		require.Equal(t, http.StatusNotFound, get(hex.EncodeToString(common.Address{2}.Bytes()), "1,2,3,4").Code)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, get("not-hex", "1,2,3,4").Code)
+		require.Equal(t, http.StatusBadRequest, get("0102", "1,2,3,4").Code)
+		require.Equal(t, http.StatusBadRequest, get(hex.EncodeToString(owner.Bytes()), "a,b").Code)
+	})
+}
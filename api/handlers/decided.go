@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/protocol/v2/message"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+const (
+	// maxDecidedRange is the maximum amount of heights that can be scanned in a single request.
+	maxDecidedRange = 10000
+	// defaultDecidedLimit is the amount of decided instances returned when no limit is given.
+	defaultDecidedLimit = 1000
+	// decidedFlushInterval is the amount of lines written between flushes.
+	decidedFlushInterval = 64
+)
+
+// Decided streams the decided instances of a validator in the given height range as NDJSON,
+// one decided message per line, ordered by height.
+func (h *Validators) Decided(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		Role     string          `json:"role" form:"role"`
+		From     uint64          `json:"from" form:"from"`
+		To       uint64          `json:"to" form:"to"`
+		Signers  api.Uint64Slice `json:"signers" form:"signers"`
+		MinRound uint64          `json:"min_round" form:"min_round"`
+		Limit    int             `json:"limit" form:"limit"`
+	}
+
+	var pubKey api.Hex
+	if err := pubKey.Bind(chi.URLParam(r, "pubkey")); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if len(pubKey) != 48 {
+		return api.InvalidRequestError(fmt.Errorf("invalid validator public key length %d", len(pubKey)))
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	role, err := message.BeaconRoleFromString(request.Role)
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if request.To < request.From {
+		return api.InvalidRequestError(fmt.Errorf("'to' (%d) is lower than 'from' (%d)", request.To, request.From))
+	}
+	if request.To-request.From >= maxDecidedRange {
+		return api.InvalidRequestError(fmt.Errorf("height range is limited to %d", maxDecidedRange))
+	}
+	if request.Limit < 0 || request.Limit > maxDecidedRange {
+		return api.InvalidRequestError(fmt.Errorf("limit must be between 0 and %d", maxDecidedRange))
+	}
+	if request.Limit == 0 {
+		request.Limit = defaultDecidedLimit
+	}
+	if h.Storage == nil {
+		return api.Error(fmt.Errorf("decided storage is not available"))
+	}
+	store := h.Storage.Get(role)
+	if store == nil {
+		return api.Error(fmt.Errorf("storage for role %s is not available", role))
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+
+	// decided instances are read one by one, so the response is never held in memory
+	msgID := spectypes.NewMsgID(types.GetDefaultDomain(), pubKey, role)
+	written := 0
+	for height := request.From; height <= request.To && written < request.Limit; height++ {
+		if r.Context().Err() != nil {
+			return nil
+		}
+		instance, err := store.GetInstance(msgID[:], specqbft.Height(height))
+		if err != nil {
+			// the status code was already sent, so the error is reported in-band
+			_ = encoder.Encode(&decidedErrorJSON{Error: fmt.Sprintf("could not get decided instance at height %d: %s", height, err)})
+			return nil
+		}
+		if instance == nil || instance.DecidedMessage == nil {
+			continue
+		}
+		decided := instance.DecidedMessage
+		if uint64(decided.Message.Round) < request.MinRound || !hasAnySigner(decided, request.Signers) {
+			continue
+		}
+		if err := encoder.Encode(decidedFromMessage(decided)); err != nil {
+			// the client is gone
+			return nil
+		}
+		written++
+		if flusher != nil && written%decidedFlushInterval == 0 {
+			flusher.Flush()
+		}
+	}
+	return nil
+}
+
+// hasAnySigner returns true if any of the given signers signed the message, or if no signers are given.
+func hasAnySigner(msg *specqbft.SignedMessage, signers []uint64) bool {
+	if len(signers) == 0 {
+		return true
+	}
+	for _, signer := range signers {
+		for _, s := range msg.Signers {
+			if s == signer {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type decidedJSON struct {
+	Height    specqbft.Height        `json:"height"`
+	Round     specqbft.Round         `json:"round"`
+	Signers   []spectypes.OperatorID `json:"signers"`
+	Signature api.Hex                `json:"signature"`
+	Root      api.Hex                `json:"root"`
+	FullData  api.Hex                `json:"full_data"`
+}
+
+type decidedErrorJSON struct {
+	Error string `json:"error"`
+}
+
+func decidedFromMessage(msg *specqbft.SignedMessage) *decidedJSON {
+	return &decidedJSON{
+		Height:    msg.Message.Height,
+		Round:     msg.Message.Round,
+		Signers:   msg.Signers,
+		Signature: api.Hex(msg.Signature),
+		Root:      api.Hex(msg.Message.Root[:]),
+		FullData:  api.Hex(msg.FullData),
+	}
+}
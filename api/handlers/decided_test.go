@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/ibft/storage"
+	"github.com/bloxapp/ssv/logging"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestValidatorsDecided(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.New(logger, basedb.Options{Type: "badger-memory", Path: ""})
+	require.NoError(t, err)
+	defer db.Close(logger)
+
+	stores := storage.NewStoresFromRoles(db, spectypes.BNRoleAttester)
+	pk := make([]byte, 48)
+	msgID := spectypes.NewMsgID(types.GetDefaultDomain(), pk, spectypes.BNRoleAttester)
+	for h := specqbft.Height(1); h <= 10; h++ {
+		// every third instance is decided in round 2, signers rotate between operators
+		round := specqbft.FirstRound
+		if h%3 == 0 {
+			round = 2
+		}
+		require.NoError(t, stores.Get(spectypes.BNRoleAttester).SaveInstance(&qbftstorage.StoredInstance{
+			State: &specqbft.State{ID: msgID[:], Height: h, Round: round},
+			DecidedMessage: &specqbft.SignedMessage{
+				Signature: []byte("sig"),
+				Signers:   []spectypes.OperatorID{spectypes.OperatorID(h%4 + 1), spectypes.OperatorID((h+1)%4 + 1)},
+				Message: specqbft.Message{
+					MsgType:    specqbft.CommitMsgType,
+					Height:     h,
+					Round:      round,
+					Identifier: msgID[:],
+				},
+			},
+		}))
+	}
+
+	router := chi.NewRouter()
+	router.Get("/v1/validators/{pubkey}/decided", api.Handler((&Validators{Storage: stores}).Decided))
+
+	query := func(params string) (int, []*decidedJSON) {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/validators/%s/decided?%s", hex.EncodeToString(pk), params), nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			return rec.Code, nil
+		}
+		require.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+
+		var decided []*decidedJSON
+		scanner := bufio.NewScanner(rec.Body)
+		for scanner.Scan() {
+			d := &decidedJSON{}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), d))
+			decided = append(decided, d)
+		}
+		return rec.Code, decided
+	}
+	heights := func(decided []*decidedJSON) []specqbft.Height {
+		var res []specqbft.Height
+		for _, d := range decided {
+			res = append(res, d.Height)
+		}
+		return res
+	}
+
+	t.Run("range", func(t *testing.T) {
+		code, decided := query("role=ATTESTER&from=2&to=5")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, []specqbft.Height{2, 3, 4, 5}, heights(decided))
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		_, decided := query("role=ATTESTER&from=0&to=100&limit=3")
+		require.Equal(t, []specqbft.Height{1, 2, 3}, heights(decided))
+	})
+
+	t.Run("min round", func(t *testing.T) {
+		_, decided := query("role=ATTESTER&from=0&to=100&min_round=2")
+		require.Equal(t, []specqbft.Height{3, 6, 9}, heights(decided))
+	})
+
+	t.Run("signers", func(t *testing.T) {
+		_, decided := query("role=ATTESTER&from=0&to=100&signers=1")
+		require.Equal(t, []specqbft.Height{3, 4, 7, 8}, heights(decided))
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, params := range []string{
+			"role=FOO&from=0&to=1",
+			"role=ATTESTER&from=5&to=1",
+			fmt.Sprintf("role=ATTESTER&from=0&to=%d", maxDecidedRange),
+			"role=ATTESTER&from=0&to=1&limit=-1",
+		} {
+			code, _ := query(params)
+			require.Equal(t, http.StatusBadRequest, code, params)
+		}
+	})
+
+	t.Run("unavailable role", func(t *testing.T) {
+		code, _ := query("role=PROPOSER&from=0&to=1")
+		require.Equal(t, http.StatusInternalServerError, code)
+	})
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+)
+
+// Exits triggers voluntary exits and withdrawal credential changes of the node's validators.
+type Exits struct {
+	Shares  registrystorage.Shares
+	Network beacon.Network
+	// StartExit starts the voluntary exit duty of a validator at the given slot
+	StartExit func(pubKey phase0.BLSPubKey, slot phase0.Slot) error
+	// StartBLSToExecutionChange starts the bls to execution change duty of a validator at the given slot
+	StartBLSToExecutionChange func(pubKey phase0.BLSPubKey, slot phase0.Slot, address bellatrix.ExecutionAddress) error
+}
+
+// Exit starts a voluntary exit of a validator at the first slot of the given epoch,
+// which defaults to the current epoch. All the operators of the validator must be
+// triggered with the same epoch for their signatures to be combined.
+func (h *Exits) Exit(w http.ResponseWriter, r *http.Request) error {
+	var pubKey api.Hex
+	if err := pubKey.Bind(chi.URLParam(r, "pubkey")); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	var request struct {
+		Epoch uint64 `json:"epoch" form:"epoch"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	share := h.Shares.Get(pubKey)
+	if share == nil {
+		return api.ErrNotFound
+	}
+
+	epoch, slot, err := h.dutySlot(request.Epoch)
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	var blsPubKey phase0.BLSPubKey
+	copy(blsPubKey[:], share.ValidatorPubKey)
+	if err := h.StartExit(blsPubKey, slot); err != nil {
+		return api.Error(err)
+	}
+	return renderDuty(w, r, epoch, slot)
+}
+
+// BLSToExecutionChange changes the BLS withdrawal credentials of a validator to the given
+// execution address at the first slot of the given epoch, which defaults to the current epoch.
+// Like exits, all the operators of the validator must be triggered with the same epoch and address.
+func (h *Exits) BLSToExecutionChange(w http.ResponseWriter, r *http.Request) error {
+	var pubKey api.Hex
+	if err := pubKey.Bind(chi.URLParam(r, "pubkey")); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	var request struct {
+		Epoch   uint64  `json:"epoch" form:"epoch"`
+		Address api.Hex `json:"address" form:"address"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	var address bellatrix.ExecutionAddress
+	if len(request.Address) != len(address) {
+		return api.InvalidRequestError(fmt.Errorf("invalid execution address length %d", len(request.Address)))
+	}
+	copy(address[:], request.Address)
+
+	share := h.Shares.Get(pubKey)
+	if share == nil {
+		return api.ErrNotFound
+	}
+
+	epoch, slot, err := h.dutySlot(request.Epoch)
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	var blsPubKey phase0.BLSPubKey
+	copy(blsPubKey[:], share.ValidatorPubKey)
+	if err := h.StartBLSToExecutionChange(blsPubKey, slot, address); err != nil {
+		return api.Error(err)
+	}
+	return renderDuty(w, r, epoch, slot)
+}
+
+// dutySlot returns the first slot of the given epoch (or the current epoch when zero),
+// making sure it's recent enough for the operators to still run the duty.
+func (h *Exits) dutySlot(requested uint64) (phase0.Epoch, phase0.Slot, error) {
+	epoch := phase0.Epoch(requested)
+	if epoch == 0 {
+		epoch = h.Network.EstimatedCurrentEpoch()
+	}
+	slot := h.Network.GetEpochFirstSlot(epoch)
+	currentSlot := h.Network.EstimatedCurrentSlot()
+	if slot > currentSlot {
+		return 0, 0, fmt.Errorf("epoch %d is in the future", epoch)
+	}
+	if currentSlot > slot+phase0.Slot(h.Network.SlotsPerEpoch()) {
+		return 0, 0, fmt.Errorf("epoch %d is too old", epoch)
+	}
+	return epoch, slot, nil
+}
+
+func renderDuty(w http.ResponseWriter, r *http.Request, epoch phase0.Epoch, slot phase0.Slot) error {
+	var response struct {
+		Epoch phase0.Epoch `json:"epoch"`
+		Slot  phase0.Slot  `json:"slot"`
+	}
+	response.Epoch = epoch
+	response.Slot = slot
+	render.Status(r, http.StatusAccepted)
+	return api.Render(w, r, response)
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/logging"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestExit(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.New(logger, basedb.Options{Type: "badger-memory", Path: ""})
+	require.NoError(t, err)
+	defer db.Close(logger)
+
+	shares, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+	pk := make([]byte, 48)
+	pk[0] = 1
+	require.NoError(t, shares.Save(&types.SSVShare{
+		Share: spectypes.Share{ValidatorPubKey: pk},
+	}))
+
+	network := beaconprotocol.NewNetwork(spectypes.PraterNetwork)
+	var exited []phase0.Slot
+	h := &Exits{
+		Shares:  shares,
+		Network: network,
+		StartExit: func(pubKey phase0.BLSPubKey, slot phase0.Slot) error {
+			require.Equal(t, pk, pubKey[:])
+			exited = append(exited, slot)
+			return nil
+		},
+	}
+	router := chi.NewRouter()
+	router.Post("/v1/validators/{pubkey}/exit", api.Handler(h.Exit))
+
+	exit := func(pubKey []byte, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/validators/%s/exit?%s", hex.EncodeToString(pubKey), query), nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("current epoch", func(t *testing.T) {
+		rec := exit(pk, "")
+		require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+		var response struct {
+			Epoch phase0.Epoch `json:"epoch"`
+			Slot  phase0.Slot  `json:"slot"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Equal(t, network.GetEpochFirstSlot(response.Epoch), response.Slot)
+		require.Equal(t, []phase0.Slot{response.Slot}, exited)
+	})
+
+	t.Run("future epoch", func(t *testing.T) {
+		rec := exit(pk, fmt.Sprintf("epoch=%d", network.EstimatedCurrentEpoch()+2))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("old epoch", func(t *testing.T) {
+		rec := exit(pk, fmt.Sprintf("epoch=%d", network.EstimatedCurrentEpoch()-2))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("unknown validator", func(t *testing.T) {
+		rec := exit(make([]byte, 48), "")
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	require.Len(t, exited, 1)
+}
+
+func TestBLSToExecutionChange(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.New(logger, basedb.Options{Type: "badger-memory", Path: ""})
+	require.NoError(t, err)
+	defer db.Close(logger)
+
+	shares, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+	pk := make([]byte, 48)
+	pk[0] = 1
+	require.NoError(t, shares.Save(&types.SSVShare{
+		Share: spectypes.Share{ValidatorPubKey: pk},
+	}))
+
+	network := beaconprotocol.NewNetwork(spectypes.PraterNetwork)
+	address := bellatrix.ExecutionAddress{1, 2, 3}
+	var changed []phase0.Slot
+	h := &Exits{
+		Shares:  shares,
+		Network: network,
+		StartBLSToExecutionChange: func(pubKey phase0.BLSPubKey, slot phase0.Slot, to bellatrix.ExecutionAddress) error {
+			require.Equal(t, pk, pubKey[:])
+			require.Equal(t, address, to)
+			changed = append(changed, slot)
+			return nil
+		},
+	}
+	router := chi.NewRouter()
+	router.Post("/v1/validators/{pubkey}/bls-to-execution-change", api.Handler(h.BLSToExecutionChange))
+
+	change := func(pubKey []byte, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/validators/%s/bls-to-execution-change?%s", hex.EncodeToString(pubKey), query), nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	addressQuery := "address=" + hex.EncodeToString(address[:])
+
+	t.Run("current epoch", func(t *testing.T) {
+		rec := change(pk, addressQuery)
+		require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+		var response struct {
+			Epoch phase0.Epoch `json:"epoch"`
+			Slot  phase0.Slot  `json:"slot"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Equal(t, network.GetEpochFirstSlot(response.Epoch), response.Slot)
+		require.Equal(t, []phase0.Slot{response.Slot}, changed)
+	})
+
+	t.Run("missing address", func(t *testing.T) {
+		rec := change(pk, "")
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("short address", func(t *testing.T) {
+		rec := change(pk, "address=0102")
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("future epoch", func(t *testing.T) {
+		rec := change(pk, fmt.Sprintf("%s&epoch=%d", addressQuery, network.EstimatedCurrentEpoch()+2))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("unknown validator", func(t *testing.T) {
+		rec := change(make([]byte, 48), addressQuery)
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	require.Len(t, changed, 1)
+}
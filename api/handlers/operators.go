@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/api"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+)
+
+type Operators struct {
+	Logger    *zap.Logger
+	Operators registrystorage.Operators
+}
+
+func (h *Operators) List(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		From  uint64 `json:"from" form:"from"`
+		Limit int    `json:"limit" form:"limit"`
+	}
+	var response struct {
+		Data       []*operatorJSON `json:"data"`
+		Pagination struct {
+			Next uint64 `json:"next,omitempty"`
+		} `json:"pagination"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if request.Limit < 0 || request.Limit > maxPageLimit {
+		return api.InvalidRequestError(fmt.Errorf("limit must be between 0 and %d", maxPageLimit))
+	}
+
+	// operator IDs are sparse, so we list all of them and paginate in memory
+	operators, err := h.Operators.ListOperators(h.Logger, 0, 0)
+	if err != nil {
+		return err
+	}
+	sort.Slice(operators, func(i, j int) bool { return operators[i].ID < operators[j].ID })
+
+	response.Data = make([]*operatorJSON, 0, len(operators))
+	for _, od := range operators {
+		if od.ID < request.From {
+			continue
+		}
+		if request.Limit > 0 && len(response.Data) == request.Limit {
+			response.Pagination.Next = od.ID
+			break
+		}
+		response.Data = append(response.Data, &operatorJSON{
+			ID:     od.ID,
+			PubKey: string(od.PublicKey),
+			Owner:  api.Hex(od.OwnerAddress.Bytes()),
+		})
+	}
+	return api.Render(w, r, response)
+}
+
+type operatorJSON struct {
+	ID     spectypes.OperatorID `json:"id"`
+	PubKey string               `json:"public_key"`
+	Owner  api.Hex              `json:"owner"`
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/logging"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestOperatorsList(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.New(logger, basedb.Options{Type: "badger-memory", Path: ""})
+	require.NoError(t, err)
+	defer db.Close(logger)
+
+	operators := registrystorage.NewOperatorsStorage(db, []byte("test"))
+	// operator IDs are sparse
+	for _, id := range []spectypes.OperatorID{7, 1, 3, 12} {
+		_, err := operators.SaveOperatorData(logger, &registrystorage.OperatorData{
+			ID:           id,
+			PublicKey:    []byte{byte(id)},
+			OwnerAddress: common.Address{byte(id)},
+		})
+		require.NoError(t, err)
+	}
+	h := &Operators{Logger: logger, Operators: operators}
+
+	list := func(query string) (int, []spectypes.OperatorID, uint64) {
+		rec := httptest.NewRecorder()
+		api.Handler(h.List)(rec, httptest.NewRequest(http.MethodGet, "/v1/operators?"+query, nil))
+		if rec.Code != http.StatusOK {
+			return rec.Code, nil, 0
+		}
+		var response struct {
+			Data []struct {
+				ID    spectypes.OperatorID `json:"id"`
+				Owner api.Hex              `json:"owner"`
+			} `json:"data"`
+			Pagination struct {
+				Next uint64 `json:"next"`
+			} `json:"pagination"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		var ids []spectypes.OperatorID
+		for _, op := range response.Data {
+			require.Equal(t, api.Hex(common.Address{byte(op.ID)}.Bytes()), op.Owner)
+			ids = append(ids, op.ID)
+		}
+		return rec.Code, ids, response.Pagination.Next
+	}
+
+	code, ids, next := list("")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []spectypes.OperatorID{1, 3, 7, 12}, ids)
+	require.Zero(t, next)
+
+	_, ids, next = list("limit=2")
+	require.Equal(t, []spectypes.OperatorID{1, 3}, ids)
+	require.Equal(t, uint64(7), next)
+
+	_, ids, next = list("limit=2&from=7")
+	require.Equal(t, []spectypes.OperatorID{7, 12}, ids)
+	require.Zero(t, next)
+
+	_, ids, _ = list("from=13")
+	require.Empty(t, ids)
+
+	for _, query := range []string{"limit=-1", "limit=100000", "from=abc"} {
+		code, _, _ := list(query)
+		require.Equal(t, http.StatusBadRequest, code, query)
+	}
+}
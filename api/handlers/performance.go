@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/operator/performance"
+)
+
+type PerformanceProvider interface {
+	Stats(logger *zap.Logger, epochs uint64) ([]*performance.OperatorStats, phase0.Epoch, phase0.Epoch, error)
+}
+
+type Performance struct {
+	Logger  *zap.Logger
+	Tracker PerformanceProvider
+}
+
+func (h *Performance) Operators(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		Epochs    uint64          `json:"epochs" form:"epochs"`
+		Operators api.Uint64Slice `json:"operators" form:"operators"`
+	}
+	var response struct {
+		FromEpoch phase0.Epoch               `json:"from_epoch"`
+		ToEpoch   phase0.Epoch               `json:"to_epoch"`
+		Data      []*operatorPerformanceJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	stats, from, to, err := h.Tracker.Stats(h.Logger, request.Epochs)
+	if err != nil {
+		return err
+	}
+	response.FromEpoch = from
+	response.ToEpoch = to
+	response.Data = make([]*operatorPerformanceJSON, 0, len(stats))
+	for _, st := range stats {
+		if len(request.Operators) > 0 && !containsOperator(request.Operators, st.OperatorID) {
+			continue
+		}
+		response.Data = append(response.Data, &operatorPerformanceJSON{
+			OperatorID:        st.OperatorID,
+			Role:              st.Role.String(),
+			Decided:           st.Decided,
+			Participated:      st.Participated,
+			Missed:            st.Missed,
+			ParticipationRate: st.ParticipationRate(),
+			AverageRounds:     st.AverageRounds(),
+		})
+	}
+	sort.Slice(response.Data, func(i, j int) bool {
+		if response.Data[i].OperatorID != response.Data[j].OperatorID {
+			return response.Data[i].OperatorID < response.Data[j].OperatorID
+		}
+		return response.Data[i].Role < response.Data[j].Role
+	})
+	return api.Render(w, r, response)
+}
+
+func containsOperator(operators []uint64, id spectypes.OperatorID) bool {
+	for _, op := range operators {
+		if op == id {
+			return true
+		}
+	}
+	return false
+}
+
+type operatorPerformanceJSON struct {
+	OperatorID        spectypes.OperatorID `json:"operator_id"`
+	Role              string               `json:"role"`
+	Decided           uint64               `json:"decided"`
+	Participated      uint64               `json:"participated"`
+	Missed            uint64               `json:"missed"`
+	ParticipationRate float64              `json:"participation_rate"`
+	AverageRounds     float64              `json:"average_rounds"`
+}
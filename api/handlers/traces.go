@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/protocol/v2/dutytrace"
+	"github.com/bloxapp/ssv/protocol/v2/message"
+)
+
+type TraceProvider interface {
+	Traces(logger *zap.Logger, slot phase0.Slot) ([]*dutytrace.Trace, error)
+}
+
+// Traces serves the recorded lifecycle of the duties of the node's validators.
+type Traces struct {
+	Logger   *zap.Logger
+	Recorder TraceProvider
+}
+
+func (h *Traces) List(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		Slot    uint64       `json:"slot" form:"slot"`
+		PubKeys api.HexSlice `json:"pubkeys" form:"pubkeys"`
+		Role    string       `json:"role" form:"role"`
+	}
+	var response struct {
+		Data []*traceJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if request.Slot == 0 {
+		return api.InvalidRequestError(errors.New("slot is required"))
+	}
+	if request.Role != "" {
+		if _, err := message.BeaconRoleFromString(request.Role); err != nil {
+			return api.InvalidRequestError(err)
+		}
+	}
+
+	traces, err := h.Recorder.Traces(h.Logger, phase0.Slot(request.Slot))
+	if err != nil {
+		return err
+	}
+	response.Data = make([]*traceJSON, 0, len(traces))
+	for _, trace := range traces {
+		role := message.RoleToString(trace.Role)
+		if request.Role != "" && request.Role != role {
+			continue
+		}
+		if len(request.PubKeys) > 0 && !containsPubKey(request.PubKeys, trace.PubKey) {
+			continue
+		}
+		response.Data = append(response.Data, &traceJSON{
+			PubKey:    trace.PubKey,
+			Role:      role,
+			Slot:      trace.Slot,
+			Events:    trace.Events,
+			Truncated: trace.Truncated,
+		})
+	}
+	sort.Slice(response.Data, func(i, j int) bool {
+		if c := bytes.Compare(response.Data[i].PubKey, response.Data[j].PubKey); c != 0 {
+			return c < 0
+		}
+		return response.Data[i].Role < response.Data[j].Role
+	})
+	return api.Render(w, r, response)
+}
+
+func containsPubKey(pubKeys []api.Hex, pubKey []byte) bool {
+	for _, pk := range pubKeys {
+		if bytes.Equal(pk, pubKey) {
+			return true
+		}
+	}
+	return false
+}
+
+type traceJSON struct {
+	PubKey    api.Hex           `json:"public_key"`
+	Role      string            `json:"role"`
+	Slot      phase0.Slot       `json:"slot"`
+	Events    []dutytrace.Event `json:"events"`
+	Truncated bool              `json:"truncated"`
+}
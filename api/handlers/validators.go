@@ -2,20 +2,49 @@ package handlers
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"net/http"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/go-chi/chi/v5"
+
 	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/ibft/storage"
+	"github.com/bloxapp/ssv/operator/doppelganger"
 	"github.com/bloxapp/ssv/protocol/v2/types"
 	registrystorage "github.com/bloxapp/ssv/registry/storage"
 )
 
+// maxPageLimit is the maximum amount of items that can be requested in a single page.
+const maxPageLimit = 1000
+
+// decidedRoles are the roles for which the latest decided heights are reported.
+var decidedRoles = []spectypes.BeaconRole{
+	spectypes.BNRoleAttester,
+	spectypes.BNRoleAggregator,
+	spectypes.BNRoleProposer,
+	spectypes.BNRoleSyncCommittee,
+	spectypes.BNRoleSyncCommitteeContribution,
+	spectypes.BNRoleValidatorRegistration,
+}
+
+// DoppelgangerProvider reports the doppelganger protection state of validators
+type DoppelgangerProvider interface {
+	Status(index phase0.ValidatorIndex) (doppelganger.ValidatorStatus, bool)
+}
+
 type Validators struct {
 	Shares registrystorage.Shares
+	// Storage is used to look up the latest decided heights, optional.
+	Storage *storage.QBFTStores
+	// Doppelganger is used to look up the doppelganger protection state, optional.
+	Doppelganger DoppelgangerProvider
 }
 
 func (h *Validators) List(w http.ResponseWriter, r *http.Request) error {
@@ -25,13 +54,25 @@ func (h *Validators) List(w http.ResponseWriter, r *http.Request) error {
 		Clusters  requestClusters `json:"clusters" form:"clusters"`
 		PubKeys   api.HexSlice    `json:"pubkeys" form:"pubkeys"`
 		Indices   api.Uint64Slice `json:"indices" form:"indices"`
+		Sort      string          `json:"sort" form:"sort"`
+		Order     string          `json:"order" form:"order"`
+		Cursor    api.Hex         `json:"cursor" form:"cursor"`
+		Limit     int             `json:"limit" form:"limit"`
 	}
 	var response struct {
-		Data []*validatorJSON `json:"data"`
+		Data       []*validatorJSON `json:"data"`
+		Pagination paginationJSON   `json:"pagination"`
 	}
 
 	if err := api.Bind(r, &request); err != nil {
-		return err
+		return api.InvalidRequestError(err)
+	}
+	if request.Limit < 0 || request.Limit > maxPageLimit {
+		return api.InvalidRequestError(fmt.Errorf("limit must be between 0 and %d", maxPageLimit))
+	}
+	less, err := sharesOrder(request.Sort, request.Order)
+	if err != nil {
+		return api.InvalidRequestError(err)
 	}
 
 	var filters []registrystorage.SharesFilter
@@ -52,13 +93,130 @@ func (h *Validators) List(w http.ResponseWriter, r *http.Request) error {
 	}
 
 	shares := h.Shares.List(filters...)
+	sort.Slice(shares, func(i, j int) bool { return less(shares[i], shares[j]) })
+	response.Pagination.Total = len(shares)
+
+	if len(request.Cursor) > 0 {
+		cursor := h.Shares.Get(request.Cursor)
+		if cursor == nil {
+			return api.InvalidRequestError(errors.New("cursor validator not found"))
+		}
+		// skip everything up to and including the cursor
+		start := sort.Search(len(shares), func(i int) bool { return less(cursor, shares[i]) })
+		shares = shares[start:]
+	}
+	if request.Limit > 0 && len(shares) > request.Limit {
+		shares = shares[:request.Limit]
+		response.Pagination.NextCursor = api.Hex(shares[len(shares)-1].ValidatorPubKey)
+	}
+
 	response.Data = make([]*validatorJSON, len(shares))
 	for i, share := range shares {
-		response.Data[i] = validatorFromShare(share)
+		response.Data[i] = h.validator(share)
 	}
 	return api.Render(w, r, response)
 }
 
+func (h *Validators) Get(w http.ResponseWriter, r *http.Request) error {
+	var pubKey api.Hex
+	if err := pubKey.Bind(chi.URLParam(r, "pubkey")); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	share := h.Shares.Get(pubKey)
+	if share == nil {
+		return api.ErrNotFound
+	}
+
+	var response struct {
+		Data *validatorDetailsJSON `json:"data"`
+	}
+	response.Data = &validatorDetailsJSON{
+		validatorJSON: h.validator(share),
+		FeeRecipient:  api.Hex(share.FeeRecipientAddress[:]),
+		Decided:       map[string]specqbft.Height{},
+	}
+	for _, op := range share.Committee {
+		response.Data.Operators = append(response.Data.Operators, &shareOperatorJSON{
+			ID:     op.OperatorID,
+			PubKey: api.Hex(op.PubKey),
+		})
+	}
+	if share.HasBeaconMetadata() {
+		response.Data.BeaconMetadata = &beaconMetadataJSON{
+			Index:   share.BeaconMetadata.Index,
+			Status:  share.BeaconMetadata.Status.String(),
+			Balance: share.BeaconMetadata.Balance,
+		}
+	}
+	if h.Storage != nil {
+		for _, role := range decidedRoles {
+			store := h.Storage.Get(role)
+			if store == nil {
+				continue
+			}
+			msgID := spectypes.NewMsgID(share.DomainType, share.ValidatorPubKey, role)
+			instance, err := store.GetHighestInstance(msgID[:])
+			if err != nil {
+				return err
+			}
+			if instance != nil && instance.State != nil {
+				response.Data.Decided[role.String()] = instance.State.Height
+			}
+		}
+	}
+	return api.Render(w, r, response)
+}
+
+// sharesOrder returns a comparison function for the given sort field and order,
+// ties are broken by the validator public key so that the order is total.
+func sharesOrder(field, order string) (func(a, b *types.SSVShare) bool, error) {
+	var compare func(a, b *types.SSVShare) int
+	switch field {
+	case "", "public_key":
+		compare = func(a, b *types.SSVShare) int { return 0 }
+	case "index":
+		compare = func(a, b *types.SSVShare) int {
+			switch {
+			case a.BeaconMetadata == nil && b.BeaconMetadata == nil:
+				return 0
+			case a.BeaconMetadata == nil:
+				return -1
+			case b.BeaconMetadata == nil:
+				return 1
+			case a.BeaconMetadata.Index < b.BeaconMetadata.Index:
+				return -1
+			case a.BeaconMetadata.Index > b.BeaconMetadata.Index:
+				return 1
+			}
+			return 0
+		}
+	case "owner":
+		compare = func(a, b *types.SSVShare) int { return bytes.Compare(a.OwnerAddress[:], b.OwnerAddress[:]) }
+	default:
+		return nil, fmt.Errorf("invalid sort field %q", field)
+	}
+
+	var desc bool
+	switch order {
+	case "", "asc":
+	case "desc":
+		desc = true
+	default:
+		return nil, fmt.Errorf("invalid sort order %q", order)
+	}
+
+	return func(a, b *types.SSVShare) bool {
+		c := compare(a, b)
+		if c == 0 {
+			c = bytes.Compare(a.ValidatorPubKey, b.ValidatorPubKey)
+		}
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	}, nil
+}
+
 func byOwners(owners []api.Hex) registrystorage.SharesFilter {
 	return func(share *types.SSVShare) bool {
 		for _, a := range owners {
@@ -145,6 +303,30 @@ func (c *requestClusters) Bind(value string) error {
 	return nil
 }
 
+type paginationJSON struct {
+	Total      int     `json:"total"`
+	NextCursor api.Hex `json:"next_cursor,omitempty"`
+}
+
+type shareOperatorJSON struct {
+	ID     spectypes.OperatorID `json:"id"`
+	PubKey api.Hex              `json:"share_public_key"`
+}
+
+type beaconMetadataJSON struct {
+	Index   phase0.ValidatorIndex `json:"index"`
+	Status  string                `json:"status"`
+	Balance phase0.Gwei           `json:"balance"`
+}
+
+type validatorDetailsJSON struct {
+	*validatorJSON
+	FeeRecipient   api.Hex                    `json:"fee_recipient"`
+	Operators      []*shareOperatorJSON       `json:"operators"`
+	BeaconMetadata *beaconMetadataJSON        `json:"beacon_metadata,omitempty"`
+	Decided        map[string]specqbft.Height `json:"decided_heights"`
+}
+
 type validatorJSON struct {
 	PubKey        api.Hex                `json:"public_key"`
 	Index         phase0.ValidatorIndex  `json:"index"`
@@ -155,6 +337,8 @@ type validatorJSON struct {
 	PartialQuorum uint64                 `json:"partial_quorum"`
 	Grafitti      string                 `json:"grafitti"`
 	Liquidated    bool                   `json:"liquidated"`
+	// Doppelganger is omitted when the doppelganger protection is disabled or the validator isn't running
+	Doppelganger *doppelganger.ValidatorStatus `json:"doppelganger,omitempty"`
 }
 
 func validatorFromShare(share *types.SSVShare) *validatorJSON {
@@ -179,3 +363,14 @@ func validatorFromShare(share *types.SSVShare) *validatorJSON {
 	}
 	return v
 }
+
+// validator returns the validator of the share along with its doppelganger protection state
+func (h *Validators) validator(share *types.SSVShare) *validatorJSON {
+	v := validatorFromShare(share)
+	if h.Doppelganger != nil && share.HasBeaconMetadata() {
+		if status, ok := h.Doppelganger.Status(v.Index); ok {
+			v.Doppelganger = &status
+		}
+	}
+	return v
+}
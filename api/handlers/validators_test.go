@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/ibft/storage"
+	"github.com/bloxapp/ssv/logging"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestValidatorsListPagination(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.New(logger, basedb.Options{Type: "badger-memory", Path: ""})
+	require.NoError(t, err)
+	defer db.Close(logger)
+
+	shares, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		pk := make([]byte, 48)
+		pk[0] = byte(i)
+		require.NoError(t, shares.Save(&types.SSVShare{
+			Share: spectypes.Share{
+				ValidatorPubKey: pk,
+				Committee:       []*spectypes.Operator{{OperatorID: 1}, {OperatorID: 2}, {OperatorID: 3}, {OperatorID: 4}},
+			},
+			Metadata: types.Metadata{
+				// indices are in reverse order of public keys
+				BeaconMetadata: &beaconprotocol.ValidatorMetadata{Index: phase0.ValidatorIndex(10 - i), Status: eth2apiv1.ValidatorStateActiveOngoing},
+			},
+		}))
+	}
+	h := &Validators{Shares: shares}
+
+	list := func(query string) (indices []phase0.ValidatorIndex, total int, next string) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/validators?"+query, nil)
+		rec := httptest.NewRecorder()
+		api.Handler(h.List)(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var response struct {
+			Data []struct {
+				Index phase0.ValidatorIndex `json:"index"`
+			} `json:"data"`
+			Pagination struct {
+				Total      int    `json:"total"`
+				NextCursor string `json:"next_cursor"`
+			} `json:"pagination"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		for _, v := range response.Data {
+			indices = append(indices, v.Index)
+		}
+		return indices, response.Pagination.Total, response.Pagination.NextCursor
+	}
+
+	t.Run("no limit", func(t *testing.T) {
+		indices, total, next := list("")
+		require.Equal(t, []phase0.ValidatorIndex{10, 9, 8, 7, 6}, indices)
+		require.Equal(t, 5, total)
+		require.Empty(t, next)
+	})
+
+	t.Run("cursor pages", func(t *testing.T) {
+		indices, total, next := list("sort=index&limit=2")
+		require.Equal(t, []phase0.ValidatorIndex{6, 7}, indices)
+		require.Equal(t, 5, total)
+		require.NotEmpty(t, next)
+
+		indices, _, next = list("sort=index&limit=2&cursor=" + next)
+		require.Equal(t, []phase0.ValidatorIndex{8, 9}, indices)
+		require.NotEmpty(t, next)
+
+		indices, _, next = list("sort=index&limit=2&cursor=" + next)
+		require.Equal(t, []phase0.ValidatorIndex{10}, indices)
+		require.Empty(t, next)
+	})
+
+	t.Run("descending", func(t *testing.T) {
+		indices, _, _ := list("sort=index&order=desc&limit=3")
+		require.Equal(t, []phase0.ValidatorIndex{10, 9, 8}, indices)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, query := range []string{"sort=foo", "order=up", "limit=-1", "limit=100000", "cursor=00"} {
+			req := httptest.NewRequest(http.MethodGet, "/v1/validators?"+query, nil)
+			rec := httptest.NewRecorder()
+			api.Handler(h.List)(rec, req)
+			require.Equal(t, http.StatusBadRequest, rec.Code, query)
+		}
+	})
+}
+
+func TestValidatorsGet(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.New(logger, basedb.Options{Type: "badger-memory", Path: ""})
+	require.NoError(t, err)
+	defer db.Close(logger)
+
+	shares, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+	pk := make([]byte, 48)
+	pk[0] = 1
+	require.NoError(t, shares.Save(&types.SSVShare{
+		Share: spectypes.Share{
+			ValidatorPubKey: pk,
+			DomainType:      types.GetDefaultDomain(),
+			Committee:       []*spectypes.Operator{{OperatorID: 1, PubKey: []byte{1}}, {OperatorID: 2, PubKey: []byte{2}}},
+		},
+		Metadata: types.Metadata{
+			BeaconMetadata: &beaconprotocol.ValidatorMetadata{Index: 5, Status: eth2apiv1.ValidatorStateActiveOngoing, Balance: 32},
+		},
+	}))
+
+	stores := storage.NewStoresFromRoles(db, spectypes.BNRoleAttester)
+	msgID := spectypes.NewMsgID(types.GetDefaultDomain(), pk, spectypes.BNRoleAttester)
+	require.NoError(t, stores.Get(spectypes.BNRoleAttester).SaveHighestInstance(&qbftstorage.StoredInstance{
+		State: &specqbft.State{ID: msgID[:], Height: 7},
+		DecidedMessage: &specqbft.SignedMessage{
+			Signature: []byte("sig"),
+			Signers:   []spectypes.OperatorID{1},
+			Message:   specqbft.Message{MsgType: specqbft.CommitMsgType, Height: 7, Identifier: msgID[:]},
+		},
+	}))
+
+	router := chi.NewRouter()
+	router.Get("/v1/validators/{pubkey}", api.Handler((&Validators{Shares: shares, Storage: stores}).Get))
+	get := func(pubKey string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/validators/"+pubKey, nil))
+		return rec
+	}
+
+	rec := get(hex.EncodeToString(pk))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var response struct {
+		Data struct {
+			Index     phase0.ValidatorIndex `json:"index"`
+			Operators []struct {
+				ID spectypes.OperatorID `json:"id"`
+			} `json:"operators"`
+			BeaconMetadata struct {
+				Status  string      `json:"status"`
+				Balance phase0.Gwei `json:"balance"`
+			} `json:"beacon_metadata"`
+			Decided map[string]specqbft.Height `json:"decided_heights"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Equal(t, phase0.ValidatorIndex(5), response.Data.Index)
+	require.Len(t, response.Data.Operators, 2)
+	require.Equal(t, eth2apiv1.ValidatorStateActiveOngoing.String(), response.Data.BeaconMetadata.Status)
+	require.Equal(t, phase0.Gwei(32), response.Data.BeaconMetadata.Balance)
+	require.Equal(t, map[string]specqbft.Height{spectypes.BNRoleAttester.String(): 7}, response.Data.Decided)
+
+	unknown := make([]byte, 48)
+	unknown[0] = 2
+	require.Equal(t, http.StatusNotFound, get(hex.EncodeToString(unknown)).Code)
+	require.Equal(t, http.StatusBadRequest, get("not-hex").Code)
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"crypto/subtle"
 	"net/http"
 	"runtime"
 	"time"
@@ -16,8 +17,17 @@ type Server struct {
 	logger *zap.Logger
 	addr   string
 
-	node       *handlers.Node
-	validators *handlers.Validators
+	node        *handlers.Node
+	validators  *handlers.Validators
+	operators   *handlers.Operators
+	clusters    *handlers.Clusters
+	performance *handlers.Performance
+	backups     *handlers.Backups
+	exits       *handlers.Exits
+	traces      *handlers.Traces
+
+	// token authenticates the endpoints which act on the node's validators or db
+	token string
 }
 
 func New(
@@ -25,12 +35,26 @@ func New(
 	addr string,
 	node *handlers.Node,
 	validators *handlers.Validators,
+	operators *handlers.Operators,
+	clusters *handlers.Clusters,
+	performance *handlers.Performance,
+	backups *handlers.Backups,
+	exits *handlers.Exits,
+	traces *handlers.Traces,
+	token string,
 ) *Server {
 	return &Server{
-		logger:     logger,
-		addr:       addr,
-		node:       node,
-		validators: validators,
+		logger:      logger,
+		addr:        addr,
+		node:        node,
+		validators:  validators,
+		operators:   operators,
+		clusters:    clusters,
+		performance: performance,
+		backups:     backups,
+		exits:       exits,
+		traces:      traces,
+		token:       token,
 	}
 }
 
@@ -45,6 +69,22 @@ func (s *Server) Run() error {
 	router.Get("/v1/node/peers", api.Handler(s.node.Peers))
 	router.Get("/v1/node/topics", api.Handler(s.node.Topics))
 	router.Get("/v1/validators", api.Handler(s.validators.List))
+	router.Get("/v1/validators/{pubkey}", api.Handler(s.validators.Get))
+	router.Get("/v1/validators/{pubkey}/decided", api.Handler(s.validators.Decided))
+	router.Get("/v1/operators", api.Handler(s.operators.List))
+	router.Get("/v1/clusters/{owner}/{operators}", api.Handler(s.clusters.Get))
+	router.Get("/v1/performance/operators", api.Handler(s.performance.Operators))
+	if s.backups != nil && s.token != "" {
+		router.With(middlewareAuth(s.token)).Post("/v1/node/backups", api.Handler(s.backups.Start))
+		router.With(middlewareAuth(s.token)).Get("/v1/node/backups/{name}", api.Handler(s.backups.Get))
+	}
+	if s.traces != nil {
+		router.Get("/v1/traces", api.Handler(s.traces.List))
+	}
+	if s.exits != nil && s.token != "" {
+		router.With(middlewareAuth(s.token)).Post("/v1/validators/{pubkey}/exit", api.Handler(s.exits.Exit))
+		router.With(middlewareAuth(s.token)).Post("/v1/validators/{pubkey}/bls-to-execution-change", api.Handler(s.exits.BLSToExecutionChange))
+	}
 
 	s.logger.Info("Serving SSV API", zap.String("addr", s.addr))
 
@@ -78,3 +118,19 @@ func middlewareLogger(logger *zap.Logger) func(next http.Handler) http.Handler {
 		return http.HandlerFunc(fn)
 	}
 }
+
+func middlewareAuth(token string) func(next http.Handler) http.Handler {
+	expected := []byte("Bearer " + token)
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+				api.Handler(func(w http.ResponseWriter, r *http.Request) error {
+					return api.ErrUnauthorized
+				})(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,316 @@
+package goclient
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/bloxapp/ssv/monitoring/metrics"
+	"github.com/bloxapp/ssv/nodeprobe"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+const (
+	// validatorDataBatchDelay is the time to wait for concurrent validator data lookups to join a batch
+	validatorDataBatchDelay = 50 * time.Millisecond
+	// validatorDataMaxBatch is the maximum amount of validators in a single request to the beacon node
+	validatorDataMaxBatch = 1000
+)
+
+const (
+	// cacheHit is a result served from the cache
+	cacheHit = "hit"
+	// cacheMiss is a result fetched from the beacon node
+	cacheMiss = "miss"
+	// cacheShared is a result of a request to the beacon node made for another caller
+	cacheShared = "shared"
+)
+
+var metricsBeaconCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "ssv_beacon_cache_requests_total",
+	Help: "Beacon node requests by call and result (hit, miss or shared)",
+}, []string{"call", "result"})
+
+// cachedNode is a beacon node which can be wrapped by a cachingClient
+type cachedNode interface {
+	beaconprotocol.BeaconNode
+	nodeprobe.StatusChecker
+	metrics.HealthCheckAgent
+}
+
+// cachingClient wraps a beacon node, serving the data shared by many validators from a per-slot cache,
+// deduplicating identical in-flight requests and batching validator data lookups.
+type cachingClient struct {
+	cachedNode
+
+	attestationData *slotCache[phase0.CommitteeIndex, ssz.Marshaler]
+	syncBlockRoots  *slotCache[struct{}, phase0.Root]
+	duties          singleflight.Group
+	validators      *validatorDataBatcher
+}
+
+// verifies that the client implements HealthCheckAgent and MultiNodeChecker
+var (
+	_ metrics.HealthCheckAgent   = &cachingClient{}
+	_ nodeprobe.MultiNodeChecker = &cachingClient{}
+)
+
+func newCachingClient(node cachedNode) *cachingClient {
+	return &cachingClient{
+		cachedNode:      node,
+		attestationData: newSlotCache[phase0.CommitteeIndex, ssz.Marshaler]("attestation_data"),
+		syncBlockRoots:  newSlotCache[struct{}, phase0.Root]("sync_message_block_root"),
+		validators:      newValidatorDataBatcher(node.GetValidatorData, validatorDataBatchDelay, validatorDataMaxBatch),
+	}
+}
+
+// NodeStatuses returns the statuses of the wrapped nodes, if there are several
+func (c *cachingClient) NodeStatuses() []nodeprobe.NodeStatus {
+	if multiNode, ok := c.cachedNode.(nodeprobe.MultiNodeChecker); ok {
+		return multiNode.NodeStatuses()
+	}
+	return nil
+}
+
+func (c *cachingClient) GetAttestationData(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (ssz.Marshaler, spec.DataVersion, error) {
+	data, err := c.attestationData.get(slot, committeeIndex, func() (ssz.Marshaler, error) {
+		data, _, err := c.cachedNode.GetAttestationData(slot, committeeIndex)
+		return data, err
+	})
+	if err != nil {
+		return nil, DataVersionNil, err
+	}
+	return data, spec.DataVersionPhase0, nil
+}
+
+func (c *cachingClient) GetSyncMessageBlockRoot(slot phase0.Slot) (phase0.Root, spec.DataVersion, error) {
+	root, err := c.syncBlockRoots.get(slot, struct{}{}, func() (phase0.Root, error) {
+		root, _, err := c.cachedNode.GetSyncMessageBlockRoot(slot)
+		return root, err
+	})
+	if err != nil {
+		return phase0.Root{}, DataVersionNil, err
+	}
+	return root, spec.DataVersionAltair, nil
+}
+
+func (c *cachingClient) GetDuties(logger *zap.Logger, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, error) {
+	res, err := doShared(&c.duties, "duties", dedupKey("duties", epoch, validatorIndices), func() (interface{}, error) {
+		return c.cachedNode.GetDuties(logger, epoch, validatorIndices)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]*spectypes.Duty), nil
+}
+
+func (c *cachingClient) SyncCommitteeDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.SyncCommitteeDuty, error) {
+	res, err := doShared(&c.duties, "sync_committee_duties", dedupKey("sync_committee_duties", epoch, validatorIndices), func() (interface{}, error) {
+		return c.cachedNode.SyncCommitteeDuties(epoch, validatorIndices)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]*eth2apiv1.SyncCommitteeDuty), nil
+}
+
+func (c *cachingClient) GetValidatorData(validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error) {
+	return c.validators.get(validatorPubKeys)
+}
+
+// dedupKey returns the key of a request for the given epoch and validators
+func dedupKey(call string, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) string {
+	b := make([]byte, 8*(len(validatorIndices)+1))
+	binary.LittleEndian.PutUint64(b, uint64(epoch))
+	for i, index := range validatorIndices {
+		binary.LittleEndian.PutUint64(b[8*(i+1):], uint64(index))
+	}
+	return call + "/" + string(b)
+}
+
+// doShared calls fn once for all concurrent callers of the same key
+func doShared(group *singleflight.Group, call, key string, fn func() (interface{}, error)) (interface{}, error) {
+	called := false
+	res, err, _ := group.Do(key, func() (interface{}, error) {
+		called = true
+		return fn()
+	})
+	if called {
+		metricsBeaconCacheRequests.WithLabelValues(call, cacheMiss).Inc()
+	} else {
+		metricsBeaconCacheRequests.WithLabelValues(call, cacheShared).Inc()
+	}
+	return res, err
+}
+
+// slotCache caches values per slot, fetching each value once for all concurrent callers.
+// Errors aren't cached, and values of slots older than the previous one are evicted.
+type slotCache[K comparable, V any] struct {
+	call  string
+	group singleflight.Group
+
+	mu   sync.Mutex
+	data map[phase0.Slot]map[K]V
+}
+
+func newSlotCache[K comparable, V any](call string) *slotCache[K, V] {
+	return &slotCache[K, V]{
+		call: call,
+		data: map[phase0.Slot]map[K]V{},
+	}
+}
+
+// get returns the cached value, or fetches it once for all concurrent callers
+func (c *slotCache[K, V]) get(slot phase0.Slot, key K, fetch func() (V, error)) (V, error) {
+	c.mu.Lock()
+	value, ok := c.data[slot][key]
+	c.mu.Unlock()
+	if ok {
+		metricsBeaconCacheRequests.WithLabelValues(c.call, cacheHit).Inc()
+		return value, nil
+	}
+
+	res, err := doShared(&c.group, c.call, fmt.Sprintf("%d/%v", slot, key), func() (interface{}, error) {
+		value, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		c.set(slot, key, value)
+		return value, nil
+	})
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return res.(V), nil
+}
+
+// set caches the value and evicts the values of slots older than the previous one
+func (c *slotCache[K, V]) set(slot phase0.Slot, key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for s := range c.data {
+		if s+1 < slot {
+			delete(c.data, s)
+		}
+	}
+	if c.data[slot] == nil {
+		c.data[slot] = map[K]V{}
+	}
+	c.data[slot][key] = value
+}
+
+// validatorDataBatcher merges concurrent validator data lookups into batched requests
+type validatorDataBatcher struct {
+	fetch    func([]phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error)
+	delay    time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	pending *validatorDataBatch
+}
+
+// validatorDataBatch is a single request for the validators of several lookups
+type validatorDataBatch struct {
+	pubKeys map[phase0.BLSPubKey]struct{}
+	done    chan struct{}
+	res     map[phase0.ValidatorIndex]*eth2apiv1.Validator
+	err     error
+}
+
+func newValidatorDataBatcher(fetch func([]phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error), delay time.Duration, maxBatch int) *validatorDataBatcher {
+	return &validatorDataBatcher{
+		fetch:    fetch,
+		delay:    delay,
+		maxBatch: maxBatch,
+	}
+}
+
+// get returns the data of the given validators, joining the pending batch if it has room.
+// Lookups larger than a batch are split into several requests.
+func (b *validatorDataBatcher) get(pubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error) {
+	if len(pubKeys) >= b.maxBatch {
+		res := make(map[phase0.ValidatorIndex]*eth2apiv1.Validator, len(pubKeys))
+		for start := 0; start < len(pubKeys); start += b.maxBatch {
+			end := start + b.maxBatch
+			if end > len(pubKeys) {
+				end = len(pubKeys)
+			}
+			chunk, err := b.fetch(pubKeys[start:end])
+			metricsBeaconCacheRequests.WithLabelValues("validator_data", cacheMiss).Inc()
+			if err != nil {
+				return nil, err
+			}
+			for index, v := range chunk {
+				res[index] = v
+			}
+		}
+		return res, nil
+	}
+
+	b.mu.Lock()
+	batch := b.pending
+	if batch != nil && len(batch.pubKeys)+len(pubKeys) <= b.maxBatch {
+		metricsBeaconCacheRequests.WithLabelValues("validator_data", cacheShared).Inc()
+	} else {
+		batch = &validatorDataBatch{
+			pubKeys: make(map[phase0.BLSPubKey]struct{}, len(pubKeys)),
+			done:    make(chan struct{}),
+		}
+		b.pending = batch
+		time.AfterFunc(b.delay, func() { b.flush(batch) })
+		metricsBeaconCacheRequests.WithLabelValues("validator_data", cacheMiss).Inc()
+	}
+	for _, pk := range pubKeys {
+		batch.pubKeys[pk] = struct{}{}
+	}
+	b.mu.Unlock()
+
+	<-batch.done
+	if batch.err != nil {
+		return nil, batch.err
+	}
+	// return only the requested validators out of the batch
+	requested := make(map[phase0.BLSPubKey]struct{}, len(pubKeys))
+	for _, pk := range pubKeys {
+		requested[pk] = struct{}{}
+	}
+	res := make(map[phase0.ValidatorIndex]*eth2apiv1.Validator, len(pubKeys))
+	for index, v := range batch.res {
+		if v.Validator == nil {
+			continue
+		}
+		if _, ok := requested[v.Validator.PublicKey]; ok {
+			res[index] = v
+		}
+	}
+	return res, nil
+}
+
+// flush closes the batch to new lookups and requests its validators
+func (b *validatorDataBatcher) flush(batch *validatorDataBatch) {
+	b.mu.Lock()
+	if b.pending == batch {
+		b.pending = nil
+	}
+	pubKeys := make([]phase0.BLSPubKey, 0, len(batch.pubKeys))
+	for pk := range batch.pubKeys {
+		pubKeys = append(pubKeys, pk)
+	}
+	b.mu.Unlock()
+
+	batch.res, batch.err = b.fetch(pubKeys)
+	close(batch.done)
+}
@@ -0,0 +1,94 @@
+package goclient
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachingClientAttestationData(t *testing.T) {
+	node := &testBeaconNode{ready: true}
+	client := newCachingClient(node)
+
+	// concurrent requests of the same committee and slot are sent once
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, _, err := client.GetAttestationData(100, 1)
+			require.NoError(t, err)
+			require.Equal(t, phase0.Slot(100), data.(*phase0.AttestationData).Slot)
+		}()
+	}
+	wg.Wait()
+	require.EqualValues(t, 1, node.calls.Load())
+
+	// other committees are requested separately
+	data, _, err := client.GetAttestationData(100, 2)
+	require.NoError(t, err)
+	require.Equal(t, phase0.CommitteeIndex(2), data.(*phase0.AttestationData).Index)
+	require.EqualValues(t, 2, node.calls.Load())
+
+	// errors aren't cached
+	node.err = errors.New("failed")
+	_, _, err = client.GetAttestationData(101, 1)
+	require.Error(t, err)
+	node.err = nil
+	_, _, err = client.GetAttestationData(101, 1)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, node.calls.Load())
+
+	// slots older than the previous one are evicted
+	_, _, err = client.GetAttestationData(102, 1)
+	require.NoError(t, err)
+	require.NotContains(t, client.attestationData.data, phase0.Slot(100))
+	require.Contains(t, client.attestationData.data, phase0.Slot(101))
+}
+
+func TestValidatorDataBatcher(t *testing.T) {
+	var fetches atomic.Int32
+	fetch := func(pubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error) {
+		fetches.Add(1)
+		res := map[phase0.ValidatorIndex]*eth2apiv1.Validator{}
+		for _, pk := range pubKeys {
+			res[phase0.ValidatorIndex(pk[0])] = &eth2apiv1.Validator{
+				Index:     phase0.ValidatorIndex(pk[0]),
+				Validator: &phase0.Validator{PublicKey: pk},
+			}
+		}
+		return res, nil
+	}
+	batcher := newValidatorDataBatcher(fetch, 20*time.Millisecond, 4)
+
+	// concurrent lookups are merged, and each gets only its validators
+	var wg sync.WaitGroup
+	for i := byte(1); i <= 3; i++ {
+		wg.Add(1)
+		go func(i byte) {
+			defer wg.Done()
+			res, err := batcher.get([]phase0.BLSPubKey{{i}})
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			require.Contains(t, res, phase0.ValidatorIndex(i))
+		}(i)
+	}
+	wg.Wait()
+	require.EqualValues(t, 1, fetches.Load())
+
+	// lookups larger than a batch are split
+	var pubKeys []phase0.BLSPubKey
+	for i := byte(1); i <= 10; i++ {
+		pubKeys = append(pubKeys, phase0.BLSPubKey{i})
+	}
+	res, err := batcher.get(pubKeys)
+	require.NoError(t, err)
+	require.Len(t, res, 10)
+	require.EqualValues(t, 4, fetches.Load())
+}
@@ -39,12 +39,18 @@ type beaconNodeStatus int32
 var (
 	allMetrics = []prometheus.Collector{
 		metricsBeaconNodeStatus,
+		metricsBeaconNodesStatus,
 		metricsBeaconDataRequest,
+		metricsBeaconCacheRequests,
 	}
 	metricsBeaconNodeStatus = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "ssv_beacon_status",
 		Help: "Status of the connected beacon node",
 	})
+	metricsBeaconNodesStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv_beacon_node_status",
+		Help: "Status of each beacon node when multiple beacon nodes are used",
+	}, []string{"node"})
 
 	// metricsBeaconDataRequest is located here to avoid including waiting for 1/3 or 2/3 of slot time into request duration.
 	metricsBeaconDataRequest = promauto.NewHistogramVec(prometheus.HistogramOpts{
@@ -103,6 +109,8 @@ type Client interface {
 	eth2client.BlindedBeaconBlockProposalProvider
 	eth2client.BlindedBeaconBlockSubmitter
 	eth2client.ValidatorRegistrationsSubmitter
+	eth2client.VoluntaryExitSubmitter
+	eth2client.BLSToExecutionChangesSubmitter
 }
 
 // goClient implementing Beacon struct
@@ -111,6 +119,7 @@ type goClient struct {
 	ctx                  context.Context
 	network              beaconprotocol.Network
 	client               Client
+	baseURL              string
 	graffiti             []byte
 	gasLimit             uint64
 	operatorID           spectypes.OperatorID
@@ -122,13 +131,43 @@ type goClient struct {
 // verifies that the client implements HealthCheckAgent
 var _ metrics.HealthCheckAgent = &goClient{}
 
-// New init new client and go-client instance
+// New init new client and go-client instance.
+// When multiple beacon node addresses are given, the returned client fails over between them,
+// and nodes which can't be reached on start are connected to once they are up.
+// Data shared by many validators is cached per slot, and identical concurrent requests are sent once.
 func New(logger *zap.Logger, opt beaconprotocol.Options, operatorID spectypes.OperatorID, slotTicker slot_ticker.Ticker) (beaconprotocol.BeaconNode, error) {
-	logger.Info("consensus client: connecting", fields.Address(opt.BeaconNodeAddr), fields.Network(string(opt.Network.BeaconNetwork)))
+	addrs := splitAddresses(opt.BeaconNodeAddr)
+	if len(addrs) == 0 {
+		return nil, errors.New("no beacon node address")
+	}
+	if len(addrs) == 1 {
+		client, err := newGoClient(logger, opt, addrs[0], operatorID, slotTicker)
+		if err != nil {
+			return nil, err
+		}
+		return newCachingClient(client), nil
+	}
+
+	connect := func(addr string) (beaconNode, error) {
+		client, err := newGoClient(logger, opt, addr, operatorID, slotTicker)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+	client, err := connectMultiClient(opt.Context, logger, opt.CrossCheckDuties, addrs, connect, reconnectInterval)
+	if err != nil {
+		return nil, err
+	}
+	return newCachingClient(client), nil
+}
+
+func newGoClient(logger *zap.Logger, opt beaconprotocol.Options, addr string, operatorID spectypes.OperatorID, slotTicker slot_ticker.Ticker) (*goClient, error) {
+	logger.Info("consensus client: connecting", fields.Address(redactAddress(addr)), fields.Network(string(opt.Network.BeaconNetwork)))
 
 	httpClient, err := http.New(opt.Context,
 		// WithAddress supplies the address of the beacon node, in host:port format.
-		http.WithAddress(opt.BeaconNodeAddr),
+		http.WithAddress(addr),
 		// LogLevel supplies the level of logging to carry out.
 		http.WithLogLevel(zerolog.DebugLevel),
 		http.WithTimeout(time.Second*5),
@@ -137,7 +176,7 @@ func New(logger *zap.Logger, opt beaconprotocol.Options, operatorID spectypes.Op
 		return nil, errors.WithMessage(err, "failed to create http client")
 	}
 
-	logger.Info("consensus client: connected", fields.Name(httpClient.Name()), fields.Address(httpClient.Address()))
+	logger.Info("consensus client: connected", fields.Name(httpClient.Name()), fields.Address(redactAddress(httpClient.Address())))
 
 	tickerChan := make(chan phase0.Slot, 32)
 	slotTicker.Subscribe(tickerChan)
@@ -147,6 +186,7 @@ func New(logger *zap.Logger, opt beaconprotocol.Options, operatorID spectypes.Op
 		ctx:               opt.Context,
 		network:           opt.Network,
 		client:            httpClient.(*http.Service),
+		baseURL:           baseURL(addr),
 		graffiti:          opt.Graffiti,
 		gasLimit:          opt.GasLimit,
 		operatorID:        operatorID,
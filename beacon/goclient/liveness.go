@@ -0,0 +1,78 @@
+package goclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+const livenessTimeout = 5 * time.Second
+
+// baseURL returns the URL of the beacon node API at the given address, the same way the http client resolves it
+func baseURL(addr string) string {
+	if !strings.HasPrefix(addr, "http") {
+		addr = "http://" + addr
+	}
+	return strings.TrimSuffix(addr, "/")
+}
+
+// ValidatorLiveness calls the liveness endpoint of the beacon node, which the client library doesn't support yet
+func (gc *goClient) ValidatorLiveness(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*beaconprotocol.ValidatorLiveness, error) {
+	indices := make([]string, len(validatorIndices))
+	for i, index := range validatorIndices {
+		indices[i] = strconv.FormatUint(uint64(index), 10)
+	}
+	body, err := json.Marshal(indices)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal indices")
+	}
+
+	ctx, cancel := context.WithTimeout(gc.ctx, livenessTimeout)
+	defer cancel()
+	url := fmt.Sprintf("%s/eth/v1/validator/liveness/%d", gc.baseURL, epoch)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not request liveness")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, errors.Errorf("liveness request failed with status %d: %s", resp.StatusCode, msg)
+	}
+
+	var res struct {
+		Data []struct {
+			Index  string `json:"index"`
+			IsLive bool   `json:"is_live"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, errors.Wrap(err, "could not decode liveness")
+	}
+	liveness := make([]*beaconprotocol.ValidatorLiveness, len(res.Data))
+	for i, data := range res.Data {
+		index, err := strconv.ParseUint(data.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid validator index")
+		}
+		liveness[i] = &beaconprotocol.ValidatorLiveness{Index: phase0.ValidatorIndex(index), IsLive: data.IsLive}
+	}
+	return liveness, nil
+}
@@ -0,0 +1,694 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/monitoring/metrics"
+	"github.com/bloxapp/ssv/nodeprobe"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+const (
+	// maxConsecutiveFailures is the amount of failed calls in a row after which a node is considered unhealthy
+	maxConsecutiveFailures = 3
+	// latencySmoothing is the weight of the latest call in the latency moving average of a node
+	latencySmoothing = 0.2
+	// reconnectInterval is the interval between connection attempts to a node which couldn't be reached at startup
+	reconnectInterval = 30 * time.Second
+)
+
+// beaconNode is a single beacon node behind a multiClient
+type beaconNode interface {
+	beaconprotocol.BeaconNode
+	nodeprobe.StatusChecker
+	metrics.HealthCheckAgent
+	AttesterDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, error)
+	ProposerDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, error)
+}
+
+// multiClientNode tracks the health of a beacon node
+type multiClientNode struct {
+	name string
+
+	mu sync.Mutex
+	// client is nil until the node is connected
+	client   beaconNode
+	ready    bool
+	readyErr error
+	failures int
+	latency  time.Duration
+}
+
+func newMultiClientNode(name string, client beaconNode) *multiClientNode {
+	return &multiClientNode{
+		name:   name,
+		client: client,
+		ready:  true,
+	}
+}
+
+// newDisconnectedMultiClientNode returns a node which couldn't be connected to, it's skipped until connect succeeds
+func newDisconnectedMultiClientNode(name string, err error) *multiClientNode {
+	n := &multiClientNode{name: name}
+	n.setReady(false, err)
+	return n
+}
+
+// getClient returns the client of the node, or nil if it isn't connected
+func (n *multiClientNode) getClient() beaconNode {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.client
+}
+
+// connect retries connecting to the node until it succeeds or the context is done
+func (n *multiClientNode) connect(ctx context.Context, logger *zap.Logger, connect func() (beaconNode, error), interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		client, err := connect()
+		if err != nil {
+			logger.Debug("could not connect to beacon node", zap.String("node", n.name), zap.Error(err))
+			n.setReady(false, err)
+			continue
+		}
+		n.mu.Lock()
+		n.client = client
+		n.ready = true
+		n.readyErr = nil
+		n.mu.Unlock()
+		logger.Info("connected to beacon node", zap.String("node", n.name))
+		return
+	}
+}
+
+// healthy returns true if the node is ready and its recent calls succeeded
+func (n *multiClientNode) healthy() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.ready && n.failures < maxConsecutiveFailures
+}
+
+func (n *multiClientNode) recordCall(latency time.Duration, err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if err != nil {
+		n.failures++
+		return
+	}
+	n.failures = 0
+	if n.latency == 0 {
+		n.latency = latency
+	} else {
+		n.latency = time.Duration(latencySmoothing*float64(latency) + (1-latencySmoothing)*float64(n.latency))
+	}
+}
+
+func (n *multiClientNode) setReady(ready bool, err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.ready = ready
+	n.readyErr = err
+	if ready && err == nil {
+		// a node which failed calls is given another chance once its readiness probe succeeds,
+		// otherwise it would never be called again and could never recover
+		n.failures = 0
+	}
+	status := statusOK
+	if err != nil {
+		status = statusUnknown
+	} else if !ready {
+		status = statusSyncing
+	}
+	metricsBeaconNodesStatus.WithLabelValues(n.name).Set(float64(status))
+}
+
+// rank is the sorting key of a node, lower is better
+func (n *multiClientNode) rank() (bool, int, time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return !(n.ready && n.failures < maxConsecutiveFailures), n.failures, n.latency
+}
+
+// multiClient implements BeaconNode on top of multiple beacon nodes.
+// Reads are sent to the best ranked node and fail over to the next one on errors or timeouts,
+// while submissions are sent to all healthy nodes and succeed if any of them accepts it.
+// Nodes which aren't connected yet are skipped.
+type multiClient struct {
+	logger           *zap.Logger
+	nodes            []*multiClientNode
+	crossCheckDuties bool
+}
+
+// verifies that the client implements HealthCheckAgent and MultiNodeChecker
+var (
+	_ metrics.HealthCheckAgent   = &multiClient{}
+	_ nodeprobe.MultiNodeChecker = &multiClient{}
+)
+
+func newMultiClient(logger *zap.Logger, crossCheckDuties bool, nodes ...*multiClientNode) *multiClient {
+	return &multiClient{
+		logger:           logger,
+		nodes:            nodes,
+		crossCheckDuties: crossCheckDuties,
+	}
+}
+
+// connectMultiClient connects to the beacon nodes at the given addresses. It fails only if none of them
+// can be reached, the others are retried in the background until the context is done.
+func connectMultiClient(ctx context.Context, logger *zap.Logger, crossCheckDuties bool, addrs []string, connect func(addr string) (beaconNode, error), retryInterval time.Duration) (*multiClient, error) {
+	nodes := make([]*multiClientNode, len(addrs))
+	var err error
+	connected := 0
+	for i, addr := range addrs {
+		name := redactAddress(addr)
+		client, connectErr := connect(addr)
+		if connectErr != nil {
+			logger.Error("could not connect to beacon node, retrying in the background", zap.String("node", name), zap.Error(connectErr))
+			nodes[i] = newDisconnectedMultiClientNode(name, connectErr)
+			err = errors.Wrapf(connectErr, "failed to create client for beacon node %s", name)
+			continue
+		}
+		nodes[i] = newMultiClientNode(name, client)
+		connected++
+	}
+	if connected == 0 {
+		return nil, err
+	}
+	for i, n := range nodes {
+		if n.getClient() == nil {
+			addr := addrs[i]
+			go n.connect(ctx, logger, func() (beaconNode, error) { return connect(addr) }, retryInterval)
+		}
+	}
+	return newMultiClient(logger, crossCheckDuties, nodes...), nil
+}
+
+// ranked returns the connected nodes ordered by health, failures and latency.
+// The sort is stable, so equally ranked nodes keep the configured order.
+func (mc *multiClient) ranked() []*multiClientNode {
+	type rankedNode struct {
+		node      *multiClientNode
+		unhealthy bool
+		failures  int
+		latency   time.Duration
+	}
+	ranks := make([]rankedNode, 0, len(mc.nodes))
+	for _, n := range mc.nodes {
+		if n.getClient() == nil {
+			continue
+		}
+		unhealthy, failures, latency := n.rank()
+		ranks = append(ranks, rankedNode{node: n, unhealthy: unhealthy, failures: failures, latency: latency})
+	}
+	sort.SliceStable(ranks, func(i, j int) bool {
+		if ranks[i].unhealthy != ranks[j].unhealthy {
+			return !ranks[i].unhealthy
+		}
+		if ranks[i].failures != ranks[j].failures {
+			return ranks[i].failures < ranks[j].failures
+		}
+		return ranks[i].latency < ranks[j].latency
+	})
+	nodes := make([]*multiClientNode, len(ranks))
+	for i, r := range ranks {
+		nodes[i] = r.node
+	}
+	return nodes
+}
+
+// healthy returns the healthy nodes, or all nodes if none of them is healthy
+func (mc *multiClient) healthy() []*multiClientNode {
+	var nodes []*multiClientNode
+	for _, n := range mc.ranked() {
+		if n.healthy() {
+			nodes = append(nodes, n)
+		}
+	}
+	if len(nodes) == 0 {
+		return mc.ranked()
+	}
+	return nodes
+}
+
+func (mc *multiClient) call(n *multiClientNode, f func(client beaconNode) error) error {
+	start := time.Now()
+	err := f(n.getClient())
+	n.recordCall(time.Since(start), err)
+	return err
+}
+
+// failover calls f on the nodes by rank until it succeeds
+func (mc *multiClient) failover(method string, f func(client beaconNode) error) error {
+	var err error
+	for _, n := range mc.ranked() {
+		if err = mc.call(n, f); err == nil {
+			return nil
+		}
+		mc.logger.Warn("beacon node call failed, failing over",
+			zap.String("method", method), zap.String("node", n.name), zap.Error(err))
+	}
+	return errors.Wrapf(err, "%s failed on all beacon nodes", method)
+}
+
+// fanOut calls f on all healthy nodes in parallel, it succeeds if any of the calls succeeded
+func (mc *multiClient) fanOut(method string, f func(client beaconNode) error) error {
+	nodes := mc.healthy()
+	errs := make([]error, len(nodes))
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		wg.Add(1)
+		go func(i int, n *multiClientNode) {
+			defer wg.Done()
+			errs[i] = mc.call(n, f)
+		}(i, n)
+	}
+	wg.Wait()
+
+	succeeded := false
+	for i, err := range errs {
+		if err == nil {
+			succeeded = true
+			continue
+		}
+		mc.logger.Warn("beacon node submission failed",
+			zap.String("method", method), zap.String("node", nodes[i].name), zap.Error(err))
+	}
+	if !succeeded {
+		return errors.Wrapf(errs[0], "%s failed on all beacon nodes", method)
+	}
+	return nil
+}
+
+// IsReady returns true if any of the nodes is ready, the readiness of each node is kept for NodeStatuses
+func (mc *multiClient) IsReady(ctx context.Context) (bool, error) {
+	var wg sync.WaitGroup
+	for _, n := range mc.nodes {
+		client := n.getClient()
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(n *multiClientNode) {
+			defer wg.Done()
+			n.setReady(client.IsReady(ctx))
+		}(n)
+	}
+	wg.Wait()
+
+	var err error
+	for _, status := range mc.NodeStatuses() {
+		if status.Ready {
+			metricsBeaconNodeStatus.Set(float64(statusOK))
+			return true, nil
+		}
+		if status.Err != nil {
+			err = status.Err
+		}
+	}
+	if err != nil {
+		metricsBeaconNodeStatus.Set(float64(statusUnknown))
+		return false, errors.Wrap(err, "no beacon node is ready")
+	}
+	metricsBeaconNodeStatus.Set(float64(statusSyncing))
+	return false, nil
+}
+
+// NodeStatuses returns the last known readiness of each node
+func (mc *multiClient) NodeStatuses() []nodeprobe.NodeStatus {
+	statuses := make([]nodeprobe.NodeStatus, 0, len(mc.nodes))
+	for _, n := range mc.nodes {
+		n.mu.Lock()
+		statuses = append(statuses, nodeprobe.NodeStatus{Name: n.name, Ready: n.ready, Err: n.readyErr})
+		n.mu.Unlock()
+	}
+	return statuses
+}
+
+// HealthCheck reports the issues of all nodes if none of them is healthy
+func (mc *multiClient) HealthCheck() []string {
+	var issues []string
+	for _, n := range mc.nodes {
+		client := n.getClient()
+		if client == nil {
+			issues = append(issues, fmt.Sprintf("%s: not connected", n.name))
+			continue
+		}
+		nodeIssues := client.HealthCheck()
+		if len(nodeIssues) == 0 {
+			metricsBeaconNodeStatus.Set(float64(statusOK))
+			return []string{}
+		}
+		for _, issue := range nodeIssues {
+			issues = append(issues, fmt.Sprintf("%s: %s", n.name, issue))
+		}
+	}
+	return issues
+}
+
+// GetDuties returns the duties of the best ranked node, or the duties most nodes agree on when cross checking
+func (mc *multiClient) GetDuties(logger *zap.Logger, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, error) {
+	if !mc.crossCheckDuties {
+		var duties []*spectypes.Duty
+		err := mc.failover("GetDuties", func(client beaconNode) (err error) {
+			duties, err = fetchDuties(client, epoch, validatorIndices)
+			return err
+		})
+		return duties, err
+	}
+
+	nodes := mc.healthy()
+	results := make([][]*spectypes.Duty, len(nodes))
+	errs := make([]error, len(nodes))
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		wg.Add(1)
+		go func(i int, n *multiClientNode) {
+			defer wg.Done()
+			errs[i] = mc.call(n, func(client beaconNode) (err error) {
+				results[i], err = fetchDuties(client, epoch, validatorIndices)
+				return err
+			})
+		}(i, n)
+	}
+	wg.Wait()
+
+	// group the responding nodes by their result, nodes are ranked so the first result is of the best node
+	var keys []string
+	votes := map[string][]int{}
+	for i, err := range errs {
+		if err != nil {
+			logger.Warn("failed to fetch duties from beacon node", zap.String("node", nodes[i].name), zap.Error(err))
+			continue
+		}
+		key := dutiesKey(results[i])
+		if _, ok := votes[key]; !ok {
+			keys = append(keys, key)
+		}
+		votes[key] = append(votes[key], i)
+	}
+	if len(keys) == 0 {
+		return nil, errors.Wrap(errs[0], "failed to fetch duties from all beacon nodes")
+	}
+
+	responded := 0
+	for _, voters := range votes {
+		responded += len(voters)
+	}
+	chosen := keys[0]
+	for _, key := range keys {
+		if len(votes[key])*2 > responded {
+			chosen = key
+			break
+		}
+	}
+	if len(votes[chosen])*2 <= responded && len(keys) > 1 {
+		logger.Warn("beacon nodes disagree on duties without a majority, using the best ranked node",
+			fields.Count(responded))
+	}
+	for _, key := range keys {
+		if key == chosen {
+			continue
+		}
+		for _, i := range votes[key] {
+			logger.Warn("beacon node returned different duties", zap.String("node", nodes[i].name),
+				zap.Int("duties", len(results[i])), zap.Int("expected_duties", len(results[votes[chosen][0]])))
+		}
+	}
+	return results[votes[chosen][0]], nil
+}
+
+// fetchDuties fetches attester and proposer duties, failing if any of them can't be fetched
+func fetchDuties(client beaconNode, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, error) {
+	attesterDuties, err := client.AttesterDuties(epoch, validatorIndices)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get attester duties")
+	}
+	proposerDuties, err := client.ProposerDuties(epoch, validatorIndices)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get proposer duties")
+	}
+	return append(attesterDuties, proposerDuties...), nil
+}
+
+// dutiesKey returns a key that is equal for equal sets of duties, regardless of their order
+func dutiesKey(duties []*spectypes.Duty) string {
+	keys := make([]string, len(duties))
+	for i, d := range duties {
+		keys[i] = fmt.Sprintf("%d/%x/%d/%d/%d/%d/%d/%d", d.Type, d.PubKey, d.Slot, d.ValidatorIndex,
+			d.CommitteeIndex, d.CommitteeLength, d.CommitteesAtSlot, d.ValidatorCommitteeIndex)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+func (mc *multiClient) SyncCommitteeDuties(epoch phase0.Epoch, indices []phase0.ValidatorIndex) ([]*eth2apiv1.SyncCommitteeDuty, error) {
+	var duties []*eth2apiv1.SyncCommitteeDuty
+	err := mc.failover("SyncCommitteeDuties", func(client beaconNode) (err error) {
+		duties, err = client.SyncCommitteeDuties(epoch, indices)
+		return err
+	})
+	return duties, err
+}
+
+// Events subscribes to the events of the best ranked node that accepts the subscription
+func (mc *multiClient) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	return mc.failover("Events", func(client beaconNode) error {
+		return client.Events(ctx, topics, handler)
+	})
+}
+
+// anyClient returns the client of the first connected node, for calls which don't depend on the node
+func (mc *multiClient) anyClient() beaconNode {
+	for _, n := range mc.nodes {
+		if client := n.getClient(); client != nil {
+			return client
+		}
+	}
+	// at least one node is connected when the client is created
+	panic("no connected beacon node")
+}
+
+func (mc *multiClient) GetBeaconNetwork() spectypes.BeaconNetwork {
+	return mc.anyClient().GetBeaconNetwork()
+}
+
+func (mc *multiClient) GetAttestationData(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (ssz.Marshaler, spec.DataVersion, error) {
+	var data ssz.Marshaler
+	version := DataVersionNil
+	err := mc.failover("GetAttestationData", func(client beaconNode) (err error) {
+		data, version, err = client.GetAttestationData(slot, committeeIndex)
+		return err
+	})
+	return data, version, err
+}
+
+func (mc *multiClient) SubmitAttestation(attestation *phase0.Attestation) error {
+	return mc.fanOut("SubmitAttestation", func(client beaconNode) error {
+		return client.SubmitAttestation(attestation)
+	})
+}
+
+func (mc *multiClient) GetBeaconBlock(slot phase0.Slot, graffiti, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	var block ssz.Marshaler
+	version := DataVersionNil
+	err := mc.failover("GetBeaconBlock", func(client beaconNode) (err error) {
+		block, version, err = client.GetBeaconBlock(slot, graffiti, randao)
+		return err
+	})
+	return block, version, err
+}
+
+func (mc *multiClient) GetBlindedBeaconBlock(slot phase0.Slot, graffiti, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	var block ssz.Marshaler
+	version := DataVersionNil
+	err := mc.failover("GetBlindedBeaconBlock", func(client beaconNode) (err error) {
+		block, version, err = client.GetBlindedBeaconBlock(slot, graffiti, randao)
+		return err
+	})
+	return block, version, err
+}
+
+func (mc *multiClient) SubmitBeaconBlock(block *spec.VersionedBeaconBlock, sig phase0.BLSSignature) error {
+	return mc.fanOut("SubmitBeaconBlock", func(client beaconNode) error {
+		return client.SubmitBeaconBlock(block, sig)
+	})
+}
+
+func (mc *multiClient) SubmitBlindedBeaconBlock(block *api.VersionedBlindedBeaconBlock, sig phase0.BLSSignature) error {
+	return mc.fanOut("SubmitBlindedBeaconBlock", func(client beaconNode) error {
+		return client.SubmitBlindedBeaconBlock(block, sig)
+	})
+}
+
+func (mc *multiClient) SubmitAggregateSelectionProof(slot phase0.Slot, committeeIndex phase0.CommitteeIndex, committeeLength uint64, index phase0.ValidatorIndex, slotSig []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	var aggregate ssz.Marshaler
+	version := DataVersionNil
+	err := mc.failover("SubmitAggregateSelectionProof", func(client beaconNode) (err error) {
+		aggregate, version, err = client.SubmitAggregateSelectionProof(slot, committeeIndex, committeeLength, index, slotSig)
+		return err
+	})
+	return aggregate, version, err
+}
+
+func (mc *multiClient) SubmitSignedAggregateSelectionProof(msg *phase0.SignedAggregateAndProof) error {
+	return mc.fanOut("SubmitSignedAggregateSelectionProof", func(client beaconNode) error {
+		return client.SubmitSignedAggregateSelectionProof(msg)
+	})
+}
+
+func (mc *multiClient) GetSyncMessageBlockRoot(slot phase0.Slot) (phase0.Root, spec.DataVersion, error) {
+	var root phase0.Root
+	version := DataVersionNil
+	err := mc.failover("GetSyncMessageBlockRoot", func(client beaconNode) (err error) {
+		root, version, err = client.GetSyncMessageBlockRoot(slot)
+		return err
+	})
+	return root, version, err
+}
+
+func (mc *multiClient) SubmitSyncMessage(msg *altair.SyncCommitteeMessage) error {
+	return mc.fanOut("SubmitSyncMessage", func(client beaconNode) error {
+		return client.SubmitSyncMessage(msg)
+	})
+}
+
+func (mc *multiClient) IsSyncCommitteeAggregator(proof []byte) (bool, error) {
+	return mc.anyClient().IsSyncCommitteeAggregator(proof)
+}
+
+func (mc *multiClient) SyncCommitteeSubnetID(index phase0.CommitteeIndex) (uint64, error) {
+	return mc.anyClient().SyncCommitteeSubnetID(index)
+}
+
+func (mc *multiClient) GetSyncCommitteeContribution(slot phase0.Slot, selectionProofs []phase0.BLSSignature, subnetIDs []uint64) (ssz.Marshaler, spec.DataVersion, error) {
+	var contributions ssz.Marshaler
+	version := DataVersionNil
+	err := mc.failover("GetSyncCommitteeContribution", func(client beaconNode) (err error) {
+		contributions, version, err = client.GetSyncCommitteeContribution(slot, selectionProofs, subnetIDs)
+		return err
+	})
+	return contributions, version, err
+}
+
+func (mc *multiClient) SubmitSignedContributionAndProof(contribution *altair.SignedContributionAndProof) error {
+	return mc.fanOut("SubmitSignedContributionAndProof", func(client beaconNode) error {
+		return client.SubmitSignedContributionAndProof(contribution)
+	})
+}
+
+func (mc *multiClient) SubmitValidatorRegistration(pubkey []byte, feeRecipient bellatrix.ExecutionAddress, sig phase0.BLSSignature) error {
+	return mc.fanOut("SubmitValidatorRegistration", func(client beaconNode) error {
+		return client.SubmitValidatorRegistration(pubkey, feeRecipient, sig)
+	})
+}
+
+func (mc *multiClient) SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error {
+	return mc.fanOut("SubmitVoluntaryExit", func(client beaconNode) error {
+		return client.SubmitVoluntaryExit(voluntaryExit)
+	})
+}
+
+func (mc *multiClient) SubmitBLSToExecutionChange(change *capella.SignedBLSToExecutionChange) error {
+	return mc.fanOut("SubmitBLSToExecutionChange", func(client beaconNode) error {
+		return client.SubmitBLSToExecutionChange(change)
+	})
+}
+
+func (mc *multiClient) DomainData(epoch phase0.Epoch, domain phase0.DomainType) (phase0.Domain, error) {
+	var data phase0.Domain
+	err := mc.failover("DomainData", func(client beaconNode) (err error) {
+		data, err = client.DomainData(epoch, domain)
+		return err
+	})
+	return data, err
+}
+
+func (mc *multiClient) SubscribeToCommitteeSubnet(subscription []*eth2apiv1.BeaconCommitteeSubscription) error {
+	return mc.fanOut("SubscribeToCommitteeSubnet", func(client beaconNode) error {
+		return client.SubscribeToCommitteeSubnet(subscription)
+	})
+}
+
+func (mc *multiClient) SubmitSyncCommitteeSubscriptions(subscription []*eth2apiv1.SyncCommitteeSubscription) error {
+	return mc.fanOut("SubmitSyncCommitteeSubscriptions", func(client beaconNode) error {
+		return client.SubmitSyncCommitteeSubscriptions(subscription)
+	})
+}
+
+func (mc *multiClient) GetValidatorData(validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error) {
+	var validators map[phase0.ValidatorIndex]*eth2apiv1.Validator
+	err := mc.failover("GetValidatorData", func(client beaconNode) (err error) {
+		validators, err = client.GetValidatorData(validatorPubKeys)
+		return err
+	})
+	return validators, err
+}
+
+func (mc *multiClient) ValidatorLiveness(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*beaconprotocol.ValidatorLiveness, error) {
+	var liveness []*beaconprotocol.ValidatorLiveness
+	err := mc.failover("ValidatorLiveness", func(client beaconNode) (err error) {
+		liveness, err = client.ValidatorLiveness(epoch, validatorIndices)
+		return err
+	})
+	return liveness, err
+}
+
+func (mc *multiClient) ComputeSigningRoot(object interface{}, domain phase0.Domain) ([32]byte, error) {
+	return mc.anyClient().ComputeSigningRoot(object, domain)
+}
+
+func (mc *multiClient) SubmitProposalPreparation(feeRecipients map[phase0.ValidatorIndex]bellatrix.ExecutionAddress) error {
+	return mc.fanOut("SubmitProposalPreparation", func(client beaconNode) error {
+		return client.SubmitProposalPreparation(feeRecipients)
+	})
+}
+
+// splitAddresses splits a ';' separated list of beacon node addresses
+func splitAddresses(addrs string) []string {
+	var result []string
+	for _, addr := range strings.Split(addrs, ";") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			result = append(result, addr)
+		}
+	}
+	return result
+}
+
+// redactAddress returns the host of a beacon node address, without credentials or api keys in its path
+func redactAddress(addr string) string {
+	raw := addr
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "invalid-address"
+	}
+	return u.Host
+}
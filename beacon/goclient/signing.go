@@ -9,6 +9,8 @@ import (
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v2/message"
 )
 
 func (gc *goClient) DomainData(epoch phase0.Epoch, domain phase0.DomainType) (phase0.Domain, error) {
@@ -27,6 +29,10 @@ func (gc *goClient) DomainData(epoch phase0.Epoch, domain phase0.DomainType) (ph
 		return appDomain, nil
 	}
 
+	if domain == message.DomainBLSToExecutionChange { // signed with the genesis fork version at any epoch, so that changes remain valid across forks
+		return gc.client.GenesisDomain(gc.ctx, domain)
+	}
+
 	data, err := gc.client.Domain(gc.ctx, domain, epoch)
 	if err != nil {
 		return phase0.Domain{}, err
@@ -2,6 +2,7 @@ package goclient
 
 import (
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
@@ -9,3 +10,13 @@ import (
 func (gc *goClient) GetValidatorData(validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error) {
 	return gc.client.ValidatorsByPubKey(gc.ctx, "head", validatorPubKeys) // TODO maybe need to get the chainId (head) as var
 }
+
+// SubmitBLSToExecutionChange submits a signed change of BLS withdrawal credentials to the node
+func (gc *goClient) SubmitBLSToExecutionChange(change *capella.SignedBLSToExecutionChange) error {
+	return gc.client.SubmitBLSToExecutionChanges(gc.ctx, []*capella.SignedBLSToExecutionChange{change})
+}
+
+// SubmitVoluntaryExit submits a signed voluntary exit to the node
+func (gc *goClient) SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error {
+	return gc.client.SubmitVoluntaryExit(gc.ctx, voluntaryExit)
+}
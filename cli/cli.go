@@ -32,4 +32,9 @@ func Execute(appName, version string) {
 func init() {
 	RootCmd.AddCommand(bootnode.StartBootNodeCmd)
 	RootCmd.AddCommand(operator.StartNodeCmd)
+	RootCmd.AddCommand(operator.DBCmd)
+	RootCmd.AddCommand(operator.SlashingProtectionCmd)
+	RootCmd.AddCommand(operator.OperatorKeyCmd)
+	RootCmd.AddCommand(operator.NetworkConfigCmd)
+	RootCmd.AddCommand(operator.ReplayCmd)
 }
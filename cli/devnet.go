@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"log"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/devnet"
+	"github.com/bloxapp/ssv/logging"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+)
+
+var devnetArgs struct {
+	operators     int
+	validators    int
+	tcpPort       int
+	udpPort       int
+	slotDuration  time.Duration
+	slotsPerEpoch uint64
+	v3ForkEpoch   int64
+	logLevel      string
+}
+
+// devnetCmd is the command to run a local network of operators on top of a simulated beacon chain and registry contract
+var devnetCmd = &cobra.Command{
+	Use:   "devnet",
+	Short: "Runs a local SSV network of operators in a single process",
+	Long: `Runs a local SSV network of operators in a single process, on top of a simulated beacon chain
+and registry contract, with real p2p over loopback. Operators and validators are generated and registered on start,
+and validators attest once per epoch. The operators time duties by the slot duration and slots per epoch
+of the simulated chain, and it only accepts validly signed attestations. The number of beacon submissions is logged every epoch.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := logging.SetGlobalLogger(devnetArgs.logLevel, "capital", "console", ""); err != nil {
+			log.Fatal(err)
+		}
+		logger := zap.L().Named("devnet")
+
+		var forks forksprotocol.Schedule
+		if devnetArgs.v3ForkEpoch >= 0 {
+			forks = forksprotocol.Schedule{{Version: forksprotocol.V3ForkVersion, Epoch: phase0.Epoch(devnetArgs.v3ForkEpoch)}}
+		}
+
+		ctx := cmd.Context()
+		d, err := devnet.New(ctx, logger, devnet.Options{
+			Operators:     devnetArgs.operators,
+			Validators:    devnetArgs.validators,
+			TCPPort:       devnetArgs.tcpPort,
+			UDPPort:       devnetArgs.udpPort,
+			SlotDuration:  devnetArgs.slotDuration,
+			SlotsPerEpoch: devnetArgs.slotsPerEpoch,
+			Forks:         forks,
+		})
+		if err != nil {
+			logger.Fatal("could not create devnet", zap.Error(err))
+		}
+		if err := d.Start(ctx, logger); err != nil {
+			logger.Fatal("could not start devnet", zap.Error(err))
+		}
+
+		epochDuration := d.Network.SlotDurationSec() * time.Duration(d.Network.SlotsPerEpoch())
+		ticker := time.NewTicker(epochDuration)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				logger.Info("beacon submissions",
+					zap.Uint64("epoch", uint64(d.Network.Beacon.EstimatedCurrentEpoch())),
+					zap.Any("submissions", d.Beacon.Submissions()),
+				)
+			}
+		}
+	},
+}
+
+func init() {
+	devnetCmd.Flags().IntVar(&devnetArgs.operators, "operators", 4, "Number of operators, at least 4")
+	devnetCmd.Flags().IntVar(&devnetArgs.validators, "validators", 1, "Number of validators")
+	devnetCmd.Flags().IntVar(&devnetArgs.tcpPort, "tcp-port", 13101, "p2p TCP port of the first operator, the other operators use the following ports")
+	devnetCmd.Flags().IntVar(&devnetArgs.udpPort, "udp-port", 12101, "Discovery UDP port of the bootnode, the operators use the following ports")
+	devnetCmd.Flags().DurationVar(&devnetArgs.slotDuration, "slot-duration", 12*time.Second, "Slot duration of the simulated beacon chain")
+	devnetCmd.Flags().Uint64Var(&devnetArgs.slotsPerEpoch, "slots-per-epoch", 32, "Slots per epoch of the simulated beacon chain")
+	devnetCmd.Flags().Int64Var(&devnetArgs.v3ForkEpoch, "v3-fork-epoch", -1, "Epoch of the v3 fork, disabled when negative")
+	devnetCmd.Flags().StringVar(&devnetArgs.logLevel, "log-level", "info", "Log level")
+
+	RootCmd.AddCommand(devnetCmd)
+}
@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/libp2p/go-libp2p"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/dkg"
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/network/forks/genesis"
+	"github.com/bloxapp/ssv/network/streams"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/operator/keystore"
+)
+
+// dkgCeremony is the ceremony file of the dkg command
+type dkgCeremony struct {
+	Network           string         `json:"network"`
+	Owner             common.Address `json:"owner"`
+	Nonce             uint64         `json:"nonce"`
+	WithdrawalAddress common.Address `json:"withdrawalAddress"`
+	Operators         []struct {
+		ID     spectypes.OperatorID `json:"id"`
+		PubKey string               `json:"publicKey"`
+		// Address is the multiaddr of the operator node, including its peer id
+		Address string `json:"address"`
+	} `json:"operators"`
+}
+
+var dkgArgs struct {
+	ceremonyFile   string
+	outputDir      string
+	timeout        time.Duration
+	operatorID     uint64
+	privateKeyFile string
+	passwordFile   string
+}
+
+// dkgCmd is the command to generate a validator key with a cluster of operators, without any party knowing the key
+var dkgCmd = &cobra.Command{
+	Use:   "dkg",
+	Short: "Runs a distributed key generation ceremony with a cluster of operators",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := logging.SetGlobalLogger("debug", "capital", "console", ""); err != nil {
+			log.Fatal(err)
+		}
+		logger := zap.L().Named(logging.NameDKG)
+
+		if err := runDKG(cmd.Context(), logger); err != nil {
+			logger.Fatal("DKG ceremony failed", zap.Error(err))
+		}
+	},
+}
+
+func runDKG(ctx context.Context, logger *zap.Logger) error {
+	raw, err := os.ReadFile(dkgArgs.ceremonyFile)
+	if err != nil {
+		return errors.Wrap(err, "could not read ceremony file")
+	}
+	var ceremony dkgCeremony
+	if err := json.Unmarshal(raw, &ceremony); err != nil {
+		return errors.Wrap(err, "could not parse ceremony file")
+	}
+	networkConfig, err := networkconfig.GetNetworkConfigByName(ceremony.Network)
+	if err != nil {
+		return err
+	}
+	// operators only take part in ceremonies started by one of them
+	sk, err := keystore.Load(dkgArgs.privateKeyFile, dkgArgs.passwordFile)
+	if err != nil {
+		return errors.Wrap(err, "could not load operator keystore")
+	}
+
+	// 0x01 withdrawal credentials: prefix, 11 zero bytes and the execution address
+	withdrawalCredentials := make([]byte, 32)
+	withdrawalCredentials[0] = 0x01
+	copy(withdrawalCredentials[12:], ceremony.WithdrawalAddress.Bytes())
+
+	params := &dkg.Init{
+		WithdrawalCredentials: withdrawalCredentials,
+		ForkVersion:           networkConfig.ForkVersion(),
+		Owner:                 ceremony.Owner,
+		Nonce:                 ceremony.Nonce,
+	}
+	addrs := make(map[spectypes.OperatorID]string, len(ceremony.Operators))
+	for _, op := range ceremony.Operators {
+		params.Operators = append(params.Operators, dkg.Operator{ID: op.ID, PubKey: op.PubKey})
+		addrs[op.ID] = op.Address
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dkgArgs.timeout)
+	defer cancel()
+
+	h, err := libp2p.New(libp2p.NoListenAddrs)
+	if err != nil {
+		return errors.Wrap(err, "could not create libp2p host")
+	}
+	defer func() { _ = h.Close() }()
+	ctrl := streams.NewStreamController(ctx, h, genesis.New(), 15*time.Second, dkgArgs.timeout)
+	transport, err := dkg.NewStreamTransport(logger, h, ctrl, addrs)
+	if err != nil {
+		return err
+	}
+
+	output, err := dkg.NewInitiator(logger, transport, dkgArgs.operatorID, sk).Run(ctx, params)
+	if err != nil {
+		return err
+	}
+	output.DepositData.NetworkName = string(networkConfig.Beacon.BeaconNetwork)
+
+	name := output.DepositData.PubKey[:16]
+	keySharesPath := filepath.Join(dkgArgs.outputDir, fmt.Sprintf("keyshares-%s.json", name))
+	if err := writeJSON(keySharesPath, output.KeyShares); err != nil {
+		return err
+	}
+	depositDataPath := filepath.Join(dkgArgs.outputDir, fmt.Sprintf("deposit_data-%s.json", name))
+	if err := writeJSON(depositDataPath, []*dkg.DepositData{output.DepositData}); err != nil {
+		return err
+	}
+	logger.Info("DKG ceremony completed",
+		zap.String("validator", output.KeyShares.Data.PublicKey),
+		zap.String("keyshares", keySharesPath),
+		zap.String("deposit_data", depositDataPath))
+	return nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	raw, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, raw, 0600); err != nil {
+		return errors.Wrapf(err, "could not write %s", path)
+	}
+	return nil
+}
+
+func init() {
+	dkgCmd.Flags().StringVar(&dkgArgs.ceremonyFile, "ceremony", "", "Path to the ceremony file with the network, owner, nonce, withdrawal address and operators")
+	dkgCmd.Flags().StringVarP(&dkgArgs.outputDir, "output-dir", "o", ".", "Directory to write the keyshares and deposit data to")
+	dkgCmd.Flags().DurationVar(&dkgArgs.timeout, "timeout", 2*time.Minute, "Timeout of the ceremony")
+	dkgCmd.Flags().Uint64Var(&dkgArgs.operatorID, "operator-id", 0, "ID of the ceremony operator which initiates it")
+	dkgCmd.Flags().StringVar(&dkgArgs.privateKeyFile, "private-key-file", "", "Keystore of the private key of the initiating operator")
+	dkgCmd.Flags().StringVar(&dkgArgs.passwordFile, "password-file", "", "Password file of the keystore")
+	_ = dkgCmd.MarkFlagRequired("ceremony")
+	_ = dkgCmd.MarkFlagRequired("operator-id")
+	_ = dkgCmd.MarkFlagRequired("private-key-file")
+	_ = dkgCmd.MarkFlagRequired("password-file")
+
+	RootCmd.AddCommand(dkgCmd)
+}
@@ -8,8 +8,13 @@ import (
 
 // Flag names.
 const (
-	privKeyFlag   = "private-key"
-	keysCountFlag = "count"
+	privKeyFlag      = "private-key"
+	operatorIDsFlag  = "operator-ids"
+	operatorKeysFlag = "operator-keys"
+	ownerAddressFlag = "owner-address"
+	ownerNonceFlag   = "owner-nonce"
+	dbPathFlag       = "db-path"
+	outputFlag       = "output"
 )
 
 // AddPrivKeyFlag adds the private key flag to the command
@@ -22,12 +27,63 @@ func GetPrivKeyFlagValue(c *cobra.Command) (string, error) {
 	return c.Flags().GetString(privKeyFlag)
 }
 
-// AddKeysCountFlag adds the keys count flag to the command
-func AddKeysCountFlag(c *cobra.Command) {
-	cliflag.AddPersistentIntFlag(c, keysCountFlag, 4, "Count of threshold keys to be generated", false)
+// AddOperatorIDsFlag adds the operator ids flag to the command
+func AddOperatorIDsFlag(c *cobra.Command) {
+	c.PersistentFlags().UintSlice(operatorIDsFlag, nil, "Comma separated ids of the operators, one of 4, 7, 10 or 13 (required)")
+	_ = c.MarkPersistentFlagRequired(operatorIDsFlag)
 }
 
-// GetKeysCountFlagValue gets the keys count flag from the command
-func GetKeysCountFlagValue(c *cobra.Command) (uint64, error) {
-	return c.Flags().GetUint64(keysCountFlag)
+// GetOperatorIDsFlagValue gets the operator ids flag from the command
+func GetOperatorIDsFlagValue(c *cobra.Command) ([]uint, error) {
+	return c.Flags().GetUintSlice(operatorIDsFlag)
+}
+
+// AddOperatorKeysFlag adds the operator keys flag to the command
+func AddOperatorKeysFlag(c *cobra.Command) {
+	c.PersistentFlags().StringSlice(operatorKeysFlag, nil, "Comma separated base64 encoded public keys of the operators, in the order of --operator-ids. Looked up in --db-path when omitted")
+}
+
+// GetOperatorKeysFlagValue gets the operator keys flag from the command
+func GetOperatorKeysFlagValue(c *cobra.Command) ([]string, error) {
+	return c.Flags().GetStringSlice(operatorKeysFlag)
+}
+
+// AddOwnerAddressFlag adds the owner address flag to the command
+func AddOwnerAddressFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, ownerAddressFlag, "", "Address of the account registering the validator", true)
+}
+
+// GetOwnerAddressFlagValue gets the owner address flag from the command
+func GetOwnerAddressFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(ownerAddressFlag)
+}
+
+// AddOwnerNonceFlag adds the owner nonce flag to the command
+func AddOwnerNonceFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, ownerNonceFlag, 0, "Number of validators the owner registered so far", false)
+}
+
+// GetOwnerNonceFlagValue gets the owner nonce flag from the command
+func GetOwnerNonceFlagValue(c *cobra.Command) (uint64, error) {
+	return c.Flags().GetUint64(ownerNonceFlag)
+}
+
+// AddDBPathFlag adds the db path flag to the command
+func AddDBPathFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, dbPathFlag, "", "Path of a node db to look up the operator public keys in", false)
+}
+
+// GetDBPathFlagValue gets the db path flag from the command
+func GetDBPathFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(dbPathFlag)
+}
+
+// AddOutputFlag adds the output flag to the command
+func AddOutputFlag(c *cobra.Command, value string) {
+	cliflag.AddPersistentStringFlag(c, outputFlag, value, "Path of the output file", false)
+}
+
+// GetOutputFlagValue gets the output flag from the command
+func GetOutputFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(outputFlag)
 }
@@ -3,13 +3,21 @@ package cli
 import (
 	"encoding/base64"
 	"log"
+	"os"
 
 	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/operator/keystore"
 	"github.com/bloxapp/ssv/utils/rsaencryption"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
 
+var generateOperatorKeysArgs struct {
+	passwordFile   string
+	output         string
+	privateKeyFile string
+}
+
 // generateOperatorKeysCmd is the command to generate operator private/public keys
 var generateOperatorKeysCmd = &cobra.Command{
 	Use:   "generate-operator-keys",
@@ -20,15 +28,42 @@ var generateOperatorKeysCmd = &cobra.Command{
 		}
 		logger := zap.L().Named(RootCmd.Short)
 
+		if generateOperatorKeysArgs.passwordFile == "" && generateOperatorKeysArgs.privateKeyFile == "" {
+			logger.Fatal("either --password-file or --private-key-file must be set")
+		}
+
 		pk, sk, err := rsaencryption.GenerateKeys()
 		if err != nil {
 			logger.Fatal("Failed to generate operator keys", zap.Error(err))
 		}
+
+		if generateOperatorKeysArgs.passwordFile != "" {
+			password, err := keystore.ReadPassword(generateOperatorKeysArgs.passwordFile)
+			if err != nil {
+				logger.Fatal("Failed to read password", zap.Error(err))
+			}
+			if _, err := keystore.Save(generateOperatorKeysArgs.output, sk, password); err != nil {
+				logger.Fatal("Failed to save operator keystore", zap.Error(err))
+			}
+			logger.Info("generated public key (base64)", zap.String("pk", base64.StdEncoding.EncodeToString(pk)))
+			logger.Info("saved encrypted private key", zap.String("path", generateOperatorKeysArgs.output))
+			return
+		}
+
+		// The plaintext key is only readable by the owner, and is never printed.
+		if err := os.WriteFile(generateOperatorKeysArgs.privateKeyFile, []byte(base64.StdEncoding.EncodeToString(sk)), 0600); err != nil {
+			logger.Fatal("Failed to save operator private key", zap.Error(err))
+		}
+		logger.Warn("saved the private key in plaintext, use --password-file to save it to an encrypted keystore instead")
 		logger.Info("generated public key (base64)", zap.String("pk", base64.StdEncoding.EncodeToString(pk)))
-		logger.Info("generated private key (base64)", zap.String("sk", base64.StdEncoding.EncodeToString(sk)))
+		logger.Info("saved private key (base64)", zap.String("path", generateOperatorKeysArgs.privateKeyFile))
 	},
 }
 
 func init() {
+	generateOperatorKeysCmd.Flags().StringVar(&generateOperatorKeysArgs.passwordFile, "password-file", "", "Password file to encrypt the private key with, the key is saved to a keystore")
+	generateOperatorKeysCmd.Flags().StringVarP(&generateOperatorKeysArgs.output, "output", "o", "encrypted_private_key.json", "Path to write the keystore to when --password-file is set")
+	generateOperatorKeysCmd.Flags().StringVar(&generateOperatorKeysArgs.privateKeyFile, "private-key-file", "", "Path to write the plaintext private key (base64) to when --password-file isn't set, the value of OperatorPrivateKey")
+
 	RootCmd.AddCommand(generateOperatorKeysCmd)
 }
@@ -0,0 +1,240 @@
+package operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/migrations"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/backup"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/commons"
+)
+
+const backupPollInterval = 2 * time.Second
+
+var dbArgs struct {
+	path    string
+	nodeURL string
+	token   string
+}
+
+// DBCmd is the command to manage the db of SSV node
+var DBCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Manages the database of SSV node",
+}
+
+// dbBackupCmd creates a backup of the db, either through a running node or directly from a stopped node's db
+var dbBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Creates a consistent backup of the database",
+	Long: `Creates a consistent backup of the database.
+With --node-url and --token, the backup is created by the running node into its configured BackupDir.
+Otherwise, the database configured by --config is opened directly, which requires the node to be stopped.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if dbArgs.nodeURL != "" {
+			if err := logging.SetGlobalLogger("info", "capital", "console", ""); err != nil {
+				log.Fatal(err)
+			}
+			logger := zap.L().Named("db")
+			if dbArgs.token == "" {
+				logger.Fatal("--token must be set with --node-url")
+			}
+			manifest, path, err := requestBackup(logger, dbArgs.nodeURL, dbArgs.token)
+			if err != nil {
+				logger.Fatal("could not create backup", zap.Error(err))
+			}
+			logger.Info("created backup on node", zap.String("path", path))
+			printManifest(manifest)
+			return
+		}
+
+		logger, err := setupGlobal(cmd)
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+		if dbArgs.path == "" {
+			logger.Fatal("either --output or --node-url must be set")
+		}
+		networkConfig, _, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		cfg.DBOptions.Ctx = cmd.Context()
+		db, err := storage.GetStorageFactory(logger, cfg.DBOptions)
+		if err != nil {
+			logger.Fatal("could not open db", zap.Error(err))
+		}
+		defer func() {
+			if err := db.Close(logger); err != nil {
+				logger.Error("could not close db", zap.Error(err))
+			}
+		}()
+
+		manifest, err := createBackup(db, networkConfig, dbArgs.path)
+		if err != nil {
+			logger.Fatal("could not create backup", zap.Error(err))
+		}
+		logger.Info("created backup", zap.String("path", dbArgs.path))
+		printManifest(manifest)
+	},
+}
+
+// dbRestoreCmd restores a backup into an empty db
+var dbRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restores a backup into an empty database",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal(cmd)
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+		if dbArgs.path == "" {
+			logger.Fatal("--input must be set")
+		}
+		networkConfig, err := loadNetworkConfig()
+		if err != nil {
+			logger.Fatal("could not load network config", zap.Error(err))
+		}
+
+		cfg.DBOptions.Ctx = cmd.Context()
+		db, err := storage.GetStorageFactory(logger, cfg.DBOptions)
+		if err != nil {
+			logger.Fatal("could not open db", zap.Error(err))
+		}
+		defer func() {
+			if err := db.Close(logger); err != nil {
+				logger.Error("could not close db", zap.Error(err))
+			}
+		}()
+
+		manifest, err := backup.Restore(db, dbArgs.path, backup.Expectations{
+			Network:           networkConfig.Name,
+			MaxMigrationLevel: migrations.LatestLevel(),
+		})
+		if err != nil {
+			logger.Fatal("could not restore backup", zap.Error(err))
+		}
+		logger.Info("restored backup", zap.String("path", dbArgs.path), zap.Time("created_at", manifest.CreatedAt))
+	},
+}
+
+// dbInspectCmd verifies a backup and prints its manifest
+var dbInspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Verifies a backup and prints its manifest",
+	Run: func(cmd *cobra.Command, args []string) {
+		if dbArgs.path == "" {
+			log.Fatal("--input must be set")
+		}
+		manifest, err := backup.Inspect(dbArgs.path)
+		if err != nil {
+			log.Fatal("invalid backup: ", err)
+		}
+		printManifest(manifest)
+	},
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, dbRestoreCmd)
+	dbRestoreCmd.Flags().StringVarP(&dbArgs.path, "input", "i", "", "Path to the backup directory")
+
+	dbBackupCmd.PersistentFlags().StringVarP(&globalArgs.ConfigPath, "config", "c", "./config/config.yaml", "Path to configuration file")
+	dbBackupCmd.PersistentFlags().StringVarP(&globalArgs.ShareConfigPath, "share-config", "s", "", "Path to local share configuration file")
+	dbBackupCmd.Flags().StringVarP(&dbArgs.path, "output", "o", "", "Path to the backup directory, must not contain a backup")
+	dbBackupCmd.Flags().StringVar(&dbArgs.nodeURL, "node-url", "", "URL of the SSV API of a running node, e.g. http://localhost:16000")
+	dbBackupCmd.Flags().StringVar(&dbArgs.token, "token", "", "SSVAPIToken of the running node")
+
+	dbInspectCmd.Flags().StringVarP(&dbArgs.path, "input", "i", "", "Path to the backup directory")
+
+	DBCmd.AddCommand(dbBackupCmd, dbRestoreCmd, dbInspectCmd)
+}
+
+// createBackup creates a backup of the given db along with a manifest describing it
+func createBackup(db basedb.IDb, networkConfig networkconfig.NetworkConfig, dir string) (*backup.Manifest, error) {
+	level, err := migrations.Level(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get migration level")
+	}
+	return backup.Create(db, dir, backup.Manifest{
+		Network:        networkConfig.Name,
+		ForkVersion:    networkConfig.SSVForkVersion(networkConfig.Beacon.EstimatedCurrentEpoch()),
+		MigrationLevel: level,
+		NodeVersion:    commons.GetBuildData(),
+	})
+}
+
+type backupStatus struct {
+	Name     string           `json:"name"`
+	Path     string           `json:"path"`
+	Status   string           `json:"status"`
+	Error    string           `json:"error"`
+	Manifest *backup.Manifest `json:"manifest"`
+}
+
+// requestBackup triggers a backup on a running node and waits for it to complete
+func requestBackup(logger *zap.Logger, nodeURL, token string) (*backup.Manifest, string, error) {
+	nodeURL = strings.TrimSuffix(nodeURL, "/")
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	var status backupStatus
+	if err := doJSON(client, http.MethodPost, nodeURL+"/v1/node/backups", token, http.StatusAccepted, &status); err != nil {
+		return nil, "", errors.Wrap(err, "could not start backup")
+	}
+	logger.Info("backup started", zap.String("name", status.Name))
+
+	for status.Status == "running" {
+		time.Sleep(backupPollInterval)
+		if err := doJSON(client, http.MethodGet, nodeURL+"/v1/node/backups/"+status.Name, token, http.StatusOK, &status); err != nil {
+			return nil, "", errors.Wrap(err, "could not get backup status")
+		}
+	}
+	if status.Status != "completed" {
+		return nil, "", errors.Errorf("backup %s: %s", status.Status, status.Error)
+	}
+	return status.Manifest, status.Path, nil
+}
+
+func doJSON(client *http.Client, method, url, token string, expectedStatus int, dest interface{}) error {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectedStatus {
+		var apiErr struct {
+			Message string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, apiErr.Message)
+	}
+	return json.NewDecoder(resp.Body).Decode(dest)
+}
+
+func printManifest(manifest *backup.Manifest) {
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		log.Fatal("could not marshal manifest: ", err)
+	}
+	fmt.Fprintln(os.Stdout, string(raw))
+}
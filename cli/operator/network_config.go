@@ -0,0 +1,50 @@
+package operator
+
+import (
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/networkconfig"
+)
+
+// NetworkConfigCmd is the command to inspect the network config of SSV node
+var NetworkConfigCmd = &cobra.Command{
+	Use:   "network-config",
+	Short: "Inspects the network config of the node",
+}
+
+// networkConfigDumpCmd prints the effective network config
+var networkConfigDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Prints the effective network config as YAML, which can be used as a template for a custom network",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal(cmd)
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+		networkConfig, err := loadNetworkConfig()
+		if err != nil {
+			logger.Fatal("could not load network config", zap.Error(err))
+		}
+
+		enc := yaml.NewEncoder(os.Stdout)
+		enc.SetIndent(2)
+		if err := enc.Encode(networkconfig.NewCustomConfig(networkConfig)); err != nil {
+			logger.Fatal("could not encode network config", zap.Error(err))
+		}
+		if err := enc.Close(); err != nil {
+			logger.Fatal("could not encode network config", zap.Error(err))
+		}
+	},
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, NetworkConfigCmd)
+
+	NetworkConfigCmd.AddCommand(networkConfigDumpCmd)
+}
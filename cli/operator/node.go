@@ -7,6 +7,9 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/bloxapp/ssv/api/handlers"
 	apiserver "github.com/bloxapp/ssv/api/server"
@@ -17,6 +20,7 @@ import (
 
 	"github.com/bloxapp/ssv/beacon/goclient"
 	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/dkg"
 	"github.com/bloxapp/ssv/ekm"
 	"github.com/bloxapp/ssv/eth1"
 	"github.com/bloxapp/ssv/eth1/goeth"
@@ -32,26 +36,36 @@ import (
 	"github.com/bloxapp/ssv/networkconfig"
 	"github.com/bloxapp/ssv/nodeprobe"
 	"github.com/bloxapp/ssv/operator"
+	"github.com/bloxapp/ssv/operator/doppelganger"
+	"github.com/bloxapp/ssv/operator/keystore"
+	"github.com/bloxapp/ssv/operator/performance"
 	"github.com/bloxapp/ssv/operator/slot_ticker"
 	operatorstorage "github.com/bloxapp/ssv/operator/storage"
 	"github.com/bloxapp/ssv/operator/validator"
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/capture"
+	"github.com/bloxapp/ssv/protocol/v2/dutytrace"
+	qbftcontroller "github.com/bloxapp/ssv/protocol/v2/qbft/controller"
 	"github.com/bloxapp/ssv/protocol/v2/types"
 	registrystorage "github.com/bloxapp/ssv/registry/storage"
 	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/backup"
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/bloxapp/ssv/utils/commons"
 	"github.com/bloxapp/ssv/utils/format"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
 )
 
 type config struct {
 	global_config.GlobalConfig `yaml:"global"`
-	DBOptions                  basedb.Options         `yaml:"db"`
-	SSVOptions                 operator.Options       `yaml:"ssv"`
-	ETH1Options                eth1.Options           `yaml:"eth1"`
-	ETH2Options                beaconprotocol.Options `yaml:"eth2"`
-	P2pNetworkConfig           p2pv1.Config           `yaml:"p2p"`
+	DBOptions                  basedb.Options          `yaml:"db"`
+	SSVOptions                 operator.Options        `yaml:"ssv"`
+	ETH1Options                eth1.Options            `yaml:"eth1"`
+	ETH2Options                beaconprotocol.Options  `yaml:"eth2"`
+	P2pNetworkConfig           p2pv1.Config            `yaml:"p2p"`
+	RemoteSigner               ekm.RemoteSignerOptions `yaml:"remote_signer"`
+	DKG                        dkg.Options             `yaml:"dkg"`
 
 	OperatorPrivateKey         string `yaml:"OperatorPrivateKey" env:"OPERATOR_KEY" env-description:"Operator private key, used to decrypt contract events"`
 	GenerateOperatorPrivateKey bool   `yaml:"GenerateOperatorPrivateKey" env:"GENERATE_OPERATOR_KEY" env-description:"Whether to generate operator key if none is passed by config"`
@@ -59,10 +73,23 @@ type config struct {
 	EnableProfile              bool   `yaml:"EnableProfile" env:"ENABLE_PROFILE" env-description:"flag that indicates whether go profiling tools are enabled"`
 	NetworkPrivateKey          string `yaml:"NetworkPrivateKey" env:"NETWORK_PRIVATE_KEY" env-description:"private key for network identity"`
 
+	KeyStore struct {
+		PrivateKeyFile string `yaml:"PrivateKeyFile" env:"OPERATOR_KEY_FILE" env-description:"Operator private key keystore file, used instead of the plaintext key in the db"`
+		PasswordFile   string `yaml:"PasswordFile" env:"OPERATOR_KEY_PASSWORD_FILE" env-description:"Password file of the operator private key keystore"`
+	} `yaml:"KeyStore"`
+
 	WsAPIPort int  `yaml:"WebSocketAPIPort" env:"WS_API_PORT" env-description:"Port to listen on for the websocket API."`
 	WithPing  bool `yaml:"WithPing" env:"WITH_PING" env-description:"Whether to send websocket ping messages'"`
 
-	SSVAPIPort int `yaml:"SSVAPIPort" env:"SSV_API_PORT" env-description:"Port to listen on for the SSV API."`
+	SSVAPIPort  int    `yaml:"SSVAPIPort" env:"SSV_API_PORT" env-description:"Port to listen on for the SSV API."`
+	BackupDir   string `yaml:"BackupDir" env:"BACKUP_DIR" env-description:"Directory to create db backups in when triggered through the SSV API, disabled when empty or when SSVAPIToken is not set"`
+	SSVAPIToken string `yaml:"SSVAPIToken" env:"SSV_API_TOKEN" env-description:"Bearer token required by the SSV API endpoints which act on validators or the db, such as exits and backups. These endpoints are disabled when empty"`
+
+	Performance performance.Config `yaml:"performance"`
+	DutyTrace   dutytrace.Config   `yaml:"dutytrace"`
+	Capture     capture.Config     `yaml:"capture"`
+
+	Doppelganger doppelganger.Config `yaml:"doppelganger"`
 
 	LocalEventsPath string `yaml:"LocalEventsPath" env:"EVENTS_PATH" env-description:"path to local events"`
 }
@@ -97,9 +124,18 @@ var StartNodeCmd = &cobra.Command{
 		}
 		nodeStorage, operatorData := setupOperatorStorage(logger, db)
 
-		keyManager, err := ekm.NewETHKeyManagerSigner(logger, db, networkConfig, cfg.SSVOptions.ValidatorOptions.BuilderProposals)
-		if err != nil {
-			logger.Fatal("could not create new eth-key-manager signer", zap.Error(err))
+		var keyManager spectypes.KeyManager
+		if cfg.RemoteSigner.URL != "" {
+			logger.Info("using remote signer", zap.String("url", cfg.RemoteSigner.URL))
+			keyManager, err = ekm.NewRemoteKeyManager(logger, db, networkConfig, cfg.RemoteSigner)
+			if err != nil {
+				logger.Fatal("could not create remote key manager", zap.Error(err))
+			}
+		} else {
+			keyManager, err = ekm.NewETHKeyManagerSigner(logger, db, networkConfig, cfg.SSVOptions.ValidatorOptions.BuilderProposals)
+			if err != nil {
+				logger.Fatal("could not create new eth-key-manager signer", zap.Error(err))
+			}
 		}
 
 		cfg.P2pNetworkConfig.Ctx = cmd.Context()
@@ -111,8 +147,9 @@ var StartNodeCmd = &cobra.Command{
 
 		cfg.P2pNetworkConfig.Permissioned = permissioned
 		cfg.P2pNetworkConfig.WhitelistedOperatorKeys = append(cfg.P2pNetworkConfig.WhitelistedOperatorKeys, networkConfig.WhitelistedOperatorKeys...)
+		cfg.P2pNetworkConfig.Shares = nodeStorage.Shares()
 
-		p2pNetwork := setupP2P(forkVersion, operatorData, db, logger, networkConfig)
+		p2pNetwork := setupP2P(forkVersion, operatorData, nodeStorage, db, logger, networkConfig)
 
 		ctx := cmd.Context()
 		slotTicker := slot_ticker.NewTicker(ctx, networkConfig)
@@ -151,11 +188,67 @@ var StartNodeCmd = &cobra.Command{
 		cfg.SSVOptions.ValidatorOptions.RegistryStorage = nodeStorage
 		cfg.SSVOptions.ValidatorOptions.GasLimit = cfg.ETH2Options.GasLimit
 
+		performanceTracker := performance.New(performance.Options{
+			Ctx:     cmd.Context(),
+			DB:      db,
+			Shares:  nodeStorage.Shares(),
+			Network: networkConfig.Beacon,
+			Config:  cfg.Performance,
+		})
+		go performanceTracker.Start(logger)
+		decidedHandlers := []qbftcontroller.NewDecidedHandler{performanceTracker.HandleDecided(logger)}
+
+		var traces *handlers.Traces
+		if cfg.DutyTrace.Enabled {
+			dutyTracer := dutytrace.New(dutytrace.Options{
+				Ctx:     cmd.Context(),
+				DB:      db,
+				Network: networkConfig.Beacon,
+				Config:  cfg.DutyTrace,
+			})
+			go dutyTracer.Start(logger)
+			cfg.SSVOptions.ValidatorOptions.DutyTracer = dutyTracer
+			traces = &handlers.Traces{
+				Logger:   logger,
+				Recorder: dutyTracer,
+			}
+		}
+
+		if cfg.Capture.Path != "" {
+			recorder, err := capture.New(capture.Options{
+				Ctx:    cmd.Context(),
+				Config: cfg.Capture,
+			})
+			if err != nil {
+				logger.Fatal("could not create capture recorder", zap.Error(err))
+			}
+			go recorder.Start(logger)
+			cfg.SSVOptions.ValidatorOptions.Capture = recorder
+		}
+
+		var doppelgangerProvider handlers.DoppelgangerProvider
+		if cfg.Doppelganger.Enabled {
+			doppelgangerHandler := doppelganger.New(doppelganger.Options{
+				Ctx:     cmd.Context(),
+				Beacon:  eth2Client,
+				Network: networkConfig.Beacon,
+				Config:  cfg.Doppelganger,
+			})
+			go doppelgangerHandler.Start(logger)
+			cfg.SSVOptions.ValidatorOptions.DutyGuard = doppelgangerHandler
+			doppelgangerProvider = doppelgangerHandler
+		}
+
 		if cfg.WsAPIPort != 0 {
 			ws := exporterapi.NewWsServer(cmd.Context(), nil, http.NewServeMux(), cfg.WithPing)
 			cfg.SSVOptions.WS = ws
 			cfg.SSVOptions.WsAPIPort = cfg.WsAPIPort
-			cfg.SSVOptions.ValidatorOptions.NewDecidedHandler = decided.NewStreamPublisher(logger, ws)
+			decidedHandlers = append(decidedHandlers, decided.NewStreamPublisher(logger, ws))
+		}
+		cfg.SSVOptions.ValidatorOptions.NewDecidedHandler = func(msg *specqbft.SignedMessage) {
+			for _, handler := range decidedHandlers {
+				handler(msg)
+			}
 		}
 
 		cfg.SSVOptions.ValidatorOptions.DutyRoles = []spectypes.BeaconRole{spectypes.BNRoleAttester} // TODO could be better to set in other place
@@ -198,11 +291,29 @@ var StartNodeCmd = &cobra.Command{
 		if err := p2pNetwork.Setup(logger); err != nil {
 			logger.Fatal("failed to setup network", zap.Error(err))
 		}
+		if cfg.DKG.Enabled {
+			participant := dkg.NewParticipant(nodeStorage.GetPrivateKey, dkg.RegisteredOperators(nodeStorage))
+			p2pNetwork.RegisterStreamHandler(logger.Named(logging.NameDKG), dkg.ProtocolID, participant.HandleRequest)
+			logger.Info("taking part in dkg ceremonies")
+		}
 		if err := p2pNetwork.Start(logger); err != nil {
 			logger.Fatal("failed to start network", zap.Error(err))
 		}
 
 		if cfg.SSVAPIPort > 0 {
+			var backups *handlers.Backups
+			if cfg.BackupDir != "" && cfg.SSVAPIToken == "" {
+				logger.Warn("db backups through the SSV API are disabled, SSVAPIToken must be set")
+			}
+			if cfg.BackupDir != "" {
+				backups = &handlers.Backups{
+					Logger: logger,
+					Dir:    cfg.BackupDir,
+					Create: func(dir string) (*backup.Manifest, error) {
+						return createBackup(db, networkConfig, dir)
+					},
+				}
+			}
 			apiServer := apiserver.New(
 				logger,
 				fmt.Sprintf(":%d", cfg.SSVAPIPort),
@@ -213,8 +324,34 @@ var StartNodeCmd = &cobra.Command{
 					TopicIndex: p2pNetwork.(handlers.TopicIndex),
 				},
 				&handlers.Validators{
+					Shares:       nodeStorage.Shares(),
+					Storage:      validatorCtrl.QBFTStores(),
+					Doppelganger: doppelgangerProvider,
+				},
+				&handlers.Operators{
+					Logger:    logger,
+					Operators: nodeStorage,
+				},
+				&handlers.Clusters{
 					Shares: nodeStorage.Shares(),
 				},
+				&handlers.Performance{
+					Logger:  logger,
+					Tracker: performanceTracker,
+				},
+				backups,
+				&handlers.Exits{
+					Shares:  nodeStorage.Shares(),
+					Network: networkConfig.Beacon,
+					StartExit: func(pubKey phase0.BLSPubKey, slot phase0.Slot) error {
+						return validatorCtrl.ExitValidator(logger, pubKey, slot)
+					},
+					StartBLSToExecutionChange: func(pubKey phase0.BLSPubKey, slot phase0.Slot, address bellatrix.ExecutionAddress) error {
+						return validatorCtrl.ChangeWithdrawalCredentials(logger, pubKey, slot, address)
+					},
+				},
+				traces,
+				cfg.SSVAPIToken,
 			)
 			go func() {
 				err := apiServer.Run()
@@ -235,7 +372,7 @@ func init() {
 }
 
 func setupGlobal(cmd *cobra.Command) (*zap.Logger, error) {
-	commons.SetBuildData(cmd.Parent().Short, cmd.Parent().Version)
+	commons.SetBuildData(cmd.Root().Short, cmd.Root().Version)
 	log.Printf("starting SSV node (version %s)", commons.GetBuildData())
 
 	if globalArgs.ConfigPath != "" {
@@ -314,9 +451,18 @@ func setupOperatorStorage(logger *zap.Logger, db basedb.IDb) (operatorstorage.St
 	if err != nil {
 		logger.Fatal("failed to create node storage", zap.Error(err))
 	}
-	operatorPubKey, err := nodeStorage.SetupPrivateKey(logger, cfg.OperatorPrivateKey, cfg.GenerateOperatorPrivateKey)
-	if err != nil {
-		logger.Fatal("could not setup operator private key", zap.Error(err))
+	var operatorPubKey []byte
+	if cfg.KeyStore.PrivateKeyFile != "" {
+		operatorPubKey, err = setupOperatorKeystore(logger, nodeStorage)
+		if err != nil {
+			logger.Fatal("could not setup operator private key from keystore", zap.Error(err))
+		}
+	} else {
+		logger.Warn("operator private key is stored in plaintext in the db, consider migrating it to an encrypted keystore with 'operator-key migrate'")
+		operatorPubKey, err = nodeStorage.SetupPrivateKey(logger, cfg.OperatorPrivateKey, cfg.GenerateOperatorPrivateKey)
+		if err != nil {
+			logger.Fatal("could not setup operator private key", zap.Error(err))
+		}
 	}
 
 	_, found, err := nodeStorage.GetPrivateKey()
@@ -337,19 +483,60 @@ func setupOperatorStorage(logger *zap.Logger, db basedb.IDb) (operatorstorage.St
 	return nodeStorage, operatorData
 }
 
+// setupOperatorKeystore loads the operator private key from the keystore file into the node storage
+func setupOperatorKeystore(logger *zap.Logger, nodeStorage operatorstorage.Storage) ([]byte, error) {
+	if cfg.OperatorPrivateKey != "" || cfg.GenerateOperatorPrivateKey {
+		return nil, errors.New("OperatorPrivateKey and GenerateOperatorPrivateKey can't be used with KeyStore")
+	}
+	if cfg.KeyStore.PasswordFile == "" {
+		return nil, errors.New("KeyStore.PasswordFile must be set")
+	}
+	sk, err := keystore.Load(cfg.KeyStore.PrivateKeyFile, cfg.KeyStore.PasswordFile)
+	if err != nil {
+		return nil, err
+	}
+	operatorPublicKey, err := rsaencryption.ExtractPublicKey(sk)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to extract operator public key")
+	}
+
+	// a plaintext key left in the db must be the same key, and should be removed
+	storedKey, found, err := nodeStorage.GetPrivateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get operator private key from db")
+	}
+	if found {
+		if !storedKey.Equal(sk) {
+			return nil, errors.New("keystore doesn't match the operator private key in the db")
+		}
+		logger.Warn("plaintext operator private key is still stored in the db, remove it with 'operator-key migrate'")
+	}
+
+	nodeStorage.SetPrivateKey(sk)
+	logger.Info("loaded operator private key from keystore",
+		zap.String("path", cfg.KeyStore.PrivateKeyFile),
+		zap.String("public-key", operatorPublicKey),
+	)
+	return []byte(operatorPublicKey), nil
+}
+
 func setupSSVNetwork(logger *zap.Logger) (networkconfig.NetworkConfig, forksprotocol.ForkVersion, error) {
-	networkConfig, err := networkconfig.GetNetworkConfigByName(cfg.SSVOptions.NetworkName)
+	networkConfig, err := loadNetworkConfig()
 	if err != nil {
 		return networkconfig.NetworkConfig{}, "", err
 	}
 
+	if err := networkConfig.Forks.Validate(); err != nil {
+		return networkconfig.NetworkConfig{}, "", errors.Wrap(err, "invalid fork schedule")
+	}
+
 	types.SetDefaultDomain(networkConfig.Domain)
 
 	currentEpoch := networkConfig.Beacon.EstimatedCurrentEpoch()
-	forkVersion := forksprotocol.GetCurrentForkVersion(currentEpoch)
+	forkVersion := networkConfig.SSVForkVersion(currentEpoch)
 
 	logger.Info("setting ssv network",
-		fields.Network(cfg.SSVOptions.NetworkName),
+		fields.Network(networkConfig.Name),
 		fields.Domain(networkConfig.Domain),
 		fields.Fork(forkVersion),
 		fields.Config(networkConfig),
@@ -357,9 +544,18 @@ func setupSSVNetwork(logger *zap.Logger) (networkconfig.NetworkConfig, forksprot
 	return networkConfig, forkVersion, nil
 }
 
+// loadNetworkConfig returns the custom network config if one is set, or the supported network by name otherwise.
+func loadNetworkConfig() (networkconfig.NetworkConfig, error) {
+	if cfg.SSVOptions.NetworkConfigPath != "" {
+		return networkconfig.LoadNetworkConfig(cfg.SSVOptions.NetworkConfigPath)
+	}
+	return networkconfig.GetNetworkConfigByName(cfg.SSVOptions.NetworkName)
+}
+
 func setupP2P(
 	forkVersion forksprotocol.ForkVersion,
 	operatorData *registrystorage.OperatorData,
+	nodeStorage operatorstorage.Storage,
 	db basedb.IDb,
 	logger *zap.Logger,
 	network networkconfig.NetworkConfig,
@@ -370,11 +566,7 @@ func setupP2P(
 		logger.Fatal("failed to setup network private key", zap.Error(err))
 	}
 
-	cfg.P2pNetworkConfig.NodeStorage, err = operatorstorage.NewNodeStorage(logger, db)
-	if err != nil {
-		logger.Fatal("failed to create node storage", zap.Error(err))
-	}
-
+	cfg.P2pNetworkConfig.NodeStorage = nodeStorage
 	cfg.P2pNetworkConfig.NetworkPrivateKey = netPrivKey
 	cfg.P2pNetworkConfig.ForkVersion = forkVersion
 	cfg.P2pNetworkConfig.OperatorID = format.OperatorID(operatorData.PublicKey)
@@ -412,6 +604,7 @@ func setupNodes(
 		Ctx:                  cfg.ETH2Options.Context,
 		NodeAddr:             cfg.ETH1Options.ETH1Addr,
 		ConnectionTimeout:    cfg.ETH1Options.ETH1ConnectionTimeout,
+		FollowDistance:       cfg.ETH1Options.ETH1FollowDistance,
 		ContractABI:          eth1.ContractABI(cfg.ETH1Options.AbiVersion),
 		RegistryContractAddr: network.RegistryContractAddr,
 		AbiVersion:           cfg.ETH1Options.AbiVersion,
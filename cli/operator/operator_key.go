@@ -0,0 +1,136 @@
+package operator
+
+import (
+	"crypto/rsa"
+	"log"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/operator/keystore"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+)
+
+var operatorKeyArgs struct {
+	passwordFile string
+	output       string
+}
+
+// OperatorKeyCmd is the command to manage the operator private key of SSV node
+var OperatorKeyCmd = &cobra.Command{
+	Use:   "operator-key",
+	Short: "Manages the operator private key of SSV node",
+}
+
+// operatorKeyMigrateCmd moves the plaintext operator private key from the db into an encrypted keystore
+var operatorKeyMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Moves the plaintext operator private key from the db into an encrypted keystore, the node must be stopped",
+	Long: `Moves the plaintext operator private key from the db into an encrypted keystore, the node must be stopped.
+The keystore is written to --output (or verified against the key in the db if it already exists),
+then the plaintext key is deleted from the db configured by --config.
+The db files holding the key are rewritten without it, but the filesystem may still keep the
+freed blocks, and backups or copies of the db taken earlier still contain the key.
+Afterwards, set KeyStore.PrivateKeyFile and KeyStore.PasswordFile in the config and remove OperatorPrivateKey.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal(cmd)
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+		if operatorKeyArgs.passwordFile == "" {
+			logger.Fatal("--password-file must be set")
+		}
+		if cfg.OperatorPrivateKey != "" {
+			logger.Fatal("OperatorPrivateKey is set in the config, it would be written back into the db on the next start; remove it before migrating")
+		}
+		password, err := keystore.ReadPassword(operatorKeyArgs.passwordFile)
+		if err != nil {
+			logger.Fatal("could not read password", zap.Error(err))
+		}
+
+		cfg.DBOptions.Ctx = cmd.Context()
+		db, err := storage.GetStorageFactory(logger, cfg.DBOptions)
+		if err != nil {
+			logger.Fatal("could not open db", zap.Error(err))
+		}
+		defer func() {
+			if err := db.Close(logger); err != nil {
+				logger.Error("could not close db", zap.Error(err))
+			}
+		}()
+
+		nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+		if err != nil {
+			logger.Fatal("could not create node storage", zap.Error(err))
+		}
+		sk, found, err := nodeStorage.GetPrivateKey()
+		if err != nil {
+			logger.Fatal("could not get operator private key", zap.Error(err))
+		}
+		if !found {
+			logger.Fatal("no plaintext operator private key in the db, nothing to migrate")
+		}
+
+		if err := writeOperatorKeystore(logger, sk, password); err != nil {
+			logger.Fatal("could not write keystore", zap.Error(err))
+		}
+
+		// Only wipe the db once the keystore is known to decrypt into the same key.
+		loaded, err := keystore.Load(operatorKeyArgs.output, operatorKeyArgs.passwordFile)
+		if err != nil {
+			logger.Fatal("could not verify keystore", zap.Error(err))
+		}
+		if !loaded.Equal(sk) {
+			logger.Fatal("keystore doesn't match the operator private key in the db")
+		}
+		if err := nodeStorage.DeletePrivateKey(); err != nil {
+			logger.Fatal("could not delete operator private key from the db", zap.Error(err))
+		}
+		logger.Info("deleted plaintext operator private key from the db")
+
+		logger.Info("operator private key migrated, set KeyStore.PrivateKeyFile and KeyStore.PasswordFile in the config before starting the node",
+			zap.String("keystore", operatorKeyArgs.output),
+			zap.String("password_file", operatorKeyArgs.passwordFile),
+		)
+	},
+}
+
+// writeOperatorKeystore saves the operator private key to a new keystore,
+// or verifies that an existing keystore holds the same key so that migration can be resumed.
+func writeOperatorKeystore(logger *zap.Logger, sk *rsa.PrivateKey, password string) error {
+	if _, err := os.Stat(operatorKeyArgs.output); err == nil {
+		existing, err := keystore.Load(operatorKeyArgs.output, operatorKeyArgs.passwordFile)
+		if err != nil {
+			return errors.Wrap(err, "could not load existing keystore")
+		}
+		if !existing.Equal(sk) {
+			return errors.New("existing keystore doesn't match the operator private key in the db")
+		}
+		logger.Info("using existing keystore", zap.String("path", operatorKeyArgs.output))
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	ks, err := keystore.Save(operatorKeyArgs.output, rsaencryption.PrivateKeyToByte(sk), password)
+	if err != nil {
+		return err
+	}
+	logger.Info("saved encrypted operator private key",
+		zap.String("path", operatorKeyArgs.output),
+		zap.String("public-key", ks.PubKey),
+	)
+	return nil
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, OperatorKeyCmd)
+	operatorKeyMigrateCmd.Flags().StringVar(&operatorKeyArgs.passwordFile, "password-file", "", "Password file to encrypt the keystore with")
+	operatorKeyMigrateCmd.Flags().StringVarP(&operatorKeyArgs.output, "output", "o", "encrypted_private_key.json", "Path to write the keystore to")
+
+	OperatorKeyCmd.AddCommand(operatorKeyMigrateCmd)
+}
@@ -0,0 +1,105 @@
+package operator
+
+import (
+	"bufio"
+	"encoding/hex"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/operator/replay"
+	"github.com/bloxapp/ssv/protocol/v2/capture"
+)
+
+var replayArgs struct {
+	path      string
+	validator string
+	step      bool
+}
+
+// ReplayCmd is the command to replay a capture of a validator
+var ReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Replays the captured messages, duties and beacon responses of a validator deterministically, without signing, broadcasting or submitting",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal(cmd)
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+		if replayArgs.path == "" {
+			logger.Fatal("--input must be set")
+		}
+		// sets the domain of the captured messages
+		networkConfig, _, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		records, err := capture.ReadFile(replayArgs.path)
+		if err == io.ErrUnexpectedEOF {
+			logger.Warn("capture was cut short, replaying the complete records", zap.Int("records", len(records)))
+		} else if err != nil {
+			logger.Fatal("could not read capture", zap.Error(err))
+		}
+
+		var pubKey []byte
+		if replayArgs.validator != "" {
+			pubKey, err = hex.DecodeString(strings.TrimPrefix(replayArgs.validator, "0x"))
+			if err != nil {
+				logger.Fatal("invalid validator public key", zap.Error(err))
+			}
+		}
+
+		r, err := replay.New(cmd.Context(), logger, replay.Options{
+			BeaconNetwork: networkConfig.Beacon,
+			Records:       records,
+			PubKey:        pubKey,
+		})
+		if err != nil {
+			logger.Fatal("could not setup replay", zap.Error(err), zap.Strings("captured_validators", replay.Validators(records)))
+		}
+		defer func() {
+			if err := r.Close(logger); err != nil {
+				logger.Error("could not close replay", zap.Error(err))
+			}
+		}()
+
+		stdin := bufio.NewReader(os.Stdin)
+		replayed := 0
+		for {
+			record, err := r.Step(logger)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				logger.Fatal("could not replay record", zap.Error(err))
+			}
+			replayed++
+			if replayArgs.step && (record.Kind == capture.KindMessage || record.Kind == capture.KindDuty) {
+				logger.Info("🔁 replayed record, press enter to continue", zap.Int("record", replayed), zap.Stringer("kind", record.Kind))
+				if _, err := stdin.ReadString('\n'); err != nil {
+					break
+				}
+			}
+		}
+
+		logger.Info("🔁 replay finished",
+			zap.Int("records", replayed),
+			zap.Int("broadcasts", len(r.Network.Broadcasts)),
+			zap.Any("submissions", r.Beacon.Submissions),
+		)
+	},
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, ReplayCmd)
+
+	ReplayCmd.Flags().StringVarP(&replayArgs.path, "input", "i", "", "Path to the capture log")
+	ReplayCmd.Flags().StringVar(&replayArgs.validator, "validator", "", "Public key of the validator to replay, can be omitted if a single validator was captured")
+	ReplayCmd.Flags().BoolVar(&replayArgs.step, "step", false, "Waits for enter after every replayed message or duty")
+}
@@ -0,0 +1,154 @@
+package operator
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/ekm"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+var slashingProtectionArgs struct {
+	path string
+}
+
+// SlashingProtectionCmd is the command to move slashing protection history in and out of SSV node
+var SlashingProtectionCmd = &cobra.Command{
+	Use:   "slashing-protection",
+	Short: "Imports and exports slashing protection history in the EIP-3076 interchange format",
+}
+
+// slashingProtectionExportCmd exports the slashing protection history of the db
+var slashingProtectionExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports the slashing protection history into an EIP-3076 interchange file, the node must be stopped",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal(cmd)
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+		if slashingProtectionArgs.path == "" {
+			logger.Fatal("--output must be set")
+		}
+		networkConfig, _, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		cfg.DBOptions.Ctx = cmd.Context()
+		db, err := storage.GetStorageFactory(logger, cfg.DBOptions)
+		if err != nil {
+			logger.Fatal("could not open db", zap.Error(err))
+		}
+		defer func() {
+			if err := db.Close(logger); err != nil {
+				logger.Error("could not close db", zap.Error(err))
+			}
+		}()
+
+		shareKeys, err := loadShareKeys(logger, db)
+		if err != nil {
+			logger.Fatal("could not load shares", zap.Error(err))
+		}
+		signerStorage := ekm.NewSignerStorage(db, networkConfig.Beacon, logger)
+		interchange, err := ekm.ExportSlashingProtection(logger, signerStorage, shareKeys, networkConfig.GenesisValidatorsRoot())
+		if err != nil {
+			logger.Fatal("could not export slashing protection", zap.Error(err))
+		}
+		raw, err := json.MarshalIndent(interchange, "", "  ")
+		if err != nil {
+			logger.Fatal("could not marshal interchange", zap.Error(err))
+		}
+		if err := os.WriteFile(slashingProtectionArgs.path, raw, 0600); err != nil {
+			logger.Fatal("could not write interchange file", zap.Error(err))
+		}
+		logger.Info("exported slashing protection",
+			zap.String("path", slashingProtectionArgs.path),
+			zap.Int("keys", len(interchange.Data)),
+		)
+	},
+}
+
+// slashingProtectionImportCmd merges an interchange file into the slashing protection history of the db
+var slashingProtectionImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Imports an EIP-3076 interchange file, keeping the higher watermarks, the node must be stopped",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal(cmd)
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+		if slashingProtectionArgs.path == "" {
+			logger.Fatal("--input must be set")
+		}
+		networkConfig, _, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		raw, err := os.ReadFile(slashingProtectionArgs.path)
+		if err != nil {
+			logger.Fatal("could not read interchange file", zap.Error(err))
+		}
+		interchange := &ekm.Interchange{}
+		if err := json.Unmarshal(raw, interchange); err != nil {
+			logger.Fatal("could not unmarshal interchange", zap.Error(err))
+		}
+		if networkConfig.GenesisValidatorsRoot() == (phase0.Root{}) {
+			logger.Warn("genesis validators root of the network is unknown, skipping its verification")
+		}
+
+		cfg.DBOptions.Ctx = cmd.Context()
+		db, err := storage.GetStorageFactory(logger, cfg.DBOptions)
+		if err != nil {
+			logger.Fatal("could not open db", zap.Error(err))
+		}
+		defer func() {
+			if err := db.Close(logger); err != nil {
+				logger.Error("could not close db", zap.Error(err))
+			}
+		}()
+
+		shareKeys, err := loadShareKeys(logger, db)
+		if err != nil {
+			logger.Fatal("could not load shares", zap.Error(err))
+		}
+		signerStorage := ekm.NewSignerStorage(db, networkConfig.Beacon, logger)
+		result, err := ekm.ImportSlashingProtection(logger, signerStorage, shareKeys, interchange, networkConfig.GenesisValidatorsRoot())
+		if err != nil {
+			logger.Fatal("could not import slashing protection", zap.Error(err))
+		}
+		logger.Info("imported slashing protection",
+			zap.Int("keys", result.Keys),
+			zap.Int("skipped_keys", result.Skipped),
+			zap.Int("raised_attestations", result.Attestations),
+			zap.Int("raised_proposals", result.Proposals),
+		)
+	},
+}
+
+// loadShareKeys maps the shares of the operator in the registry to their validators,
+// as slashing protection is kept by share public key and interchange files refer to validator public keys
+func loadShareKeys(logger *zap.Logger, db basedb.IDb) (*ekm.ShareKeys, error) {
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	if err != nil {
+		return nil, err
+	}
+	return ekm.NewShareKeys(nodeStorage.Shares().List()), nil
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, SlashingProtectionCmd)
+	slashingProtectionExportCmd.Flags().StringVarP(&slashingProtectionArgs.path, "output", "o", "", "Path to write the interchange file to")
+	slashingProtectionImportCmd.Flags().StringVarP(&slashingProtectionArgs.path, "input", "i", "", "Path to the interchange file")
+
+	SlashingProtectionCmd.AddCommand(slashingProtectionExportCmd, slashingProtectionImportCmd)
+}
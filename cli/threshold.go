@@ -1,22 +1,31 @@
 package cli
 
 import (
-	"fmt"
+	"context"
+	"encoding/json"
 	"log"
+	"os"
 
-	"github.com/bloxapp/ssv/logging"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/logging"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/keyshares"
 	"github.com/bloxapp/ssv/utils/threshold"
 )
 
 // createThreshold is the command to create threshold based on the given private key
 var createThresholdCmd = &cobra.Command{
 	Use:   "create-threshold",
-	Short: "Turns a private key into a threshold key",
+	Short: "Turns a private key into a keyshares file, splitting it between the given operators",
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := logging.SetGlobalLogger("debug", "capital", "console", ""); err != nil {
 			log.Fatal(err)
@@ -27,38 +36,123 @@ var createThresholdCmd = &cobra.Command{
 		if err != nil {
 			logger.Fatal("failed to get private key flag value", zap.Error(err))
 		}
+		ownerAddress, err := flags.GetOwnerAddressFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get owner address flag value", zap.Error(err))
+		}
+		if !common.IsHexAddress(ownerAddress) {
+			logger.Fatal("invalid owner address", zap.String("owner", ownerAddress))
+		}
+		ownerNonce, err := flags.GetOwnerNonceFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get owner nonce flag value", zap.Error(err))
+		}
+		output, err := flags.GetOutputFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get output flag value", zap.Error(err))
+		}
 
-		keysCount, err := flags.GetKeysCountFlagValue(cmd)
+		operators, err := thresholdOperators(cmd, logger)
 		if err != nil {
-			logger.Fatal("failed to get keys count flag value", zap.Error(err))
+			logger.Fatal("failed to get operators", zap.Error(err))
 		}
 
+		threshold.Init()
 		baseKey := &bls.SecretKey{}
 		if err := baseKey.SetHexString(privKey); err != nil {
 			logger.Fatal("failed to set hex private key", zap.Error(err))
 		}
 
-		// https://github.com/ethereum/eth2-ssv/issues/22
-		// currently support 4 nodes threshold is keysCount-1(3). need to align based open the issue to
-		// support k(2f+1) and n (3f+1) and allow to pass it as flag
-		privKeys, err := threshold.Create(baseKey.Serialize(), keysCount-1, keysCount)
+		ks, err := keyshares.Split(baseKey, common.HexToAddress(ownerAddress), ownerNonce, operators)
 		if err != nil {
 			logger.Fatal("failed to turn a private key into a threshold key", zap.Error(err))
 		}
-
-		// TODO: export to json file
-		fmt.Println("Generating threshold keys for validator", baseKey.GetPublicKey().SerializeToHexStr())
-		for i, pk := range privKeys {
-			fmt.Println()
-			fmt.Println("Public key", i, pk.GetPublicKey().SerializeToHexStr())
-			fmt.Println("Private key", i, pk.SerializeToHexStr())
+		raw, err := json.MarshalIndent(ks, "", "  ")
+		if err != nil {
+			logger.Fatal("failed to marshal keyshares", zap.Error(err))
+		}
+		if err := os.WriteFile(output, raw, 0600); err != nil {
+			logger.Fatal("failed to write keyshares", zap.Error(err))
 		}
+		logger.Info("generated keyshares",
+			zap.String("validator", ks.Payload.PublicKey),
+			zap.Any("operators", ks.Payload.OperatorIDs),
+			zap.String("path", output),
+		)
 	},
 }
 
+// thresholdOperators returns the operators given by flags,
+// looking up their public keys in the registry storage of a node db when not given
+func thresholdOperators(cmd *cobra.Command, logger *zap.Logger) ([]keyshares.Operator, error) {
+	ids, err := flags.GetOperatorIDsFlagValue(cmd)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := flags.GetOperatorKeysFlagValue(cmd)
+	if err != nil {
+		return nil, err
+	}
+	dbPath, err := flags.GetDBPathFlagValue(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	operators := make([]keyshares.Operator, len(ids))
+	for i, id := range ids {
+		operators[i].ID = spectypes.OperatorID(id)
+	}
+
+	switch {
+	case len(keys) > 0:
+		if len(keys) != len(ids) {
+			return nil, errors.Errorf("got %d operator keys for %d operators", len(keys), len(ids))
+		}
+		for i := range operators {
+			operators[i].OperatorKey = keys[i]
+		}
+	case dbPath != "":
+		db, err := storage.GetStorageFactory(logger, basedb.Options{
+			Type: "badger-db",
+			Path: dbPath,
+			Ctx:  context.Background(),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "could not open db")
+		}
+		defer func() {
+			if err := db.Close(logger); err != nil {
+				logger.Error("could not close db", zap.Error(err))
+			}
+		}()
+		nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create node storage")
+		}
+		for i := range operators {
+			od, found, err := nodeStorage.GetOperatorData(operators[i].ID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not get operator %d", operators[i].ID)
+			}
+			if !found {
+				return nil, errors.Errorf("operator %d not found", operators[i].ID)
+			}
+			operators[i].OperatorKey = string(od.PublicKey)
+		}
+	default:
+		return nil, errors.New("either --operator-keys or --db-path must be set")
+	}
+	return operators, nil
+}
+
 func init() {
 	flags.AddPrivKeyFlag(createThresholdCmd)
-	flags.AddKeysCountFlag(createThresholdCmd)
+	flags.AddOperatorIDsFlag(createThresholdCmd)
+	flags.AddOperatorKeysFlag(createThresholdCmd)
+	flags.AddDBPathFlag(createThresholdCmd)
+	flags.AddOwnerAddressFlag(createThresholdCmd)
+	flags.AddOwnerNonceFlag(createThresholdCmd)
+	flags.AddOutputFlag(createThresholdCmd, "keyshares.json")
 
 	RootCmd.AddCommand(createThresholdCmd)
 }
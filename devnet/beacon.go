@@ -0,0 +1,460 @@
+package devnet
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"go.uber.org/zap"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+// Submission kinds counted by the simulated beacon node
+const (
+	SubmittedAttestations  = "attestations"
+	SubmittedAggregates    = "aggregates"
+	SubmittedBlocks        = "blocks"
+	SubmittedSyncMessages  = "sync_messages"
+	SubmittedContributions = "contributions"
+	SubmittedRegistrations = "validator_registrations"
+	SubmittedExits         = "voluntary_exits"
+	// SubmittedBLSToExecutionChanges counts the changes of withdrawal credentials
+	SubmittedBLSToExecutionChanges = "bls_to_execution_changes"
+)
+
+// Beacon is a simulated beacon node implementing beacon.BeaconNode.
+// Every validator added to it is active and attests once per epoch, in a committee of its own,
+// and everything submitted to it is counted. Attestations are only accepted with a valid signature of the validator,
+// other submissions aren't verified. It doesn't produce blocks or sync committee duties.
+type Beacon struct {
+	network beaconprotocol.Network
+
+	lock         sync.RWMutex
+	validators   map[phase0.ValidatorIndex]*eth2apiv1.Validator
+	byPubKey     map[phase0.BLSPubKey]phase0.ValidatorIndex
+	live         map[phase0.Epoch]map[phase0.ValidatorIndex]struct{}
+	submissions  map[string]int
+	headHandlers []eth2client.EventHandlerFunc
+}
+
+// NewBeacon creates a new simulated beacon node of the given network.
+func NewBeacon(network beaconprotocol.Network) *Beacon {
+	return &Beacon{
+		network:     network,
+		validators:  map[phase0.ValidatorIndex]*eth2apiv1.Validator{},
+		byPubKey:    map[phase0.BLSPubKey]phase0.ValidatorIndex{},
+		live:        map[phase0.Epoch]map[phase0.ValidatorIndex]struct{}{},
+		submissions: map[string]int{},
+	}
+}
+
+// AddValidator activates a validator, or returns its index if it's already active.
+// Indices start from 1 as the validator controller treats 0 as unknown.
+func (b *Beacon) AddValidator(pubKey phase0.BLSPubKey) phase0.ValidatorIndex {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if index, ok := b.byPubKey[pubKey]; ok {
+		return index
+	}
+	index := phase0.ValidatorIndex(len(b.validators) + 1)
+	b.validators[index] = &eth2apiv1.Validator{
+		Index:   index,
+		Balance: 32_000_000_000,
+		Status:  eth2apiv1.ValidatorStateActiveOngoing,
+		Validator: &phase0.Validator{
+			PublicKey:                  pubKey,
+			EffectiveBalance:           32_000_000_000,
+			ActivationEpoch:            0,
+			ExitEpoch:                  phase0.Epoch(^uint64(0)),
+			WithdrawableEpoch:          phase0.Epoch(^uint64(0)),
+			ActivationEligibilityEpoch: 0,
+		},
+	}
+	b.byPubKey[pubKey] = index
+	return index
+}
+
+// Submissions returns the amount of objects submitted to the node by kind, e.g. SubmittedAttestations.
+func (b *Beacon) Submissions() map[string]int {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	submissions := make(map[string]int, len(b.submissions))
+	for kind, count := range b.submissions {
+		submissions[kind] = count
+	}
+	return submissions
+}
+
+// Start emits a head event at the start of every slot until the context is done.
+func (b *Beacon) Start(ctx context.Context) {
+	slot := b.network.EstimatedCurrentSlot() + 1
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(b.network.GetSlotStartTime(slot))):
+		}
+
+		epoch := b.network.EstimatedEpochAtSlot(slot)
+		head := &eth2apiv1.HeadEvent{
+			Slot:                      slot,
+			Block:                     blockRoot(slot),
+			EpochTransition:           b.network.IsFirstSlotOfEpoch(slot),
+			CurrentDutyDependentRoot:  b.dependentRoot(epoch),
+			PreviousDutyDependentRoot: b.dependentRoot(epoch - 1),
+		}
+		b.lock.RLock()
+		handlers := b.headHandlers
+		b.lock.RUnlock()
+		for _, handler := range handlers {
+			handler(&eth2apiv1.Event{Topic: "head", Data: head})
+		}
+		slot++
+	}
+}
+
+// GetBeaconNetwork returns the beacon network the node is on
+func (b *Beacon) GetBeaconNetwork() spectypes.BeaconNetwork {
+	return b.network.BeaconNetwork
+}
+
+// GetDuties returns the attester and aggregator duties of the given validators.
+// A validator attests at the slot of its index modulo the slots per epoch, so that the duties are spread over the epoch.
+func (b *Beacon) GetDuties(logger *zap.Logger, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	slotsPerEpoch := b.network.SlotsPerEpoch()
+	committeesAtSlot := uint64(len(b.validators))/slotsPerEpoch + 1
+	var duties []*spectypes.Duty
+	for _, index := range validatorIndices {
+		v, ok := b.validators[index]
+		if !ok || v.Status != eth2apiv1.ValidatorStateActiveOngoing {
+			continue
+		}
+		for _, role := range []spectypes.BeaconRole{spectypes.BNRoleAttester, spectypes.BNRoleAggregator} {
+			duties = append(duties, &spectypes.Duty{
+				Type:                    role,
+				PubKey:                  v.Validator.PublicKey,
+				Slot:                    b.network.GetEpochFirstSlot(epoch) + phase0.Slot(uint64(index)%slotsPerEpoch),
+				ValidatorIndex:          index,
+				CommitteeIndex:          phase0.CommitteeIndex(uint64(index) / slotsPerEpoch),
+				CommitteeLength:         1,
+				CommitteesAtSlot:        committeesAtSlot,
+				ValidatorCommitteeIndex: 0,
+			})
+		}
+	}
+	return duties, nil
+}
+
+// SyncCommitteeDuties returns no duties, the simulated chain has no sync committees
+func (b *Beacon) SyncCommitteeDuties(epoch phase0.Epoch, indices []phase0.ValidatorIndex) ([]*eth2apiv1.SyncCommitteeDuty, error) {
+	return nil, nil
+}
+
+// Events subscribes the handler to head events, other topics are ignored
+func (b *Beacon) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	for _, topic := range topics {
+		if topic == "head" {
+			b.lock.Lock()
+			b.headHandlers = append(b.headHandlers, handler)
+			b.lock.Unlock()
+		}
+	}
+	return nil
+}
+
+// SubscribeToCommitteeSubnet is a no-op
+func (b *Beacon) SubscribeToCommitteeSubnet(subscription []*eth2apiv1.BeaconCommitteeSubscription) error {
+	return nil
+}
+
+// SubmitSyncCommitteeSubscriptions is a no-op
+func (b *Beacon) SubmitSyncCommitteeSubscriptions(subscription []*eth2apiv1.SyncCommitteeSubscription) error {
+	return nil
+}
+
+// GetValidatorData returns the known validators of the given public keys
+func (b *Beacon) GetValidatorData(validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	result := map[phase0.ValidatorIndex]*eth2apiv1.Validator{}
+	for _, pk := range validatorPubKeys {
+		if index, ok := b.byPubKey[pk]; ok {
+			v := *b.validators[index]
+			result[index] = &v
+		}
+	}
+	return result, nil
+}
+
+// ValidatorLiveness returns whether the given validators had an attestation submitted in the given epoch
+func (b *Beacon) ValidatorLiveness(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*beaconprotocol.ValidatorLiveness, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	liveness := make([]*beaconprotocol.ValidatorLiveness, len(validatorIndices))
+	for i, index := range validatorIndices {
+		_, live := b.live[epoch][index]
+		liveness[i] = &beaconprotocol.ValidatorLiveness{Index: index, IsLive: live}
+	}
+	return liveness, nil
+}
+
+// GetAttestationData returns attestation data by the given slot and committee index
+func (b *Beacon) GetAttestationData(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (ssz.Marshaler, spec.DataVersion, error) {
+	return b.attestationData(slot, committeeIndex), spec.DataVersionPhase0, nil
+}
+
+// SubmitAttestation verifies the signature of the attesting validator and marks it as live
+func (b *Beacon) SubmitAttestation(attestation *phase0.Attestation) error {
+	domain, err := b.DomainData(attestation.Data.Target.Epoch, spectypes.DomainAttester)
+	if err != nil {
+		return err
+	}
+	root, err := spectypes.ComputeETHSigningRoot(attestation.Data, domain)
+	if err != nil {
+		return errors.Wrap(err, "could not compute signing root")
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	slot := attestation.Data.Slot
+	slotsPerEpoch := b.network.SlotsPerEpoch()
+	index := phase0.ValidatorIndex(uint64(attestation.Data.Index)*slotsPerEpoch + uint64(slot)%slotsPerEpoch)
+	v, ok := b.validators[index]
+	if !ok {
+		return errors.Errorf("unknown validator %d", index)
+	}
+	if !verifySignature(v.Validator.PublicKey, attestation.Signature, root) {
+		return errors.Errorf("invalid attestation signature of validator %d", index)
+	}
+	epoch := b.network.EstimatedEpochAtSlot(slot)
+	if b.live[epoch] == nil {
+		b.live[epoch] = map[phase0.ValidatorIndex]struct{}{}
+	}
+	b.live[epoch][index] = struct{}{}
+	b.submissions[SubmittedAttestations]++
+	return nil
+}
+
+// GetBeaconBlock isn't supported, the simulated chain has no proposals
+func (b *Beacon) GetBeaconBlock(slot phase0.Slot, graffiti, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	return nil, spec.DataVersionPhase0, errors.New("devnet beacon node doesn't produce blocks")
+}
+
+// GetBlindedBeaconBlock isn't supported, the simulated chain has no proposals
+func (b *Beacon) GetBlindedBeaconBlock(slot phase0.Slot, graffiti, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	return nil, spec.DataVersionPhase0, errors.New("devnet beacon node doesn't produce blocks")
+}
+
+// SubmitBeaconBlock counts the submitted block
+func (b *Beacon) SubmitBeaconBlock(block *spec.VersionedBeaconBlock, sig phase0.BLSSignature) error {
+	b.count(SubmittedBlocks)
+	return nil
+}
+
+// SubmitBlindedBeaconBlock counts the submitted block
+func (b *Beacon) SubmitBlindedBeaconBlock(block *api.VersionedBlindedBeaconBlock, sig phase0.BLSSignature) error {
+	b.count(SubmittedBlocks)
+	return nil
+}
+
+// SubmitAggregateSelectionProof returns an aggregate of the single attestation in the validator's committee
+func (b *Beacon) SubmitAggregateSelectionProof(slot phase0.Slot, committeeIndex phase0.CommitteeIndex, committeeLength uint64, index phase0.ValidatorIndex, slotSig []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	aggregationBits := bitfield.NewBitlist(committeeLength)
+	aggregationBits.SetBitAt(0, true)
+
+	var selectionProof phase0.BLSSignature
+	copy(selectionProof[:], slotSig)
+	return &phase0.AggregateAndProof{
+		AggregatorIndex: index,
+		Aggregate: &phase0.Attestation{
+			AggregationBits: aggregationBits,
+			Data:            b.attestationData(slot, committeeIndex),
+		},
+		SelectionProof: selectionProof,
+	}, spec.DataVersionPhase0, nil
+}
+
+// SubmitSignedAggregateSelectionProof counts the submitted aggregate
+func (b *Beacon) SubmitSignedAggregateSelectionProof(msg *phase0.SignedAggregateAndProof) error {
+	b.count(SubmittedAggregates)
+	return nil
+}
+
+// GetSyncMessageBlockRoot returns the block root of the given slot
+func (b *Beacon) GetSyncMessageBlockRoot(slot phase0.Slot) (phase0.Root, spec.DataVersion, error) {
+	return blockRoot(slot), spec.DataVersionAltair, nil
+}
+
+// SubmitSyncMessage counts the submitted sync committee message
+func (b *Beacon) SubmitSyncMessage(msg *altair.SyncCommitteeMessage) error {
+	b.count(SubmittedSyncMessages)
+	return nil
+}
+
+// IsSyncCommitteeAggregator returns false, the simulated chain has no sync committees
+func (b *Beacon) IsSyncCommitteeAggregator(proof []byte) (bool, error) {
+	return false, nil
+}
+
+// SyncCommitteeSubnetID returns the first subnet, the simulated chain has no sync committees
+func (b *Beacon) SyncCommitteeSubnetID(index phase0.CommitteeIndex) (uint64, error) {
+	return 0, nil
+}
+
+// GetSyncCommitteeContribution isn't supported, the simulated chain has no sync committees
+func (b *Beacon) GetSyncCommitteeContribution(slot phase0.Slot, selectionProofs []phase0.BLSSignature, subnetIDs []uint64) (ssz.Marshaler, spec.DataVersion, error) {
+	return nil, spec.DataVersionAltair, errors.New("devnet beacon node has no sync committees")
+}
+
+// SubmitSignedContributionAndProof counts the submitted contribution
+func (b *Beacon) SubmitSignedContributionAndProof(contribution *altair.SignedContributionAndProof) error {
+	b.count(SubmittedContributions)
+	return nil
+}
+
+// SubmitValidatorRegistration counts the submitted registration
+func (b *Beacon) SubmitValidatorRegistration(pubkey []byte, feeRecipient bellatrix.ExecutionAddress, sig phase0.BLSSignature) error {
+	b.count(SubmittedRegistrations)
+	return nil
+}
+
+// SubmitProposalPreparation is a no-op
+func (b *Beacon) SubmitProposalPreparation(feeRecipients map[phase0.ValidatorIndex]bellatrix.ExecutionAddress) error {
+	return nil
+}
+
+// SubmitVoluntaryExit moves the validator to the exiting state, it gets no duties afterwards
+func (b *Beacon) SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	v, ok := b.validators[voluntaryExit.Message.ValidatorIndex]
+	if !ok {
+		return errors.Errorf("unknown validator %d", voluntaryExit.Message.ValidatorIndex)
+	}
+	v.Status = eth2apiv1.ValidatorStateActiveExiting
+	b.submissions[SubmittedExits]++
+	return nil
+}
+
+// SubmitBLSToExecutionChange is counted, the simulated validators have no withdrawal credentials to change
+func (b *Beacon) SubmitBLSToExecutionChange(change *capella.SignedBLSToExecutionChange) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.validators[change.Message.ValidatorIndex]; !ok {
+		return errors.Errorf("unknown validator %d", change.Message.ValidatorIndex)
+	}
+	b.submissions[SubmittedBLSToExecutionChanges]++
+	return nil
+}
+
+// DomainData returns the signature domain of the network
+func (b *Beacon) DomainData(epoch phase0.Epoch, domain phase0.DomainType) (phase0.Domain, error) {
+	var forkVersion phase0.Version = b.network.ForkVersion()
+	genesisValidatorsRoot := b.network.GenesisValidatorsRoot()
+	if domain == spectypes.DomainApplicationBuilder {
+		genesisValidatorsRoot = phase0.Root{}
+	}
+	return spectypes.ComputeETHDomain(domain, forkVersion, genesisValidatorsRoot)
+}
+
+// ComputeSigningRoot computes the root of the object by calculating the hash tree root of the signing data with the given domain.
+func (b *Beacon) ComputeSigningRoot(object interface{}, domain phase0.Domain) ([32]byte, error) {
+	v, ok := object.(ssz.HashRoot)
+	if !ok {
+		return [32]byte{}, errors.New("cannot compute signing root")
+	}
+	root, err := v.HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return (&phase0.SigningData{ObjectRoot: root, Domain: domain}).HashTreeRoot()
+}
+
+// IsReady returns true, the simulated node is always synced
+func (b *Beacon) IsReady(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+func (b *Beacon) count(kind string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.submissions[kind]++
+}
+
+// attestationData returns the attestation data of the given slot, which justifies the previous epoch
+func (b *Beacon) attestationData(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) *phase0.AttestationData {
+	target := b.network.EstimatedEpochAtSlot(slot)
+	source := target
+	if source > 0 {
+		source--
+	}
+	return &phase0.AttestationData{
+		Slot:            slot,
+		Index:           committeeIndex,
+		BeaconBlockRoot: blockRoot(slot),
+		Source: &phase0.Checkpoint{
+			Epoch: source,
+			Root:  blockRoot(b.network.GetEpochFirstSlot(source)),
+		},
+		Target: &phase0.Checkpoint{
+			Epoch: target,
+			Root:  blockRoot(b.network.GetEpochFirstSlot(target)),
+		},
+	}
+}
+
+// verifySignature returns true if sig is the signature of the given public key over root
+func verifySignature(pubKey phase0.BLSPubKey, sig phase0.BLSSignature, root [32]byte) bool {
+	pk := &bls.PublicKey{}
+	if err := pk.Deserialize(pubKey[:]); err != nil {
+		return false
+	}
+	s := &bls.Sign{}
+	if err := s.Deserialize(sig[:]); err != nil {
+		return false
+	}
+	return s.VerifyByte(pk, root[:])
+}
+
+// dependentRoot returns the root the duties of the given epoch depend on, which never changes as the chain has no reorgs
+func (b *Beacon) dependentRoot(epoch phase0.Epoch) phase0.Root {
+	firstSlot := b.network.GetEpochFirstSlot(epoch)
+	if firstSlot == 0 {
+		return blockRoot(0)
+	}
+	return blockRoot(firstSlot - 1)
+}
+
+// blockRoot returns the root of the simulated block of the given slot, every slot has a block
+func blockRoot(slot phase0.Slot) phase0.Root {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(slot))
+	return sha256.Sum256(append([]byte("devnet-block"), b[:]...))
+}
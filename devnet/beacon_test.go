@@ -0,0 +1,80 @@
+package devnet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+func TestBeacon_Duties(t *testing.T) {
+	logger := logging.TestLogger(t)
+	network := beacon.NewCustomNetwork(spectypes.PraterNetwork, beacon.CustomParams{
+		GenesisTime:        uint64(time.Now().Unix()),
+		GenesisForkVersion: spectypes.PraterNetwork.ForkVersion(),
+		SlotDuration:       time.Second,
+		SlotsPerEpoch:      4,
+	})
+	b := NewBeacon(network)
+	threshold.Init()
+
+	pubKeys := make([]phase0.BLSPubKey, 6)
+	indices := make([]phase0.ValidatorIndex, len(pubKeys))
+	keys := map[phase0.ValidatorIndex]*bls.SecretKey{}
+	for i := range indices {
+		sk := new(bls.SecretKey)
+		sk.SetByCSPRNG()
+		copy(pubKeys[i][:], sk.GetPublicKey().Serialize())
+		indices[i] = b.AddValidator(pubKeys[i])
+		keys[indices[i]] = sk
+	}
+	require.Equal(t, phase0.ValidatorIndex(1), indices[0])
+	require.Equal(t, indices[0], b.AddValidator(pubKeys[0]))
+
+	duties, err := b.GetDuties(logger, 2, indices)
+	require.NoError(t, err)
+	require.Len(t, duties, 2*len(indices))
+
+	// every validator attests once per epoch, the attestation is counted and makes it live
+	for _, duty := range duties {
+		if duty.Type != spectypes.BNRoleAttester {
+			continue
+		}
+		require.Equal(t, phase0.Epoch(2), network.EstimatedEpochAtSlot(duty.Slot))
+
+		data, _, err := b.GetAttestationData(duty.Slot, duty.CommitteeIndex)
+		require.NoError(t, err)
+		attestationData := data.(*phase0.AttestationData)
+		require.Equal(t, duty.Slot, attestationData.Slot)
+		require.Equal(t, attestationData.Target.Epoch-1, attestationData.Source.Epoch)
+
+		// unsigned attestations are rejected
+		require.Error(t, b.SubmitAttestation(&phase0.Attestation{Data: attestationData}))
+
+		domain, err := b.DomainData(attestationData.Target.Epoch, spectypes.DomainAttester)
+		require.NoError(t, err)
+		root, err := spectypes.ComputeETHSigningRoot(attestationData, domain)
+		require.NoError(t, err)
+		attestation := &phase0.Attestation{Data: attestationData}
+		copy(attestation.Signature[:], keys[duty.ValidatorIndex].SignByte(root[:]).Serialize())
+		require.NoError(t, b.SubmitAttestation(attestation))
+	}
+	require.Equal(t, len(indices), b.Submissions()[SubmittedAttestations])
+
+	liveness, err := b.ValidatorLiveness(2, indices)
+	require.NoError(t, err)
+	for i, l := range liveness {
+		require.Equal(t, indices[i], l.Index)
+		require.True(t, l.IsLive)
+	}
+	liveness, err = b.ValidatorLiveness(3, indices)
+	require.NoError(t, err)
+	require.False(t, liveness[0].IsLive)
+}
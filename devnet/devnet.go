@@ -0,0 +1,214 @@
+package devnet
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/network/commons"
+	"github.com/bloxapp/ssv/network/discovery"
+	"github.com/bloxapp/ssv/networkconfig"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	"github.com/bloxapp/ssv/utils/keyshares"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+// NetworkName is the name of the devnet network config
+const NetworkName = "devnet"
+
+var (
+	// Domain is the SSV domain of the devnet, which is different from every public network
+	Domain = spectypes.DomainType{0x0, 0x0, 0xde, 0x1}
+	// Owner is the owner address of the devnet operators and validators
+	Owner = common.HexToAddress("0x000000000000000000000000000000000000dE01")
+	// RegistryContractAddr is the address of the simulated registry contract
+	RegistryContractAddr = "0x000000000000000000000000000000000000dE02"
+)
+
+// Options configures a devnet
+type Options struct {
+	// Operators is the number of operators, at least 4
+	Operators int
+	// Validators is the number of validators, each run by a committee of operators chosen round-robin
+	Validators int
+	// TCPPort is the p2p TCP port of the first operator, the other operators use the following ports
+	TCPPort int
+	// UDPPort is the discovery UDP port of the bootnode, the operators use the following ports
+	UDPPort int
+	// SlotDuration is the duration of a slot of the simulated beacon chain
+	SlotDuration time.Duration
+	// SlotsPerEpoch is the number of slots in an epoch of the simulated beacon chain
+	SlotsPerEpoch uint64
+	// Forks schedules the SSV forks of the devnet, relative to its genesis
+	Forks forksprotocol.Schedule
+}
+
+// Devnet is a local SSV network, running its operators in a single process
+// on top of a simulated beacon chain and registry contract, with real p2p over loopback.
+type Devnet struct {
+	opts Options
+
+	Network   networkconfig.NetworkConfig
+	Beacon    *Beacon
+	Registry  *Registry
+	Operators []*Operator
+	// Validators are the secret keys of the devnet validators
+	Validators []*bls.SecretKey
+
+	bootnode *discovery.Bootnode
+}
+
+// New creates a devnet, registering its operators and validators with the simulated registry contract.
+// The genesis is set a few slots before the end of the first epoch, so that validators registered
+// during the first epoch start attesting shortly after the devnet starts.
+func New(ctx context.Context, logger *zap.Logger, opts Options) (*Devnet, error) {
+	if opts.Operators < 4 {
+		return nil, errors.Errorf("at least 4 operators are required, got %d", opts.Operators)
+	}
+	if opts.SlotDuration < time.Second || opts.SlotsPerEpoch < 2 {
+		return nil, errors.New("slot duration must be at least a second and an epoch must have at least 2 slots")
+	}
+	if opts.SlotDuration%time.Second != 0 {
+		return nil, errors.Errorf("slot duration must be a whole number of seconds, got %s", opts.SlotDuration)
+	}
+	if err := opts.Forks.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid fork schedule")
+	}
+	threshold.Init()
+
+	secondsPerSlot := uint64(opts.SlotDuration / time.Second)
+	genesisTime := uint64(time.Now().Unix()) - (2*opts.SlotsPerEpoch-3)*secondsPerSlot
+	beaconNetwork := beacon.NewCustomNetwork(spectypes.PraterNetwork, beacon.CustomParams{
+		GenesisTime:        genesisTime,
+		GenesisForkVersion: spectypes.PraterNetwork.ForkVersion(),
+		SlotDuration:       time.Duration(secondsPerSlot) * time.Second,
+		SlotsPerEpoch:      opts.SlotsPerEpoch,
+	})
+
+	bootnode, err := newBootnode(ctx, logger, opts.UDPPort)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not start bootnode")
+	}
+
+	d := &Devnet{
+		opts: opts,
+		Network: networkconfig.NetworkConfig{
+			Name:                 NetworkName,
+			Beacon:               beaconNetwork,
+			Domain:               Domain,
+			ETH1SyncOffset:       new(big.Int),
+			RegistryContractAddr: RegistryContractAddr,
+			Bootnodes:            []string{bootnode.ENR},
+			Forks:                opts.Forks,
+		},
+		Beacon:   NewBeacon(beaconNetwork),
+		Registry: NewRegistry(),
+		bootnode: bootnode,
+	}
+	types.SetDefaultDomain(Domain)
+
+	for i := 0; i < opts.Operators; i++ {
+		op, err := newOperator(ctx, logger, spectypes.OperatorID(i+1))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not create operator %d", i+1)
+		}
+		d.Registry.AddOperator(op.ID, Owner, op.PublicKey)
+		d.Operators = append(d.Operators, op)
+	}
+	for i := 0; i < opts.Validators; i++ {
+		if err := d.AddValidator(); err != nil {
+			return nil, errors.Wrapf(err, "could not add validator %d", i+1)
+		}
+	}
+	return d, nil
+}
+
+// AddValidator generates a new validator, activates it on the simulated beacon chain and registers it
+// with the next committee of operators. It can be called while the devnet is running.
+func (d *Devnet) AddValidator() error {
+	sk := new(bls.SecretKey)
+	sk.SetByCSPRNG()
+
+	committee := d.committee(len(d.Validators))
+	operators := make([]keyshares.Operator, len(committee))
+	for i, op := range committee {
+		operators[i] = keyshares.Operator{ID: op.ID, OperatorKey: string(op.PublicKey)}
+	}
+
+	var pubKey phase0.BLSPubKey
+	copy(pubKey[:], sk.GetPublicKey().Serialize())
+	d.Beacon.AddValidator(pubKey)
+	if _, err := d.Registry.AddValidator(sk, Owner, operators); err != nil {
+		return err
+	}
+	d.Validators = append(d.Validators, sk)
+	return nil
+}
+
+// Start starts the simulated beacon chain and all the operators.
+func (d *Devnet) Start(ctx context.Context, logger *zap.Logger) error {
+	go d.Beacon.Start(ctx)
+
+	for i, op := range d.Operators {
+		err := op.Start(ctx, logger, d.Network, d.Beacon, d.Registry, d.opts.TCPPort+i, d.opts.UDPPort+i+1)
+		if err != nil {
+			return errors.Wrapf(err, "could not start operator %d", op.ID)
+		}
+	}
+
+	validators := make([]string, len(d.Validators))
+	for i, sk := range d.Validators {
+		validators[i] = hex.EncodeToString(sk.GetPublicKey().Serialize())
+	}
+	logger.Info("devnet is running",
+		zap.Int("operators", len(d.Operators)),
+		zap.Strings("validators", validators),
+		zap.Time("genesis", time.Unix(int64(d.Network.Beacon.MinGenesisTime()), 0)),
+		zap.Uint64("current_epoch", uint64(d.Network.Beacon.EstimatedCurrentEpoch())),
+	)
+	return nil
+}
+
+// committee returns the operators of the validator with the given index: the largest valid committee,
+// starting from a different operator for every validator so that all the operators are used.
+func (d *Devnet) committee(validatorIndex int) []*Operator {
+	size := len(d.Operators)
+	for !keyshares.ValidCommitteeSize(size) {
+		size--
+	}
+	committee := make([]*Operator, size)
+	for i := range committee {
+		committee[i] = d.Operators[(validatorIndex+i)%len(d.Operators)]
+	}
+	return committee
+}
+
+func newBootnode(ctx context.Context, logger *zap.Logger, port int) (*discovery.Bootnode, error) {
+	sk, err := commons.GenNetworkKey()
+	if err != nil {
+		return nil, err
+	}
+	isk, err := commons.ConvertToInterfacePrivkey(sk)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := isk.Raw()
+	if err != nil {
+		return nil, err
+	}
+	return discovery.NewBootnode(ctx, logger.Named("bootnode"), &discovery.BootnodeOptions{
+		PrivateKey: hex.EncodeToString(raw),
+		ExternalIP: "127.0.0.1",
+		Port:       port,
+	})
+}
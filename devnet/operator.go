@@ -0,0 +1,166 @@
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/ekm"
+	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/network/commons"
+	p2pv1 "github.com/bloxapp/ssv/network/p2p"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/operator"
+	"github.com/bloxapp/ssv/operator/slot_ticker"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/operator/validator"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/format"
+)
+
+// Operator is an SSV node of the devnet, with an in-memory db and a generated operator key
+type Operator struct {
+	ID spectypes.OperatorID
+	// PublicKey is the base64 encoded PEM public key of the operator
+	PublicKey []byte
+
+	db      basedb.IDb
+	storage operatorstorage.Storage
+
+	Network    network.P2PNetwork
+	Controller validator.Controller
+}
+
+func newOperator(ctx context.Context, logger *zap.Logger, id spectypes.OperatorID) (*Operator, error) {
+	logger = logger.Named(fmt.Sprintf("operator-%d", id))
+
+	db, err := storage.GetStorageFactory(logger, basedb.Options{
+		Type: "badger-memory",
+		Ctx:  ctx,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open db")
+	}
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create node storage")
+	}
+	pubKey, err := nodeStorage.SetupPrivateKey(logger, "", true)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not setup operator private key")
+	}
+	return &Operator{
+		ID:        id,
+		PublicKey: pubKey,
+		db:        db,
+		storage:   nodeStorage,
+	}, nil
+}
+
+// Start wires the operator node like the start-node command does, with the simulated beacon node
+// and a client of the simulated registry contract, and runs it in the background.
+func (o *Operator) Start(
+	ctx context.Context,
+	logger *zap.Logger,
+	networkConfig networkconfig.NetworkConfig,
+	beaconNode *Beacon,
+	registry *Registry,
+	tcpPort, udpPort int,
+) error {
+	logger = logger.Named(fmt.Sprintf("operator-%d", o.ID))
+	forkVersion := networkConfig.SSVForkVersion(networkConfig.Beacon.EstimatedCurrentEpoch())
+
+	keyManager, err := ekm.NewETHKeyManagerSigner(logger, o.db, networkConfig, false)
+	if err != nil {
+		return errors.Wrap(err, "could not create eth-key-manager signer")
+	}
+
+	netPrivKey, err := commons.GenNetworkKey()
+	if err != nil {
+		return errors.Wrap(err, "could not generate network key")
+	}
+	p2pConfig := &p2pv1.Config{
+		Ctx:               ctx,
+		Discovery:         "discv5",
+		TCPPort:           tcpPort,
+		UDPPort:           udpPort,
+		HostAddress:       "127.0.0.1",
+		RequestTimeout:    10 * time.Second,
+		MaxBatchResponse:  25,
+		MaxPeers:          60,
+		TopicMaxPeers:     10,
+		NetworkPrivateKey: netPrivKey,
+		OperatorID:        format.OperatorID(o.PublicKey),
+		ForkVersion:       forkVersion,
+		NodeStorage:       o.storage,
+		Network:           networkConfig,
+		Shares:            o.storage.Shares(),
+		Permissioned: func() bool {
+			return false
+		},
+	}
+	o.Network = p2pv1.New(logger, p2pConfig)
+
+	slotTicker := slot_ticker.NewTicker(ctx, networkConfig)
+	validatorOptions := validator.ControllerOptions{
+		Context:                    ctx,
+		DB:                         o.db,
+		SignatureCollectionTimeout: 5 * time.Second,
+		MetadataUpdateInterval:     networkConfig.Beacon.SlotDurationSec() * time.Duration(networkConfig.Beacon.SlotsPerEpoch()),
+		HistorySyncBatchSize:       25,
+		MinPeers:                   2,
+		BeaconNetwork:              networkConfig.Beacon,
+		Network:                    o.Network,
+		Beacon:                     beaconNode,
+		ShareEncryptionKeyProvider: o.storage.GetPrivateKey,
+		KeyManager:                 keyManager,
+		OperatorData:               &registrystorage.OperatorData{PublicKey: o.PublicKey},
+		RegistryStorage:            o.storage,
+		ForkVersion:                forkVersion,
+		DutyRoles:                  []spectypes.BeaconRole{spectypes.BNRoleAttester},
+		QueuePrioritizer:           "standard",
+		QueueDropPolicy:            "lowest-priority",
+		WorkersCount:               256,
+		QueueBufferSize:            1024,
+		GasLimit:                   spectypes.DefaultGasLimit,
+	}
+	o.Controller = validator.NewController(logger, validatorOptions)
+
+	node := operator.New(logger, operator.Options{
+		Network:             networkConfig,
+		BeaconNode:          beaconNode,
+		Eth1Client:          registry.Client(ctx),
+		P2PNetwork:          o.Network,
+		Context:             ctx,
+		DB:                  o.db,
+		ValidatorController: o.Controller,
+		DutyLimit:           32,
+		ValidatorOptions:    validatorOptions,
+		ForkVersion:         forkVersion,
+	}, slotTicker)
+
+	if err := node.StartEth1(logger, new(big.Int)); err != nil {
+		return errors.Wrap(err, "failed to start eth1")
+	}
+	p2pConfig.GetValidatorStats = o.Controller.GetValidatorStats
+	if err := o.Network.Setup(logger); err != nil {
+		return errors.Wrap(err, "failed to setup network")
+	}
+	if err := o.Network.Start(logger); err != nil {
+		return errors.Wrap(err, "failed to start network")
+	}
+
+	go func() {
+		if err := node.Start(logger); err != nil {
+			logger.Error("failed to start SSV node", zap.Error(err))
+		}
+	}()
+	return nil
+}
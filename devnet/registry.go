@@ -0,0 +1,179 @@
+package devnet
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+	"sync"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/async/event"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	"github.com/bloxapp/ssv/utils/keyshares"
+)
+
+// Registry is a simulated registry contract. Every event is mined in a block of its own
+// and is immediately confirmed, and is streamed to the clients of the operators.
+type Registry struct {
+	lock    sync.Mutex
+	events  []*eth1.Event
+	nonces  map[common.Address]uint64
+	clients []*registryClient
+}
+
+// NewRegistry creates a new simulated registry contract.
+func NewRegistry() *Registry {
+	return &Registry{
+		nonces: map[common.Address]uint64{},
+	}
+}
+
+// AddOperator emits an OperatorAdded event of the given operator.
+// pubKey is the base64 encoded PEM public key of the operator.
+func (r *Registry) AddOperator(id spectypes.OperatorID, owner common.Address, pubKey []byte) {
+	r.emit(abiparser.OperatorAdded, abiparser.OperatorAddedEvent{
+		OperatorId: id,
+		Owner:      owner,
+		PublicKey:  pubKey,
+		Fee:        big.NewInt(0),
+	})
+}
+
+// AddValidator splits the validator key between the given operators and emits a ValidatorAdded event of it.
+func (r *Registry) AddValidator(sk *bls.SecretKey, owner common.Address, operators []keyshares.Operator) (*keyshares.KeyShares, error) {
+	r.lock.Lock()
+	nonce := r.nonces[owner]
+	r.nonces[owner]++
+	r.lock.Unlock()
+
+	ks, err := keyshares.Split(sk, owner, nonce, operators)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not split validator key")
+	}
+	shares, err := hexutil.Decode(ks.Payload.SharesData)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode shares data")
+	}
+	r.emit(abiparser.ValidatorAdded, abiparser.ValidatorAddedEvent{
+		Owner:       owner,
+		OperatorIds: ks.Payload.OperatorIDs,
+		PublicKey:   sk.GetPublicKey().Serialize(),
+		Shares:      shares,
+	})
+	return ks, nil
+}
+
+// Client returns a new eth1 client of the contract, which should be used by a single operator.
+func (r *Registry) Client(ctx context.Context) eth1.Client {
+	return &registryClient{
+		ctx:      ctx,
+		registry: r,
+		feed:     new(event.Feed),
+		pending:  make(chan *eth1.Event, 1024),
+	}
+}
+
+// emit mines an event in a new block and streams it to the started clients
+func (r *Registry) emit(name string, data interface{}) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	block := uint64(len(r.events) + 1)
+	// the tx hash is unique per event, as the node skips events of known transactions
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], block)
+	e := &eth1.Event{
+		Log: types.Log{
+			BlockNumber: block,
+			TxHash:      crypto.Keccak256Hash([]byte("devnet-tx"), b[:]),
+		},
+		Name: name,
+		Data: data,
+	}
+	r.events = append(r.events, e)
+	for _, c := range r.clients {
+		c.pending <- e
+	}
+}
+
+// history returns the events from the given block and the number of the last block
+func (r *Registry) history(fromBlock uint64) ([]*eth1.Event, uint64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var events []*eth1.Event
+	for _, e := range r.events {
+		if e.Log.BlockNumber >= fromBlock {
+			events = append(events, e)
+		}
+	}
+	return events, uint64(len(r.events))
+}
+
+// registryClient implements eth1.Client over the simulated registry contract
+type registryClient struct {
+	ctx      context.Context
+	registry *Registry
+	feed     *event.Feed
+	pending  chan *eth1.Event
+	synced   uint64
+}
+
+// EventsFeed returns the contract events feed
+func (c *registryClient) EventsFeed() *event.Feed {
+	return c.feed
+}
+
+// Sync sends the events from the given block, followed by a SyncEndedEvent
+func (c *registryClient) Sync(logger *zap.Logger, fromBlock *big.Int) error {
+	var from uint64
+	if fromBlock != nil {
+		from = fromBlock.Uint64()
+	}
+	events, head := c.registry.history(from)
+	for _, e := range events {
+		c.feed.Send(e)
+	}
+	c.synced = head
+	c.feed.Send(&eth1.Event{Name: "SyncEndedEvent", Data: eth1.SyncEndedEvent{Success: true, Block: head}})
+	return nil
+}
+
+// Start streams the events emitted after the sync, each followed by a ConfirmedBlockEvent
+func (c *registryClient) Start(logger *zap.Logger) error {
+	c.registry.lock.Lock()
+	missed := c.registry.events[c.synced:]
+	for _, e := range missed {
+		c.pending <- e
+	}
+	c.registry.clients = append(c.registry.clients, c)
+	c.registry.lock.Unlock()
+
+	go func() {
+		for {
+			select {
+			case <-c.ctx.Done():
+				return
+			case e := <-c.pending:
+				c.feed.Send(e)
+				confirmed := eth1.ConfirmedBlockEvent{Block: e.Log.BlockNumber}
+				c.feed.Send(&eth1.Event{Log: types.Log{BlockNumber: confirmed.Block}, Name: "ConfirmedBlockEvent", Data: confirmed})
+			}
+		}
+	}()
+	return nil
+}
+
+// IsReady returns true, the simulated contract is always synced
+func (c *registryClient) IsReady(ctx context.Context) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,55 @@
+package devnet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	"github.com/bloxapp/ssv/logging"
+)
+
+func TestRegistry_Client(t *testing.T) {
+	logger := logging.TestLogger(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := NewRegistry()
+	r.AddOperator(1, Owner, []byte("operator-1"))
+	r.AddOperator(2, Owner, []byte("operator-2"))
+
+	client := r.Client(ctx)
+	events := make(chan *eth1.Event, 16)
+	sub := client.EventsFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+
+	// sync sends the history, followed by the end of the sync
+	require.NoError(t, client.Sync(logger, nil))
+	for i := uint64(1); i <= 2; i++ {
+		e := <-events
+		require.Equal(t, abiparser.OperatorAdded, e.Name)
+		require.Equal(t, i, e.Log.BlockNumber)
+	}
+	e := <-events
+	require.Equal(t, eth1.SyncEndedEvent{Success: true, Block: 2}, e.Data)
+
+	// events emitted after the sync are streamed, each followed by the confirmation of its block
+	r.AddOperator(3, Owner, []byte("operator-3"))
+	require.NoError(t, client.Start(logger))
+	r.AddOperator(4, Owner, []byte("operator-4"))
+	for i := uint64(3); i <= 4; i++ {
+		select {
+		case e := <-events:
+			require.Equal(t, abiparser.OperatorAdded, e.Name)
+			require.Equal(t, i, e.Log.BlockNumber)
+			require.NotEqual(t, r.events[0].Log.TxHash, e.Log.TxHash)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for event")
+		}
+		e := <-events
+		require.Equal(t, eth1.ConfirmedBlockEvent{Block: i}, e.Data)
+	}
+}
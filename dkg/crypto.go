@@ -0,0 +1,149 @@
+package dkg
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+)
+
+// polynomial is a random polynomial whose free coefficient is the contribution of an operator to the validator key
+type polynomial []bls.SecretKey
+
+func newPolynomial(threshold uint64) polynomial {
+	p := make(polynomial, threshold)
+	for i := range p {
+		p[i].SetByCSPRNG()
+	}
+	return p
+}
+
+// commitments returns the public keys of the coefficients, which allow to verify evaluations without revealing them
+func (p polynomial) commitments() [][]byte {
+	commitments := make([][]byte, len(p))
+	for i := range p {
+		commitments[i] = p[i].GetPublicKey().Serialize()
+	}
+	return commitments
+}
+
+// evaluate returns the share of the given operator
+func (p polynomial) evaluate(id spectypes.OperatorID) (*bls.SecretKey, error) {
+	blsID, err := operatorBlsID(id)
+	if err != nil {
+		return nil, err
+	}
+	share := &bls.SecretKey{}
+	if err := share.Set(p, blsID); err != nil {
+		return nil, errors.Wrap(err, "could not evaluate polynomial")
+	}
+	return share, nil
+}
+
+// operatorBlsID returns the bls.ID of an operator, the same way shares are indexed in utils/threshold
+func operatorBlsID(id spectypes.OperatorID) (*bls.ID, error) {
+	blsID := &bls.ID{}
+	if err := blsID.SetDecString(fmt.Sprintf("%d", id)); err != nil {
+		return nil, err
+	}
+	return blsID, nil
+}
+
+// parseCommitments deserializes the commitments of a deal
+func parseCommitments(raw [][]byte, threshold uint64) ([]bls.PublicKey, error) {
+	if uint64(len(raw)) != threshold {
+		return nil, errors.Errorf("expected %d commitments, got %d", threshold, len(raw))
+	}
+	commitments := make([]bls.PublicKey, len(raw))
+	for i := range raw {
+		if err := commitments[i].Deserialize(raw[i]); err != nil {
+			return nil, errors.Wrap(err, "could not deserialize commitment")
+		}
+	}
+	return commitments, nil
+}
+
+// sharePubKey evaluates the commitments at the given operator, returning the public key of its share
+func sharePubKey(commitments []bls.PublicKey, id spectypes.OperatorID) (*bls.PublicKey, error) {
+	blsID, err := operatorBlsID(id)
+	if err != nil {
+		return nil, err
+	}
+	pk := &bls.PublicKey{}
+	if err := pk.Set(commitments, blsID); err != nil {
+		return nil, errors.Wrap(err, "could not evaluate commitments")
+	}
+	return pk, nil
+}
+
+// aggregate returns the validator public key and the public keys of the final shares of the given operators
+func aggregate(commitments [][]bls.PublicKey, operators []Operator) (*bls.PublicKey, map[spectypes.OperatorID]*bls.PublicKey, error) {
+	validatorPK := &bls.PublicKey{}
+	sharePKs := make(map[spectypes.OperatorID]*bls.PublicKey, len(operators))
+	for _, op := range operators {
+		sharePKs[op.ID] = &bls.PublicKey{}
+	}
+	for i, c := range commitments {
+		if i == 0 {
+			*validatorPK = c[0]
+		} else {
+			validatorPK.Add(&c[0])
+		}
+		for _, op := range operators {
+			pk, err := sharePubKey(c, op.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if i == 0 {
+				*sharePKs[op.ID] = *pk
+			} else {
+				sharePKs[op.ID].Add(pk)
+			}
+		}
+	}
+	return validatorPK, sharePKs, nil
+}
+
+// dealsRoot commits to a set of deals, ordered by operator
+func dealsRoot(ceremonyID string, deals []*Deal) ([]byte, error) {
+	h := sha256.New()
+	for _, deal := range deals {
+		root, err := deal.root(ceremonyID)
+		if err != nil {
+			return nil, err
+		}
+		h.Write(root)
+	}
+	return h.Sum(nil), nil
+}
+
+// recoverSignature reconstructs a signature from partial signatures verified against the public keys of their shares
+func recoverSignature(partials map[spectypes.OperatorID][]byte, sharePKs map[spectypes.OperatorID]*bls.PublicKey, msg []byte, threshold uint64) (*bls.Sign, error) {
+	var sigs []bls.Sign
+	var ids []bls.ID
+	for id, raw := range partials {
+		sig := bls.Sign{}
+		if err := sig.Deserialize(raw); err != nil {
+			return nil, errors.Wrapf(err, "could not deserialize partial signature of operator %d", id)
+		}
+		if !sig.VerifyByte(sharePKs[id], msg) {
+			return nil, errors.Errorf("invalid partial signature of operator %d", id)
+		}
+		blsID, err := operatorBlsID(id)
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, sig)
+		ids = append(ids, *blsID)
+	}
+	if uint64(len(sigs)) < threshold {
+		return nil, errors.Errorf("not enough partial signatures, got %d out of %d", len(sigs), threshold)
+	}
+	sig := &bls.Sign{}
+	if err := sig.Recover(sigs, ids); err != nil {
+		return nil, errors.Wrap(err, "could not recover signature")
+	}
+	return sig, nil
+}
@@ -0,0 +1,78 @@
+package dkg
+
+import (
+	"encoding/hex"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DepositAmount is the amount of a validator deposit
+	DepositAmount phase0.Gwei = 32000000000
+	// depositCliVersion is the version of the deposit-cli file format the launchpad expects
+	depositCliVersion = "2.5.0"
+)
+
+// DepositData is a deposit in the format of deposit-cli's deposit_data files
+type DepositData struct {
+	PubKey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	DepositMessageRoot    string `json:"deposit_message_root"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+	ForkVersion           string `json:"fork_version"`
+	NetworkName           string `json:"network_name"`
+	DepositCliVersion     string `json:"deposit_cli_version"`
+}
+
+// depositMessage returns the deposit message of the validator and the root its signature is computed over
+func depositMessage(validatorPK []byte, withdrawalCredentials []byte, forkVersion phase0.Version) (*phase0.DepositMessage, phase0.Root, error) {
+	msg := &phase0.DepositMessage{
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                DepositAmount,
+	}
+	copy(msg.PublicKey[:], validatorPK)
+
+	// deposits are valid across forks, so the domain uses the genesis fork version and no genesis validators root
+	domain, err := spectypes.ComputeETHDomain(spectypes.DomainDeposit, forkVersion, phase0.Root{})
+	if err != nil {
+		return nil, phase0.Root{}, errors.Wrap(err, "could not compute deposit domain")
+	}
+	root, err := spectypes.ComputeETHSigningRoot(msg, domain)
+	if err != nil {
+		return nil, phase0.Root{}, errors.Wrap(err, "could not compute deposit signing root")
+	}
+	return msg, root, nil
+}
+
+// newDepositData returns the signed deposit of the validator
+func newDepositData(msg *phase0.DepositMessage, signature []byte, forkVersion phase0.Version) (*DepositData, error) {
+	data := &phase0.DepositData{
+		PublicKey:             msg.PublicKey,
+		WithdrawalCredentials: msg.WithdrawalCredentials,
+		Amount:                msg.Amount,
+	}
+	copy(data.Signature[:], signature)
+
+	msgRoot, err := msg.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute deposit message root")
+	}
+	dataRoot, err := data.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute deposit data root")
+	}
+	return &DepositData{
+		PubKey:                hex.EncodeToString(data.PublicKey[:]),
+		WithdrawalCredentials: hex.EncodeToString(data.WithdrawalCredentials),
+		Amount:                uint64(data.Amount),
+		Signature:             hex.EncodeToString(data.Signature[:]),
+		DepositMessageRoot:    hex.EncodeToString(msgRoot[:]),
+		DepositDataRoot:       hex.EncodeToString(dataRoot[:]),
+		ForkVersion:           hex.EncodeToString(forkVersion[:]),
+		DepositCliVersion:     depositCliVersion,
+	}, nil
+}
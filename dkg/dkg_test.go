@@ -0,0 +1,347 @@
+package dkg
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/network/forks/genesis"
+	"github.com/bloxapp/ssv/network/streams"
+	"github.com/bloxapp/ssv/utils/keyshares"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+)
+
+type testOperator struct {
+	Operator
+	sk          *rsa.PrivateKey
+	participant *Participant
+}
+
+func newTestOperators(t *testing.T, n int) []*testOperator {
+	operators := make([]*testOperator, n)
+	for i := range operators {
+		pk, skPem, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		sk, err := rsaencryption.ConvertPemToPrivateKey(string(skPem))
+		require.NoError(t, err)
+		operators[i] = &testOperator{
+			Operator: Operator{ID: spectypes.OperatorID(i + 1), PubKey: base64.StdEncoding.EncodeToString(pk)},
+			sk:       sk,
+			participant: NewParticipant(func() (*rsa.PrivateKey, bool, error) {
+				return sk, true, nil
+			}, nil),
+		}
+	}
+	return operators
+}
+
+func newTestInit(operators []*testOperator) *Init {
+	ceremony := &Init{
+		WithdrawalCredentials: append([]byte{1}, make([]byte, 31)...),
+		ForkVersion:           phase0.Version{0x00, 0x00, 0x10, 0x20},
+		Owner:                 common.HexToAddress("0x1234567890123456789012345678901234567890"),
+		Nonce:                 3,
+	}
+	for _, op := range operators {
+		ceremony.Operators = append(ceremony.Operators, op.Operator)
+	}
+	return ceremony
+}
+
+// newTestMessage returns a message signed by the given operator for the given peer
+func newTestMessage(t *testing.T, initiator *testOperator, peerID peer.ID, msgType MessageType, ceremonyID string, data interface{}) *Message {
+	raw, err := json.Marshal(data)
+	require.NoError(t, err)
+	msg := &Message{Type: msgType, CeremonyID: ceremonyID, Data: raw, Initiator: initiator.ID}
+	root, err := msg.root(peerID)
+	require.NoError(t, err)
+	msg.Signature, err = sign(initiator.sk, root)
+	require.NoError(t, err)
+	return msg
+}
+
+// testTransport delivers requests to the participants directly, optionally tampering with their responses
+type testTransport struct {
+	logger    *zap.Logger
+	peer      peer.ID
+	operators map[spectypes.OperatorID]*testOperator
+	tamper    func(op *testOperator, msg *Message, res *Response)
+}
+
+func newTestTransport(t *testing.T, operators []*testOperator) *testTransport {
+	tt := &testTransport{
+		logger:    logging.TestLogger(t),
+		peer:      "initiator",
+		operators: make(map[spectypes.OperatorID]*testOperator),
+	}
+	for _, op := range operators {
+		tt.operators[op.ID] = op
+	}
+	return tt
+}
+
+func (tt *testTransport) PeerID() peer.ID {
+	return tt.peer
+}
+
+func (tt *testTransport) Send(_ context.Context, operatorID spectypes.OperatorID, req []byte) ([]byte, error) {
+	op := tt.operators[operatorID]
+	raw, err := op.participant.HandleRequest(tt.logger, tt.peer, req)
+	if err != nil || tt.tamper == nil {
+		return raw, err
+	}
+	msg := &Message{}
+	res := &Response{}
+	if err := json.Unmarshal(req, msg); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, res); err != nil {
+		return nil, err
+	}
+	tt.tamper(op, msg, res)
+	return json.Marshal(res)
+}
+
+func TestCeremony(t *testing.T) {
+	for _, n := range []int{4, 7} {
+		t.Run(fmt.Sprintf("%d operators", n), func(t *testing.T) {
+			operators := newTestOperators(t, n)
+			ceremony := newTestInit(operators)
+			initiator := NewInitiator(logging.TestLogger(t), newTestTransport(t, operators), operators[0].ID, operators[0].sk)
+
+			out, err := initiator.Run(context.Background(), ceremony)
+			require.NoError(t, err)
+
+			validatorPK, err := hexutil.Decode(out.KeyShares.Payload.PublicKey)
+			require.NoError(t, err)
+			require.Equal(t, hex.EncodeToString(validatorPK), out.DepositData.PubKey)
+			require.Equal(t, hex.EncodeToString(ceremony.WithdrawalCredentials), out.DepositData.WithdrawalCredentials)
+			require.Equal(t, "00001020", out.DepositData.ForkVersion)
+			require.Equal(t, uint64(DepositAmount), out.DepositData.Amount)
+
+			// every operator decrypts a share matching its public key in the payload
+			sharesData, err := hexutil.Decode(out.KeyShares.Payload.SharesData)
+			require.NoError(t, err)
+			pubKeysOffset := phase0.SignatureLength + phase0.PublicKeyLength*n
+			require.Len(t, sharesData, pubKeysOffset+keyshares.EncryptedKeyLength*n)
+			shares := make([]bls.SecretKey, n)
+			ids := make([]bls.ID, n)
+			for i, op := range operators {
+				require.Equal(t, op.ID, out.KeyShares.Payload.OperatorIDs[i])
+				encrypted := sharesData[pubKeysOffset+i*keyshares.EncryptedKeyLength : pubKeysOffset+(i+1)*keyshares.EncryptedKeyLength]
+				decrypted, err := rsaencryption.DecodeKey(op.sk, encrypted)
+				require.NoError(t, err)
+				require.NoError(t, shares[i].SetHexString(string(decrypted)))
+				sharePK := sharesData[phase0.SignatureLength+i*phase0.PublicKeyLength : phase0.SignatureLength+(i+1)*phase0.PublicKeyLength]
+				require.Equal(t, sharePK, shares[i].GetPublicKey().Serialize())
+				blsID, err := operatorBlsID(op.ID)
+				require.NoError(t, err)
+				ids[i] = *blsID
+			}
+
+			// any quorum of shares reconstructs the same validator key
+			quorum := int(ceremony.threshold())
+			for _, from := range []int{0, n - quorum} {
+				sk := &bls.SecretKey{}
+				require.NoError(t, sk.Recover(shares[from:from+quorum], ids[from:from+quorum]))
+				require.Equal(t, validatorPK, sk.GetPublicKey().Serialize())
+			}
+			// but less than a quorum doesn't
+			sk := &bls.SecretKey{}
+			require.NoError(t, sk.Recover(shares[:quorum-1], ids[:quorum-1]))
+			require.NotEqual(t, validatorPK, sk.GetPublicKey().Serialize())
+
+			// the deposit and the ownership proof are signed by the validator key
+			pk := &bls.PublicKey{}
+			require.NoError(t, pk.Deserialize(validatorPK))
+			_, depositRoot, err := depositMessage(validatorPK, ceremony.WithdrawalCredentials, ceremony.ForkVersion)
+			require.NoError(t, err)
+			depositSig := &bls.Sign{}
+			require.NoError(t, depositSig.DeserializeHexStr(out.DepositData.Signature))
+			require.True(t, depositSig.VerifyByte(pk, depositRoot[:]))
+			ownerSig := &bls.Sign{}
+			require.NoError(t, ownerSig.Deserialize(sharesData[:phase0.SignatureLength]))
+			require.True(t, ownerSig.VerifyByte(pk, keyshares.OwnerNonceHash(ceremony.Owner, ceremony.Nonce)))
+		})
+	}
+}
+
+func TestCeremonyCheatingDealer(t *testing.T) {
+	operators := newTestOperators(t, 4)
+	transport := newTestTransport(t, operators)
+	// operator 2 deals operator 3 a share that isn't on its polynomial, but signs it
+	transport.tamper = func(op *testOperator, msg *Message, res *Response) {
+		if msg.Type != InitMessageType || op.ID != 2 {
+			return
+		}
+		deal := &Deal{}
+		require.NoError(t, json.Unmarshal(res.Data, deal))
+		bad := &bls.SecretKey{}
+		bad.SetByCSPRNG()
+		var err error
+		deal.Shares[3], err = keyshares.EncryptShare(operators[2].PubKey, bad)
+		require.NoError(t, err)
+		root, err := deal.root(msg.CeremonyID)
+		require.NoError(t, err)
+		deal.Signature, err = sign(op.sk, root)
+		require.NoError(t, err)
+		res.Data, err = json.Marshal(deal)
+		require.NoError(t, err)
+	}
+
+	_, err := NewInitiator(logging.TestLogger(t), transport, operators[0].ID, operators[0].sk).Run(context.Background(), newTestInit(operators))
+	require.ErrorContains(t, err, "operator 3: share of operator 2 doesn't match its commitments")
+}
+
+func TestCeremonyTamperedDeal(t *testing.T) {
+	operators := newTestOperators(t, 4)
+	transport := newTestTransport(t, operators)
+	// the deal of operator 1 is altered in transit
+	transport.tamper = func(op *testOperator, msg *Message, res *Response) {
+		if msg.Type != InitMessageType || op.ID != 1 {
+			return
+		}
+		deal := &Deal{}
+		require.NoError(t, json.Unmarshal(res.Data, deal))
+		deal.Commitments[0] = deal.Commitments[1]
+		var err error
+		res.Data, err = json.Marshal(deal)
+		require.NoError(t, err)
+	}
+
+	_, err := NewInitiator(logging.TestLogger(t), transport, operators[0].ID, operators[0].sk).Run(context.Background(), newTestInit(operators))
+	require.ErrorContains(t, err, "invalid signature of operator 1")
+
+	// operators reject tampered deals as well
+	transport.tamper = nil
+	p := operators[0].participant
+	logger := logging.TestLogger(t)
+	ceremony := newTestInit(operators)
+	initMsg := newTestMessage(t, operators[0], "initiator", InitMessageType, "c", ceremony)
+	var deals []*Deal
+	for _, op := range operators {
+		res, err := op.participant.Handle(logger, "initiator", initMsg)
+		require.NoError(t, err)
+		deals = append(deals, res.(*Deal))
+	}
+	deals[2].Commitments[0] = deals[2].Commitments[1]
+	exchangeMsg := newTestMessage(t, operators[0], "initiator", ExchangeMessageType, "c", &Exchange{Deals: deals})
+	_, err = p.Handle(logger, "initiator", exchangeMsg)
+	require.ErrorContains(t, err, "invalid signature of operator 3")
+
+	// the session is gone after a failed exchange
+	_, err = p.Handle(logger, "initiator", exchangeMsg)
+	require.ErrorContains(t, err, "unknown ceremony")
+}
+
+func TestInvalidInit(t *testing.T) {
+	operators := newTestOperators(t, 5)
+	logger := logging.TestLogger(t)
+
+	ceremony := newTestInit(operators)
+	_, err := operators[0].participant.deal(logger, "c", operators[0].sk, "initiator", ceremony)
+	require.ErrorContains(t, err, "invalid number of operators 5")
+
+	ceremony = newTestInit(operators[1:])
+	_, err = operators[0].participant.deal(logger, "c", operators[0].sk, "initiator", ceremony)
+	require.ErrorContains(t, err, "operator is not a member of the ceremony")
+
+	ceremony = newTestInit(operators[:4])
+	ceremony.Operators[1].ID = 1
+	_, err = operators[0].participant.deal(logger, "c", operators[0].sk, "initiator", ceremony)
+	require.ErrorContains(t, err, "duplicate operator 1")
+
+	ceremony = newTestInit(operators[:4])
+	ceremony.WithdrawalCredentials = ceremony.WithdrawalCredentials[1:]
+	_, err = operators[0].participant.deal(logger, "c", operators[0].sk, "initiator", ceremony)
+	require.ErrorContains(t, err, "withdrawal credentials must be 32 bytes")
+}
+
+func TestUnauthenticatedRequests(t *testing.T) {
+	operators := newTestOperators(t, 4)
+	outsider := newTestOperators(t, 1)[0]
+	outsider.ID = 5
+	logger := logging.TestLogger(t)
+	p := operators[0].participant
+	ceremony := newTestInit(operators)
+
+	// an init which isn't signed by an operator of the ceremony doesn't start a session
+	_, err := p.Handle(logger, "initiator", newTestMessage(t, outsider, "initiator", InitMessageType, "c", ceremony))
+	require.ErrorContains(t, err, "initiator 5 is not an operator of the ceremony")
+	forged := newTestMessage(t, outsider, "initiator", InitMessageType, "c", ceremony)
+	forged.Initiator = operators[1].ID
+	_, err = p.Handle(logger, "initiator", forged)
+	require.ErrorContains(t, err, "invalid signature of operator 2")
+	// nor does an init replayed by another peer
+	_, err = p.Handle(logger, "other", newTestMessage(t, operators[1], "initiator", InitMessageType, "c", ceremony))
+	require.ErrorContains(t, err, "invalid signature of operator 2")
+	require.Empty(t, p.sessions)
+
+	// an exchange of another peer doesn't complete or drop the session
+	_, err = p.Handle(logger, "initiator", newTestMessage(t, operators[1], "initiator", InitMessageType, "c", ceremony))
+	require.NoError(t, err)
+	exchange := &Exchange{}
+	_, err = p.Handle(logger, "other", newTestMessage(t, operators[1], "other", ExchangeMessageType, "c", exchange))
+	require.ErrorContains(t, err, "ceremony was started by another peer")
+	_, err = p.Handle(logger, "initiator", newTestMessage(t, outsider, "initiator", ExchangeMessageType, "c", exchange))
+	require.ErrorContains(t, err, "initiator 5 is not an operator of the ceremony")
+	require.Len(t, p.sessions, 1)
+
+	// the initiator must be an operator of the ceremony
+	_, err = NewInitiator(logger, newTestTransport(t, operators), outsider.ID, outsider.sk).Run(context.Background(), ceremony)
+	require.ErrorContains(t, err, "initiator 5 is not an operator of the ceremony")
+}
+
+func TestStreamTransport(t *testing.T) {
+	logger := logging.TestLogger(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	newHost := func() (host.Host, streams.StreamController) {
+		h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = h.Close() })
+		return h, streams.NewStreamController(ctx, h, genesis.New(), 5*time.Second, 10*time.Second)
+	}
+
+	operators := newTestOperators(t, 4)
+	addrs := make(map[spectypes.OperatorID]string)
+	for _, op := range operators {
+		h, ctrl := newHost()
+		participant := op.participant
+		h.SetStreamHandler(ProtocolID, func(stream libp2pnetwork.Stream) {
+			req, respond, done, err := ctrl.HandleStream(logger, stream)
+			defer done()
+			require.NoError(t, err)
+			res, err := participant.HandleRequest(logger, stream.Conn().RemotePeer(), req)
+			require.NoError(t, err)
+			require.NoError(t, respond(res))
+		})
+		addrs[op.ID] = fmt.Sprintf("%s/p2p/%s", h.Addrs()[0], h.ID())
+	}
+
+	h, ctrl := newHost()
+	transport, err := NewStreamTransport(logger, h, ctrl, addrs)
+	require.NoError(t, err)
+	out, err := NewInitiator(logger, transport, operators[0].ID, operators[0].sk).Run(ctx, newTestInit(operators))
+	require.NoError(t, err)
+	require.Len(t, out.KeyShares.Payload.OperatorIDs, 4)
+}
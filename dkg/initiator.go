@@ -0,0 +1,238 @@
+package dkg
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/utils/keyshares"
+)
+
+// Transport sends a raw request to an operator and returns its response
+type Transport interface {
+	Send(ctx context.Context, operatorID spectypes.OperatorID, req []byte) ([]byte, error)
+	// PeerID returns the peer the requests are sent from
+	PeerID() peer.ID
+}
+
+// Initiator runs DKG ceremonies by relaying messages between the operators.
+// It only sees commitments, encrypted shares and partial signatures.
+// The initiator must be an operator of the ceremony, it signs the messages with the operator key.
+type Initiator struct {
+	logger     *zap.Logger
+	transport  Transport
+	operatorID spectypes.OperatorID
+	sk         *rsa.PrivateKey
+}
+
+// NewInitiator creates a new Initiator for the given operator
+func NewInitiator(logger *zap.Logger, transport Transport, operatorID spectypes.OperatorID, sk *rsa.PrivateKey) *Initiator {
+	spectypes.InitBLS()
+	return &Initiator{
+		logger:     logger,
+		transport:  transport,
+		operatorID: operatorID,
+		sk:         sk,
+	}
+}
+
+// Run runs a ceremony, generating a validator key shared by the operators
+func (i *Initiator) Run(ctx context.Context, ceremony *Init) (*Output, error) {
+	if err := ceremony.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid init")
+	}
+	if ceremony.index(i.operatorID) < 0 {
+		return nil, errors.Errorf("initiator %d is not an operator of the ceremony", i.operatorID)
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	ceremonyID := hex.EncodeToString(id)
+	logger := i.logger.With(zap.String("ceremony_id", ceremonyID))
+
+	// round 1: every operator deals a random polynomial
+	deals := make([]*Deal, len(ceremony.Operators))
+	err := i.broadcast(ctx, ceremony, ceremonyID, InitMessageType, ceremony, func(idx int, data json.RawMessage) error {
+		deal := &Deal{}
+		if err := json.Unmarshal(data, deal); err != nil {
+			return err
+		}
+		if deal.OperatorID != ceremony.Operators[idx].ID {
+			return errors.Errorf("unexpected deal of operator %d", deal.OperatorID)
+		}
+		deals[idx] = deal
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not collect deals")
+	}
+	deals, commitments, err := verifyDeals(ceremonyID, ceremony, deals)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("collected deals of all operators")
+
+	// round 2: every operator combines the shares dealt to it
+	results := make([]*Result, len(ceremony.Operators))
+	err = i.broadcast(ctx, ceremony, ceremonyID, ExchangeMessageType, &Exchange{Deals: deals}, func(idx int, data json.RawMessage) error {
+		result := &Result{}
+		if err := json.Unmarshal(data, result); err != nil {
+			return err
+		}
+		if result.OperatorID != ceremony.Operators[idx].ID {
+			return errors.Errorf("unexpected result of operator %d", result.OperatorID)
+		}
+		results[idx] = result
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not collect results")
+	}
+	logger.Info("collected results of all operators")
+
+	return i.output(ceremonyID, ceremony, deals, commitments, results)
+}
+
+// output verifies the results of the operators against the deals and reconstructs the signatures of the validator
+func (i *Initiator) output(ceremonyID string, ceremony *Init, deals []*Deal, commitments [][]bls.PublicKey, results []*Result) (*Output, error) {
+	validatorPK, sharePKs, err := aggregate(commitments, ceremony.Operators)
+	if err != nil {
+		return nil, err
+	}
+	expectedDealsRoot, err := dealsRoot(ceremonyID, deals)
+	if err != nil {
+		return nil, err
+	}
+
+	depositPartials := make(map[spectypes.OperatorID][]byte, len(results))
+	ownerNoncePartials := make(map[spectypes.OperatorID][]byte, len(results))
+	shares := make([]keyshares.Share, len(results))
+	for idx, result := range results {
+		op := ceremony.Operators[idx]
+		root, err := result.root(ceremonyID)
+		if err != nil {
+			return nil, err
+		}
+		if err := verify(op, root, result.Signature); err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(result.DealsRoot, expectedDealsRoot) {
+			return nil, errors.Errorf("operator %d used different deals", op.ID)
+		}
+		if !bytes.Equal(result.ValidatorPubKey, validatorPK.Serialize()) {
+			return nil, errors.Errorf("operator %d computed a different validator public key", op.ID)
+		}
+		if !bytes.Equal(result.SharePubKey, sharePKs[op.ID].Serialize()) {
+			return nil, errors.Errorf("share public key of operator %d doesn't match the commitments", op.ID)
+		}
+		depositPartials[op.ID] = result.DepositSignature
+		ownerNoncePartials[op.ID] = result.OwnerNonceSignature
+		shares[idx] = keyshares.Share{
+			OperatorID:   op.ID,
+			OperatorKey:  op.PubKey,
+			PubKey:       result.SharePubKey,
+			EncryptedKey: result.EncryptedShare,
+		}
+	}
+
+	msg, depositRoot, err := depositMessage(validatorPK.Serialize(), ceremony.WithdrawalCredentials, ceremony.ForkVersion)
+	if err != nil {
+		return nil, err
+	}
+	depositSig, err := recoverSignature(depositPartials, sharePKs, depositRoot[:], ceremony.threshold())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not reconstruct deposit signature")
+	}
+	if !depositSig.VerifyByte(validatorPK, depositRoot[:]) {
+		return nil, errors.New("invalid deposit signature")
+	}
+	ownerNonceHash := keyshares.OwnerNonceHash(ceremony.Owner, ceremony.Nonce)
+	ownerNonceSig, err := recoverSignature(ownerNoncePartials, sharePKs, ownerNonceHash, ceremony.threshold())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not reconstruct owner nonce signature")
+	}
+	if !ownerNonceSig.VerifyByte(validatorPK, ownerNonceHash) {
+		return nil, errors.New("invalid owner nonce signature")
+	}
+
+	depositData, err := newDepositData(msg, depositSig.Serialize(), ceremony.ForkVersion)
+	if err != nil {
+		return nil, err
+	}
+	ks, err := keyshares.New(validatorPK.Serialize(), ceremony.Owner, ceremony.Nonce, ownerNonceSig.Serialize(), shares)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create keyshares")
+	}
+	return &Output{
+		KeyShares:   ks,
+		DepositData: depositData,
+	}, nil
+}
+
+// broadcast sends a message to all operators in parallel and handles their responses,
+// failing if any operator fails
+func (i *Initiator) broadcast(ctx context.Context, ceremony *Init, ceremonyID string, msgType MessageType, data interface{}, handle func(idx int, data json.RawMessage) error) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	msg := &Message{
+		Type:       msgType,
+		CeremonyID: ceremonyID,
+		Data:       raw,
+		Initiator:  i.operatorID,
+	}
+	root, err := msg.root(i.transport.PeerID())
+	if err != nil {
+		return err
+	}
+	if msg.Signature, err = sign(i.sk, root); err != nil {
+		return errors.Wrap(err, "could not sign message")
+	}
+	req, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(ceremony.Operators))
+	for idx, op := range ceremony.Operators {
+		wg.Add(1)
+		go func(idx int, op Operator) {
+			defer wg.Done()
+			errs[idx] = func() error {
+				raw, err := i.transport.Send(ctx, op.ID, req)
+				if err != nil {
+					return err
+				}
+				res := &Response{}
+				if err := json.Unmarshal(raw, res); err != nil {
+					return errors.Wrap(err, "could not unmarshal response")
+				}
+				if res.Error != "" {
+					return errors.New(res.Error)
+				}
+				return handle(idx, res.Data)
+			}()
+		}(idx, op)
+	}
+	wg.Wait()
+
+	for idx, err := range errs {
+		if err != nil {
+			return errors.Wrapf(err, "operator %d", ceremony.Operators[idx].ID)
+		}
+	}
+	return nil
+}
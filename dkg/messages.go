@@ -0,0 +1,214 @@
+package dkg
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	"github.com/bloxapp/ssv/utils/keyshares"
+)
+
+// ProtocolID is the stream protocol of DKG ceremonies
+const ProtocolID = "/ssv/dkg/0.0.1"
+
+// MessageType is the type of a DKG message
+type MessageType string
+
+const (
+	// InitMessageType starts a ceremony, operators respond with a Deal
+	InitMessageType MessageType = "init"
+	// ExchangeMessageType delivers the deals of all operators, operators respond with a Result
+	ExchangeMessageType MessageType = "exchange"
+)
+
+// Message is a request of the initiator to an operator.
+// The initiator is an operator of the ceremony, which signs every message for the peer it sends it from.
+type Message struct {
+	Type       MessageType          `json:"type"`
+	CeremonyID string               `json:"ceremonyId"`
+	Data       json.RawMessage      `json:"data"`
+	Initiator  spectypes.OperatorID `json:"initiator"`
+	Signature  []byte               `json:"signature"`
+}
+
+// Response is the response of an operator to a Message
+type Response struct {
+	Error string          `json:"error,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+}
+
+// Operator is a member of a ceremony
+type Operator struct {
+	ID spectypes.OperatorID `json:"id"`
+	// PubKey is the base64 encoded PEM public key of the operator
+	PubKey string `json:"publicKey"`
+}
+
+// Init describes the validator to generate
+type Init struct {
+	Operators             []Operator     `json:"operators"`
+	WithdrawalCredentials []byte         `json:"withdrawalCredentials"`
+	ForkVersion           phase0.Version `json:"forkVersion"`
+	Owner                 common.Address `json:"owner"`
+	Nonce                 uint64         `json:"nonce"`
+}
+
+// Deal is the contribution of an operator to the validator key:
+// commitments to the coefficients of a random polynomial and its evaluations for every operator.
+type Deal struct {
+	OperatorID  spectypes.OperatorID `json:"operatorId"`
+	Commitments [][]byte             `json:"commitments"`
+	// Shares are the evaluations of the polynomial, each encrypted with the public key of its operator
+	Shares    map[spectypes.OperatorID][]byte `json:"shares"`
+	Signature []byte                          `json:"signature"`
+}
+
+// Exchange delivers the deals of all operators
+type Exchange struct {
+	Deals []*Deal `json:"deals"`
+}
+
+// Result is the outcome of a ceremony for an operator
+type Result struct {
+	OperatorID      spectypes.OperatorID `json:"operatorId"`
+	ValidatorPubKey []byte               `json:"validatorPubKey"`
+	SharePubKey     []byte               `json:"sharePubKey"`
+	// EncryptedShare is the share encrypted with the public key of the operator, as registered with the contract
+	EncryptedShare []byte `json:"encryptedShare"`
+	// DepositSignature is a partial signature over the deposit message
+	DepositSignature []byte `json:"depositSignature"`
+	// OwnerNonceSignature is a partial signature over keyshares.OwnerNonceHash
+	OwnerNonceSignature []byte `json:"ownerNonceSignature"`
+	// DealsRoot commits to the deals the operator used, which must be the same for all operators
+	DealsRoot []byte `json:"dealsRoot"`
+	Signature []byte `json:"signature"`
+}
+
+// Output is the outcome of a ceremony
+type Output struct {
+	KeyShares   *keyshares.KeyShares
+	DepositData *DepositData
+}
+
+// validate validates the ceremony parameters
+func (in *Init) validate() error {
+	n := len(in.Operators)
+	if !keyshares.ValidCommitteeSize(n) {
+		return errors.Errorf("invalid number of operators %d, must be one of 4, 7, 10 or 13", n)
+	}
+	seen := make(map[spectypes.OperatorID]bool, n)
+	for _, op := range in.Operators {
+		if op.ID == 0 {
+			return errors.New("invalid operator id 0")
+		}
+		if seen[op.ID] {
+			return errors.Errorf("duplicate operator %d", op.ID)
+		}
+		seen[op.ID] = true
+		if _, err := keyshares.ParseOperatorKey(op.PubKey); err != nil {
+			return errors.Wrapf(err, "invalid public key of operator %d", op.ID)
+		}
+	}
+	if len(in.WithdrawalCredentials) != 32 {
+		return errors.New("withdrawal credentials must be 32 bytes")
+	}
+	return nil
+}
+
+// threshold returns the number of shares required to sign, which is the quorum of the committee
+func (in *Init) threshold() uint64 {
+	quorum, _ := types.ComputeQuorumAndPartialQuorum(len(in.Operators))
+	return quorum
+}
+
+// index returns the index of the operator with the given id, or -1
+func (in *Init) index(id spectypes.OperatorID) int {
+	for i, op := range in.Operators {
+		if op.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// root returns the hash the message is signed over, binding it to the peer which sends it
+func (m *Message) root(peerID peer.ID) ([]byte, error) {
+	return signingRoot(m.CeremonyID, &struct {
+		Type      MessageType          `json:"type"`
+		Data      json.RawMessage      `json:"data"`
+		Initiator spectypes.OperatorID `json:"initiator"`
+		Peer      string               `json:"peer"`
+	}{m.Type, m.Data, m.Initiator, peerID.String()})
+}
+
+// authenticate verifies that the message was signed by one of the operators for the peer it was received from
+func (m *Message) authenticate(peerID peer.ID, operators []Operator) error {
+	var initiator *Operator
+	for i := range operators {
+		if operators[i].ID == m.Initiator {
+			initiator = &operators[i]
+		}
+	}
+	if initiator == nil {
+		return errors.Errorf("initiator %d is not an operator of the ceremony", m.Initiator)
+	}
+	root, err := m.root(peerID)
+	if err != nil {
+		return err
+	}
+	return verify(*initiator, root, m.Signature)
+}
+
+// root returns the hash the deal is signed over
+func (d *Deal) root(ceremonyID string) ([]byte, error) {
+	return signingRoot(ceremonyID, &Deal{
+		OperatorID:  d.OperatorID,
+		Commitments: d.Commitments,
+		Shares:      d.Shares,
+	})
+}
+
+// root returns the hash the result is signed over
+func (r *Result) root(ceremonyID string) ([]byte, error) {
+	unsigned := *r
+	unsigned.Signature = nil
+	return signingRoot(ceremonyID, &unsigned)
+}
+
+func signingRoot(ceremonyID string, v interface{}) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	h.Write([]byte(ProtocolID))
+	h.Write([]byte(ceremonyID))
+	h.Write(raw)
+	return h.Sum(nil), nil
+}
+
+// sign signs a root with the RSA key of the operator
+func sign(sk *rsa.PrivateKey, root []byte) ([]byte, error) {
+	return rsa.SignPKCS1v15(rand.Reader, sk, crypto.SHA256, root)
+}
+
+// verify verifies the RSA signature of an operator over a root
+func verify(op Operator, root, signature []byte) error {
+	pk, err := keyshares.ParseOperatorKey(op.PubKey)
+	if err != nil {
+		return err
+	}
+	if err := rsa.VerifyPKCS1v15(pk, crypto.SHA256, root, signature); err != nil {
+		return errors.Wrapf(err, "invalid signature of operator %d", op.ID)
+	}
+	return nil
+}
@@ -0,0 +1,376 @@
+package dkg
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"encoding/json"
+	"sync"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/utils/keyshares"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+)
+
+const (
+	// sessionTimeout is how long an operator waits for the deals of a ceremony it dealt in
+	sessionTimeout = 5 * time.Minute
+	// maxSessions limits the number of concurrent ceremonies of an operator
+	maxSessions = 16
+)
+
+// PrivateKeyProvider returns the RSA private key of the operator
+type PrivateKeyProvider func() (*rsa.PrivateKey, bool, error)
+
+// OperatorVerifier verifies that a ceremony operator is registered, returning an error otherwise
+type OperatorVerifier func(logger *zap.Logger, op Operator) error
+
+// Options configures the participation of the node in DKG ceremonies
+type Options struct {
+	Enabled bool `yaml:"Enabled" env:"DKG_ENABLED" env-description:"Whether to take part in DKG ceremonies of registered operators"`
+}
+
+// RegisteredOperators returns an OperatorVerifier accepting only operators registered with the same id
+func RegisteredOperators(operators registrystorage.Operators) OperatorVerifier {
+	return func(logger *zap.Logger, op Operator) error {
+		data, found, err := operators.GetOperatorDataByPubKey(logger, []byte(op.PubKey))
+		if err != nil {
+			return errors.Wrap(err, "could not get operator data")
+		}
+		if !found {
+			return errors.New("operator is not registered")
+		}
+		if data.ID != op.ID {
+			return errors.Errorf("operator is registered with id %d", data.ID)
+		}
+		return nil
+	}
+}
+
+// session is a ceremony the operator dealt in, waiting for the deals of the other operators
+type session struct {
+	ceremony *Init
+	self     Operator
+	poly     polynomial
+	created  time.Time
+	// peer is the peer of the initiator, only it can complete the ceremony
+	peer peer.ID
+}
+
+// Participant runs the operator side of DKG ceremonies.
+// The secret of the polynomial it deals never leaves the operator, and the shares it deals
+// are encrypted to their operators, so neither the initiator nor any operator learns the validator key.
+type Participant struct {
+	privateKey     PrivateKeyProvider
+	verifyOperator OperatorVerifier
+
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+// NewParticipant creates a new Participant, verifyOperator is optional
+func NewParticipant(privateKey PrivateKeyProvider, verifyOperator OperatorVerifier) *Participant {
+	spectypes.InitBLS()
+	return &Participant{
+		privateKey:     privateKey,
+		verifyOperator: verifyOperator,
+		sessions:       make(map[string]*session),
+	}
+}
+
+// HandleRequest handles a raw request of the DKG stream protocol
+func (p *Participant) HandleRequest(logger *zap.Logger, peerID peer.ID, req []byte) ([]byte, error) {
+	msg := &Message{}
+	var res interface{}
+	err := json.Unmarshal(req, msg)
+	if err == nil {
+		logger = logger.With(zap.String("ceremony_id", msg.CeremonyID), zap.String("type", string(msg.Type)))
+		res, err = p.Handle(logger, peerID, msg)
+	}
+
+	response := &Response{}
+	if err != nil {
+		logger.Warn("dkg request failed", zap.Error(err))
+		response.Error = err.Error()
+	} else if response.Data, err = json.Marshal(res); err != nil {
+		return nil, errors.Wrap(err, "could not marshal response")
+	}
+	return json.Marshal(response)
+}
+
+// Handle handles a message of the initiator received from the given peer, returning a *Deal or a *Result.
+// Messages which aren't signed by an operator of the ceremony for that peer are rejected before any session is allocated.
+func (p *Participant) Handle(logger *zap.Logger, peerID peer.ID, msg *Message) (interface{}, error) {
+	if msg.CeremonyID == "" {
+		return nil, errors.New("missing ceremony id")
+	}
+	sk, found, err := p.privateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get operator private key")
+	}
+	if !found {
+		return nil, errors.New("operator private key not found")
+	}
+
+	switch msg.Type {
+	case InitMessageType:
+		ceremony := &Init{}
+		if err := json.Unmarshal(msg.Data, ceremony); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal init")
+		}
+		if err := msg.authenticate(peerID, ceremony.Operators); err != nil {
+			return nil, errors.Wrap(err, "could not authenticate initiator")
+		}
+		return p.deal(logger, msg.CeremonyID, sk, peerID, ceremony)
+	case ExchangeMessageType:
+		exchange := &Exchange{}
+		if err := json.Unmarshal(msg.Data, exchange); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal exchange")
+		}
+		s, err := p.takeSession(msg.CeremonyID, func(s *session) error {
+			if peerID != s.peer {
+				return errors.New("ceremony was started by another peer")
+			}
+			return errors.Wrap(msg.authenticate(peerID, s.ceremony.Operators), "could not authenticate initiator")
+		})
+		if err != nil {
+			return nil, err
+		}
+		return p.exchange(logger, msg.CeremonyID, sk, s, exchange)
+	default:
+		return nil, errors.Errorf("unknown message type %q", msg.Type)
+	}
+}
+
+// deal starts a ceremony of the given initiator peer, dealing a random polynomial to the operators
+func (p *Participant) deal(logger *zap.Logger, ceremonyID string, sk *rsa.PrivateKey, initiator peer.ID, ceremony *Init) (*Deal, error) {
+	if err := ceremony.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid init")
+	}
+	pubKey, err := rsaencryption.ExtractPublicKey(sk)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not extract operator public key")
+	}
+	var self *Operator
+	for i := range ceremony.Operators {
+		if ceremony.Operators[i].PubKey == pubKey {
+			self = &ceremony.Operators[i]
+		}
+	}
+	if self == nil {
+		return nil, errors.New("operator is not a member of the ceremony")
+	}
+	if p.verifyOperator != nil {
+		for _, op := range ceremony.Operators {
+			if err := p.verifyOperator(logger, op); err != nil {
+				return nil, errors.Wrapf(err, "could not verify operator %d", op.ID)
+			}
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for id, s := range p.sessions {
+		if time.Since(s.created) > sessionTimeout {
+			delete(p.sessions, id)
+		}
+	}
+	if _, exists := p.sessions[ceremonyID]; exists {
+		return nil, errors.New("ceremony already started")
+	}
+	if len(p.sessions) >= maxSessions {
+		return nil, errors.New("too many concurrent ceremonies")
+	}
+
+	poly := newPolynomial(ceremony.threshold())
+	deal := &Deal{
+		OperatorID:  self.ID,
+		Commitments: poly.commitments(),
+		Shares:      make(map[spectypes.OperatorID][]byte, len(ceremony.Operators)),
+	}
+	for _, op := range ceremony.Operators {
+		share, err := poly.evaluate(op.ID)
+		if err != nil {
+			return nil, err
+		}
+		deal.Shares[op.ID], err = keyshares.EncryptShare(op.PubKey, share)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not encrypt share of operator %d", op.ID)
+		}
+	}
+	root, err := deal.root(ceremonyID)
+	if err != nil {
+		return nil, err
+	}
+	if deal.Signature, err = sign(sk, root); err != nil {
+		return nil, errors.Wrap(err, "could not sign deal")
+	}
+
+	p.sessions[ceremonyID] = &session{
+		ceremony: ceremony,
+		self:     *self,
+		poly:     poly,
+		created:  time.Now(),
+		peer:     initiator,
+	}
+	logger.Info("dealt dkg ceremony", zap.Uint64("operator_id", self.ID), zap.Int("operators", len(ceremony.Operators)))
+	return deal, nil
+}
+
+// takeSession removes the session of a ceremony if it's accepted by check, sessions which aren't accepted are kept
+func (p *Participant) takeSession(ceremonyID string, check func(s *session) error) (*session, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s, ok := p.sessions[ceremonyID]
+	if !ok {
+		return nil, errors.New("unknown ceremony")
+	}
+	if err := check(s); err != nil {
+		return nil, err
+	}
+	delete(p.sessions, ceremonyID)
+	return s, nil
+}
+
+// exchange completes a ceremony, combining the shares dealt to the operator into its share of the validator key
+func (p *Participant) exchange(logger *zap.Logger, ceremonyID string, sk *rsa.PrivateKey, s *session, exchange *Exchange) (*Result, error) {
+	ceremony := s.ceremony
+
+	deals, commitments, err := verifyDeals(ceremonyID, ceremony, exchange.Deals)
+	if err != nil {
+		return nil, err
+	}
+
+	// our own deal must be the one we dealt
+	ownShare, err := s.poly.evaluate(s.self.ID)
+	if err != nil {
+		return nil, err
+	}
+	ownCommitments := s.poly.commitments()
+	for i, commitment := range deals[ceremony.index(s.self.ID)].Commitments {
+		if !bytes.Equal(commitment, ownCommitments[i]) {
+			return nil, errors.New("own deal was altered")
+		}
+	}
+
+	share := &bls.SecretKey{}
+	for i, deal := range deals {
+		var dealt *bls.SecretKey
+		if deal.OperatorID == s.self.ID {
+			dealt = ownShare
+		} else {
+			decrypted, err := rsaencryption.DecodeKey(sk, deal.Shares[s.self.ID])
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not decrypt share of operator %d", deal.OperatorID)
+			}
+			dealt = &bls.SecretKey{}
+			if err := dealt.SetHexString(string(decrypted)); err != nil {
+				return nil, errors.Wrapf(err, "invalid share of operator %d", deal.OperatorID)
+			}
+		}
+		expected, err := sharePubKey(commitments[i], s.self.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !dealt.GetPublicKey().IsEqual(expected) {
+			return nil, errors.Errorf("share of operator %d doesn't match its commitments", deal.OperatorID)
+		}
+		if i == 0 {
+			*share = *dealt
+		} else {
+			share.Add(dealt)
+		}
+	}
+
+	validatorPK, sharePKs, err := aggregate(commitments, ceremony.Operators)
+	if err != nil {
+		return nil, err
+	}
+	if !share.GetPublicKey().IsEqual(sharePKs[s.self.ID]) {
+		return nil, errors.New("share doesn't match the aggregated commitments")
+	}
+
+	_, depositRoot, err := depositMessage(validatorPK.Serialize(), ceremony.WithdrawalCredentials, ceremony.ForkVersion)
+	if err != nil {
+		return nil, err
+	}
+	encryptedShare, err := keyshares.EncryptShare(s.self.PubKey, share)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encrypt share")
+	}
+	root, err := dealsRoot(ceremonyID, deals)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{
+		OperatorID:          s.self.ID,
+		ValidatorPubKey:     validatorPK.Serialize(),
+		SharePubKey:         share.GetPublicKey().Serialize(),
+		EncryptedShare:      encryptedShare,
+		DepositSignature:    share.SignByte(depositRoot[:]).Serialize(),
+		OwnerNonceSignature: share.SignByte(keyshares.OwnerNonceHash(ceremony.Owner, ceremony.Nonce)).Serialize(),
+		DealsRoot:           root,
+	}
+	resultRoot, err := result.root(ceremonyID)
+	if err != nil {
+		return nil, err
+	}
+	if result.Signature, err = sign(sk, resultRoot); err != nil {
+		return nil, errors.Wrap(err, "could not sign result")
+	}
+
+	logger.Info("completed dkg ceremony",
+		zap.Uint64("operator_id", s.self.ID),
+		zap.String("validator", validatorPK.SerializeToHexStr()),
+	)
+	return result, nil
+}
+
+// verifyDeals verifies that there is a single deal signed by each operator,
+// and returns the deals in the order of the operators with their commitments
+func verifyDeals(ceremonyID string, ceremony *Init, deals []*Deal) ([]*Deal, [][]bls.PublicKey, error) {
+	if len(deals) != len(ceremony.Operators) {
+		return nil, nil, errors.Errorf("expected %d deals, got %d", len(ceremony.Operators), len(deals))
+	}
+	ordered := make([]*Deal, len(ceremony.Operators))
+	commitments := make([][]bls.PublicKey, len(ceremony.Operators))
+	for _, deal := range deals {
+		if deal == nil {
+			return nil, nil, errors.New("missing deal")
+		}
+		i := ceremony.index(deal.OperatorID)
+		if i < 0 {
+			return nil, nil, errors.Errorf("deal of unknown operator %d", deal.OperatorID)
+		}
+		if ordered[i] != nil {
+			return nil, nil, errors.Errorf("duplicate deal of operator %d", deal.OperatorID)
+		}
+		root, err := deal.root(ceremonyID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := verify(ceremony.Operators[i], root, deal.Signature); err != nil {
+			return nil, nil, err
+		}
+		if len(deal.Shares) != len(ceremony.Operators) {
+			return nil, nil, errors.Errorf("deal of operator %d has %d shares", deal.OperatorID, len(deal.Shares))
+		}
+		for _, op := range ceremony.Operators {
+			if _, ok := deal.Shares[op.ID]; !ok {
+				return nil, nil, errors.Errorf("deal of operator %d has no share for operator %d", deal.OperatorID, op.ID)
+			}
+		}
+		commitments[i], err = parseCommitments(deal.Commitments, ceremony.threshold())
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid commitments of operator %d", deal.OperatorID)
+		}
+		ordered[i] = deal
+	}
+	return ordered, commitments, nil
+}
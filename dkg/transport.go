@@ -0,0 +1,57 @@
+package dkg
+
+import (
+	"context"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/network/streams"
+)
+
+// StreamTransport sends requests to operator nodes over the DKG stream protocol
+type StreamTransport struct {
+	logger *zap.Logger
+	ctrl   streams.StreamController
+	self   peer.ID
+	peers  map[spectypes.OperatorID]peer.ID
+}
+
+// NewStreamTransport creates a new StreamTransport,
+// addrs are the multiaddrs of the operator nodes, including their peer id (e.g. /ip4/1.2.3.4/tcp/13001/p2p/16Uiu2...)
+func NewStreamTransport(logger *zap.Logger, h host.Host, ctrl streams.StreamController, addrs map[spectypes.OperatorID]string) (*StreamTransport, error) {
+	t := &StreamTransport{
+		logger: logger,
+		ctrl:   ctrl,
+		self:   h.ID(),
+		peers:  make(map[spectypes.OperatorID]peer.ID, len(addrs)),
+	}
+	for id, addr := range addrs {
+		info, err := peer.AddrInfoFromString(addr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid address of operator %d", id)
+		}
+		h.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.PermanentAddrTTL)
+		t.peers[id] = info.ID
+	}
+	return t, nil
+}
+
+// PeerID returns the peer of the host the requests are sent from
+func (t *StreamTransport) PeerID() peer.ID {
+	return t.self
+}
+
+// Send sends a request to the node of the given operator, timeouts are handled by the stream controller
+func (t *StreamTransport) Send(_ context.Context, operatorID spectypes.OperatorID, req []byte) ([]byte, error) {
+	peerID, ok := t.peers[operatorID]
+	if !ok {
+		return nil, errors.Errorf("unknown address of operator %d", operatorID)
+	}
+	return t.ctrl.Request(t.logger.With(fields.PeerID(peerID)), peerID, ProtocolID, req)
+}
@@ -26,6 +26,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/protocol/v2/message"
 	"github.com/bloxapp/ssv/storage/basedb"
 )
 
@@ -196,6 +197,18 @@ func (km *ethKeyManagerSigner) signBeaconObject(obj ssz.HashRoot, domain phase0.
 			return nil, nil, fmt.Errorf("obj type is unknown: %T", obj)
 		}
 		return km.signer.SignRegistration(data, domain, pk)
+	case spectypes.DomainVoluntaryExit:
+		data, ok := obj.(*phase0.VoluntaryExit)
+		if !ok {
+			return nil, nil, errors.New("could not cast obj to VoluntaryExit")
+		}
+		return km.signer.SignVoluntaryExit(data, domain, pk)
+	case message.DomainBLSToExecutionChange:
+		data, ok := obj.(*capella.BLSToExecutionChange)
+		if !ok {
+			return nil, nil, errors.New("could not cast obj to BLSToExecutionChange")
+		}
+		return km.signer.SignBLSToExecutionChange(data, domain, pk)
 	default:
 		return nil, nil, errors.New("domain unknown")
 	}
@@ -254,7 +267,7 @@ func (km *ethKeyManagerSigner) AddShare(shareKey *bls.SecretKey) error {
 		return errors.Wrap(err, "could not check share existence")
 	}
 	if acc == nil {
-		if err := km.saveMinimalSlashingProtection(shareKey.GetPublicKey().Serialize()); err != nil {
+		if err := saveMinimalSlashingProtection(km.storage, shareKey.GetPublicKey().Serialize()); err != nil {
 			return errors.Wrap(err, "could not save minimal slashing protection")
 		}
 		if err := km.saveShare(shareKey); err != nil {
@@ -265,19 +278,21 @@ func (km *ethKeyManagerSigner) AddShare(shareKey *bls.SecretKey) error {
 	return nil
 }
 
-func (km *ethKeyManagerSigner) saveMinimalSlashingProtection(pk []byte) error {
-	currentSlot := km.storage.Network().EstimatedCurrentSlot()
-	currentEpoch := km.storage.Network().EstimatedEpochAtSlot(currentSlot)
+// saveMinimalSlashingProtection sets the highest attestation and proposal of a new share to the current epoch and slot,
+// so it never signs anything from before it was added.
+func saveMinimalSlashingProtection(storage Storage, pk []byte) error {
+	currentSlot := storage.BeaconNetwork().EstimatedCurrentSlot()
+	currentEpoch := storage.BeaconNetwork().EstimatedEpochAtSlot(currentSlot)
 	highestTarget := currentEpoch + minimalAttSlashingProtectionEpochDistance
 	highestSource := highestTarget - 1
 	highestProposal := currentSlot + minimalBlockSlashingProtectionSlotDistance
 
 	minAttData := minimalAttProtectionData(highestSource, highestTarget)
 
-	if err := km.storage.SaveHighestAttestation(pk, minAttData); err != nil {
+	if err := storage.SaveHighestAttestation(pk, minAttData); err != nil {
 		return errors.Wrapf(err, "could not save minimal highest attestation for %s", string(pk))
 	}
-	if err := km.storage.SaveHighestProposal(pk, highestProposal); err != nil {
+	if err := storage.SaveHighestProposal(pk, highestProposal); err != nil {
 		return errors.Wrapf(err, "could not save minimal highest proposal for %s", string(pk))
 	}
 	return nil
@@ -0,0 +1,476 @@
+package ekm
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	apiv1bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/eth2-key-manager/encryptor/keystorev4"
+	"github.com/bloxapp/eth2-key-manager/signer"
+	slashingprotection "github.com/bloxapp/eth2-key-manager/slashing_protection"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/google/uuid"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/protocol/v2/message"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// RemoteSignerOptions configures a remote signer which holds the share keys instead of the local db
+type RemoteSignerOptions struct {
+	URL       string        `yaml:"URL" env:"REMOTE_SIGNER_URL" env-description:"URL of a remote signer implementing the EIP-3030 signing root API and the keymanager API, share keys are kept in the local db when empty"`
+	AuthToken string        `yaml:"AuthToken" env:"REMOTE_SIGNER_AUTH_TOKEN" env-description:"Bearer token for the keymanager API of the remote signer"`
+	Timeout   time.Duration `yaml:"Timeout" env:"REMOTE_SIGNER_TIMEOUT" env-default:"10s" env-description:"Timeout of requests to the remote signer"`
+}
+
+const (
+	remoteSignPath      = "/api/v1/eth2/sign/"
+	remoteKeystoresPath = "/eth/v1/keystores"
+)
+
+// remoteKeyManager is a spectypes.KeyManager which signs with an EIP-3030 remote signer
+// and manages its keys with the keymanager API. Slashing protection is kept in the local db,
+// so the node refuses to request slashable signatures regardless of the remote signer's own protection.
+//
+// Requests carry only the signing root, as in EIP-3030, since SSV messages aren't beacon objects.
+// Signers which require typed requests, such as Web3Signer, reject them and aren't supported.
+type remoteKeyManager struct {
+	logger            *zap.Logger
+	client            *http.Client
+	url               string
+	authToken         string
+	storage           Storage
+	slashingProtector core.SlashingProtector
+	genesisRoot       phase0.Root
+	domain            spectypes.DomainType
+
+	// protectionLock serializes slashing protection checks and updates
+	protectionLock sync.Mutex
+}
+
+// NewRemoteKeyManager returns a new key manager which signs with the remote signer at the given options
+func NewRemoteKeyManager(logger *zap.Logger, db basedb.IDb, network networkconfig.NetworkConfig, opts RemoteSignerOptions) (spectypes.KeyManager, error) {
+	if opts.URL == "" {
+		return nil, errors.New("remote signer url is empty")
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	signerStore := NewSignerStorage(db, network.Beacon, logger)
+	return &remoteKeyManager{
+		logger:            logger.Named("RemoteKeyManager"),
+		client:            &http.Client{Timeout: opts.Timeout},
+		url:               strings.TrimSuffix(opts.URL, "/"),
+		authToken:         opts.AuthToken,
+		storage:           signerStore,
+		slashingProtector: slashingprotection.NewNormalProtection(signerStore),
+		genesisRoot:       network.GenesisValidatorsRoot(),
+		domain:            network.Domain,
+	}, nil
+}
+
+func (km *remoteKeyManager) SignBeaconObject(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType) (spectypes.Signature, [32]byte, error) {
+	switch domainType {
+	case spectypes.DomainAttester:
+		data, ok := obj.(*phase0.AttestationData)
+		if !ok {
+			return nil, [32]byte{}, errors.New("could not cast obj to AttestationData")
+		}
+		if err := km.protectAttestation(pk, data); err != nil {
+			return nil, [32]byte{}, err
+		}
+	case spectypes.DomainProposer:
+		slot, err := blockSlot(obj)
+		if err != nil {
+			return nil, [32]byte{}, err
+		}
+		if err := km.protectProposal(pk, slot); err != nil {
+			return nil, [32]byte{}, err
+		}
+	case spectypes.DomainAggregateAndProof,
+		spectypes.DomainSelectionProof,
+		spectypes.DomainRandao,
+		spectypes.DomainSyncCommittee,
+		spectypes.DomainSyncCommitteeSelectionProof,
+		spectypes.DomainContributionAndProof,
+		spectypes.DomainApplicationBuilder,
+		spectypes.DomainVoluntaryExit,
+		message.DomainBLSToExecutionChange:
+	default:
+		return nil, [32]byte{}, errors.New("domain unknown")
+	}
+
+	root, err := spectypes.ComputeETHSigningRoot(obj, domain)
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "could not compute signing root")
+	}
+	sig, err := km.sign(pk, root)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	return sig, root, nil
+}
+
+// protectAttestation refuses slashable attestations and raises the highest attestation before signing
+func (km *remoteKeyManager) protectAttestation(pk []byte, data *phase0.AttestationData) error {
+	km.protectionLock.Lock()
+	defer km.protectionLock.Unlock()
+
+	if !signer.IsValidFarFutureEpoch(km.storage.Network(), data.Target.Epoch) {
+		return errors.New("target epoch too far into the future")
+	}
+	if !signer.IsValidFarFutureEpoch(km.storage.Network(), data.Source.Epoch) {
+		return errors.New("source epoch too far into the future")
+	}
+	if err := km.IsAttestationSlashable(pk, data); err != nil {
+		return err
+	}
+	return km.slashingProtector.UpdateHighestAttestation(pk, data)
+}
+
+// protectProposal refuses slashable proposals and raises the highest proposal before signing
+func (km *remoteKeyManager) protectProposal(pk []byte, slot phase0.Slot) error {
+	km.protectionLock.Lock()
+	defer km.protectionLock.Unlock()
+
+	if !signer.IsValidFarFutureSlot(km.storage.Network(), slot) {
+		return errors.New("proposed block slot too far into the future")
+	}
+	if err := km.IsBeaconBlockSlashable(pk, slot); err != nil {
+		return err
+	}
+	return km.slashingProtector.UpdateHighestProposal(pk, slot)
+}
+
+func (km *remoteKeyManager) IsAttestationSlashable(pk []byte, data *phase0.AttestationData) error {
+	if val, err := km.slashingProtector.IsSlashableAttestation(pk, data); err != nil || val != nil {
+		if err != nil {
+			return err
+		}
+		return errors.Errorf("slashable attestation (%s), not signing", val.Status)
+	}
+	return nil
+}
+
+func (km *remoteKeyManager) IsBeaconBlockSlashable(pk []byte, slot phase0.Slot) error {
+	status, err := km.slashingProtector.IsSlashableProposal(pk, slot)
+	if err != nil {
+		return err
+	}
+	if status.Status != core.ValidProposal {
+		return errors.Errorf("slashable proposal (%s), not signing", status.Status)
+	}
+	return nil
+}
+
+func (km *remoteKeyManager) SignRoot(data spectypes.Root, sigType spectypes.SignatureType, pk []byte) (spectypes.Signature, error) {
+	root, err := spectypes.ComputeSigningRoot(data, spectypes.ComputeSignatureDomain(km.domain, sigType))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute signing root")
+	}
+	return km.sign(pk, root)
+}
+
+func (km *remoteKeyManager) AddShare(shareKey *bls.SecretKey) error {
+	pk := shareKey.GetPublicKey().Serialize()
+	keystore, password, err := encryptKeystore(shareKey)
+	if err != nil {
+		return errors.Wrap(err, "could not encrypt share")
+	}
+
+	interchange, err := km.prepareShare(pk)
+	if err != nil {
+		return err
+	}
+	req := struct {
+		Keystores          []string `json:"keystores"`
+		Passwords          []string `json:"passwords"`
+		SlashingProtection string   `json:"slashing_protection,omitempty"`
+	}{
+		Keystores:          []string{string(keystore)},
+		Passwords:          []string{password},
+		SlashingProtection: string(interchange),
+	}
+	status, err := km.keystoresRequest(http.MethodPost, req)
+	if err != nil {
+		return errors.Wrap(err, "could not import share")
+	}
+	switch status.Status {
+	case "imported", "duplicate":
+		km.logger.Debug("added share to remote signer", fields.PubKey(pk), zap.String("status", status.Status))
+		return nil
+	default:
+		return errors.Errorf("could not import share: %s %s", status.Status, status.Message)
+	}
+}
+
+// prepareShare saves the minimal slashing protection of a new share,
+// and returns the slashing protection of the share to import into the remote signer.
+func (km *remoteKeyManager) prepareShare(pk []byte) ([]byte, error) {
+	km.protectionLock.Lock()
+	defer km.protectionLock.Unlock()
+
+	_, found, err := km.storage.RetrieveHighestAttestation(pk)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not check share existence")
+	}
+	if !found {
+		if err := saveMinimalSlashingProtection(km.storage, pk); err != nil {
+			return nil, errors.Wrap(err, "could not save minimal slashing protection")
+		}
+	}
+
+	interchange, err := km.interchange(pk)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not export slashing protection")
+	}
+	return interchange, nil
+}
+
+func (km *remoteKeyManager) RemoveShare(pubKey string) error {
+	pkDecoded, err := hex.DecodeString(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not hex decode share public key")
+	}
+
+	req := struct {
+		PubKeys []string `json:"pubkeys"`
+	}{
+		PubKeys: []string{hexPrefixed(pkDecoded)},
+	}
+	status, err := km.keystoresRequest(http.MethodDelete, req)
+	if err != nil {
+		return errors.Wrap(err, "could not delete share")
+	}
+	switch status.Status {
+	case "deleted", "not_active", "not_found":
+	default:
+		return errors.Errorf("could not delete share: %s %s", status.Status, status.Message)
+	}
+
+	km.protectionLock.Lock()
+	defer km.protectionLock.Unlock()
+
+	if err := km.storage.RemoveHighestAttestation(pkDecoded); err != nil {
+		return errors.Wrap(err, "could not remove highest attestation")
+	}
+	if err := km.storage.RemoveHighestProposal(pkDecoded); err != nil {
+		return errors.Wrap(err, "could not remove highest proposal")
+	}
+	km.logger.Debug("removed share from remote signer", fields.PubKey(pkDecoded), zap.String("status", status.Status))
+	return nil
+}
+
+// sign requests a signature of the given root, and verifies it before returning
+func (km *remoteKeyManager) sign(pk []byte, root [32]byte) (spectypes.Signature, error) {
+	body, err := json.Marshal(struct {
+		SigningRoot string `json:"signingRoot"`
+	}{
+		SigningRoot: hexPrefixed(root[:]),
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, km.url+remoteSignPath+hexPrefixed(pk), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := km.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not request signature")
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read signature")
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, errors.New("remote signer does not have the key")
+	case http.StatusPreconditionFailed:
+		return nil, errors.New("remote signer refused to sign a slashable message")
+	case http.StatusBadRequest:
+		return nil, fmt.Errorf("remote signer rejected the signing root request, it must implement the EIP-3030 signing root API: %s", strings.TrimSpace(string(raw)))
+	default:
+		return nil, fmt.Errorf("remote signer responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+
+	// EIP-3030 signers may respond with either a JSON object or the plain signature
+	sigHex := strings.TrimSpace(string(raw))
+	if strings.HasPrefix(sigHex, "{") {
+		var jsonResp struct {
+			Signature string `json:"signature"`
+		}
+		if err := json.Unmarshal(raw, &jsonResp); err != nil {
+			return nil, errors.Wrap(err, "could not decode signature response")
+		}
+		sigHex = jsonResp.Signature
+	}
+	sig, err := decodeHex(sigHex, 96)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+
+	if err := verifySignature(sig, pk, root); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+type keystoreStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// keystoresRequest sends a request for a single key to the keymanager API and returns its status
+func (km *remoteKeyManager) keystoresRequest(method string, body interface{}) (*keystoreStatus, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(method, km.url+remoteKeystoresPath, bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if km.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+km.authToken)
+	}
+
+	resp, err := km.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<12))
+		return nil, fmt.Errorf("keymanager API responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	var statuses struct {
+		Data []keystoreStatus `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&statuses); err != nil {
+		return nil, errors.Wrap(err, "could not decode keymanager API response")
+	}
+	if len(statuses.Data) != 1 {
+		return nil, errors.Errorf("expected a single status, got %d", len(statuses.Data))
+	}
+	return &statuses.Data[0], nil
+}
+
+// interchange returns the slashing protection of the given public key as an EIP-3076 interchange
+func (km *remoteKeyManager) interchange(pk []byte) ([]byte, error) {
+	data := InterchangeData{
+		PubKey:             hexPrefixed(pk),
+		SignedBlocks:       []InterchangeSignedBlock{},
+		SignedAttestations: []InterchangeSignedAttestation{},
+	}
+	att, found, err := km.storage.RetrieveHighestAttestation(pk)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		data.SignedAttestations = append(data.SignedAttestations, InterchangeSignedAttestation{
+			SourceEpoch: att.Source.Epoch,
+			TargetEpoch: att.Target.Epoch,
+		})
+	}
+	slot, found, err := km.storage.RetrieveHighestProposal(pk)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		data.SignedBlocks = append(data.SignedBlocks, InterchangeSignedBlock{Slot: slot})
+	}
+	return json.Marshal(&Interchange{
+		Metadata: InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    hexPrefixed(km.genesisRoot[:]),
+		},
+		Data: []InterchangeData{data},
+	})
+}
+
+// encryptKeystore encrypts the given share key into an EIP-2335 keystore with a random password
+func encryptKeystore(shareKey *bls.SecretKey) ([]byte, string, error) {
+	passwordBytes := make([]byte, 32)
+	if _, err := rand.Read(passwordBytes); err != nil {
+		return nil, "", err
+	}
+	password := hex.EncodeToString(passwordBytes)
+
+	crypto, err := keystorev4.New(keystorev4.WithCipher("pbkdf2")).Encrypt(shareKey.Serialize(), password)
+	if err != nil {
+		return nil, "", err
+	}
+	keystore, err := json.Marshal(map[string]interface{}{
+		"crypto":  crypto,
+		"pubkey":  shareKey.GetPublicKey().SerializeToHexStr(),
+		"path":    "",
+		"uuid":    uuid.New().String(),
+		"version": 4,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return keystore, password, nil
+}
+
+func verifySignature(sig, pk []byte, root [32]byte) error {
+	blsSig := &bls.Sign{}
+	if err := blsSig.Deserialize(sig); err != nil {
+		return errors.Wrap(err, "could not deserialize signature")
+	}
+	blsPK := &bls.PublicKey{}
+	if err := blsPK.Deserialize(pk); err != nil {
+		return errors.Wrap(err, "could not deserialize public key")
+	}
+	if !blsSig.VerifyByte(blsPK, root[:]) {
+		return errors.New("remote signer returned an invalid signature")
+	}
+	return nil
+}
+
+// blockSlot returns the slot of the given beacon block or blinded beacon block
+func blockSlot(obj ssz.HashRoot) (phase0.Slot, error) {
+	switch v := obj.(type) {
+	case *phase0.BeaconBlock:
+		return v.Slot, nil
+	case *altair.BeaconBlock:
+		return v.Slot, nil
+	case *bellatrix.BeaconBlock:
+		return v.Slot, nil
+	case *capella.BeaconBlock:
+		return v.Slot, nil
+	case *apiv1bellatrix.BlindedBeaconBlock:
+		return v.Slot, nil
+	case *apiv1capella.BlindedBeaconBlock:
+		return v.Slot, nil
+	default:
+		return 0, fmt.Errorf("obj type is unknown: %T", obj)
+	}
+}
@@ -0,0 +1,213 @@
+package ekm
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/encryptor/keystorev4"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+// testRemoteSigner is a minimal EIP-3030 signer with a keymanager API
+type testRemoteSigner struct {
+	t    *testing.T
+	mu   sync.Mutex
+	keys map[string]*bls.SecretKey
+	// slashingProtection holds the last imported interchange
+	slashingProtection *Interchange
+	// corrupt makes the signer return signatures of the wrong root
+	corrupt bool
+	// typedOnly makes the signer reject requests without an object type, as Web3Signer does
+	typedOnly bool
+}
+
+func (s *testRemoteSigner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, remoteSignPath) && r.Method == http.MethodPost:
+		sk, ok := s.keys[strings.TrimPrefix(r.URL.Path, remoteSignPath)]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req struct {
+			Type        string `json:"type"`
+			SigningRoot string `json:"signingRoot"`
+		}
+		require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+		if s.typedOnly && req.Type == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("missing type"))
+			return
+		}
+		root, err := decodeHex(req.SigningRoot, 32)
+		require.NoError(s.t, err)
+		if s.corrupt {
+			root[0] ^= 0xff
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"signature": hexPrefixed(sk.SignByte(root).Serialize())})
+
+	case r.URL.Path == remoteKeystoresPath && r.Method == http.MethodPost:
+		require.Equal(s.t, "Bearer token", r.Header.Get("Authorization"))
+		var req struct {
+			Keystores          []string `json:"keystores"`
+			Passwords          []string `json:"passwords"`
+			SlashingProtection string   `json:"slashing_protection"`
+		}
+		require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+		require.Len(s.t, req.Keystores, 1)
+		var keystore struct {
+			Crypto map[string]interface{} `json:"crypto"`
+			PubKey string                 `json:"pubkey"`
+		}
+		require.NoError(s.t, json.Unmarshal([]byte(req.Keystores[0]), &keystore))
+		secret, err := keystorev4.New().Decrypt(keystore.Crypto, req.Passwords[0])
+		require.NoError(s.t, err)
+		sk := &bls.SecretKey{}
+		require.NoError(s.t, sk.Deserialize(secret))
+		require.Equal(s.t, keystore.PubKey, sk.GetPublicKey().SerializeToHexStr())
+
+		s.slashingProtection = &Interchange{}
+		require.NoError(s.t, json.Unmarshal([]byte(req.SlashingProtection), s.slashingProtection))
+
+		status := "imported"
+		if _, ok := s.keys["0x"+keystore.PubKey]; ok {
+			status = "duplicate"
+		}
+		s.keys["0x"+keystore.PubKey] = sk
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": []map[string]string{{"status": status}}})
+
+	case r.URL.Path == remoteKeystoresPath && r.Method == http.MethodDelete:
+		var req struct {
+			PubKeys []string `json:"pubkeys"`
+		}
+		require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+		status := "not_found"
+		if _, ok := s.keys[req.PubKeys[0]]; ok {
+			status = "deleted"
+			delete(s.keys, req.PubKeys[0])
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": []map[string]string{{"status": status}}})
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestRemoteKeyManager(t *testing.T) {
+	threshold.Init()
+	logger := logging.TestLogger(t)
+	network := networkconfig.TestNetwork
+
+	remote := &testRemoteSigner{t: t, keys: map[string]*bls.SecretKey{}}
+	server := httptest.NewServer(remote)
+	defer server.Close()
+
+	db, err := getBaseStorage(logger)
+	require.NoError(t, err)
+	defer db.Close(logger)
+
+	km, err := NewRemoteKeyManager(logger, db, network, RemoteSignerOptions{URL: server.URL, AuthToken: "token"})
+	require.NoError(t, err)
+
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	pk := sk.GetPublicKey().Serialize()
+	msg := &specqbft.Message{
+		MsgType:    specqbft.CommitMsgType,
+		Height:     specqbft.Height(3),
+		Round:      specqbft.Round(2),
+		Identifier: []byte("identifier1"),
+		Root:       [32]byte{1, 2, 3},
+	}
+
+	// signing fails before the share is added
+	_, err = km.SignRoot(msg, spectypes.QBFTSignatureType, pk)
+	require.ErrorContains(t, err, "does not have the key")
+
+	require.NoError(t, km.AddShare(sk))
+	require.NoError(t, km.AddShare(sk))
+	require.Len(t, remote.keys, 1)
+	currentEpoch := network.Beacon.EstimatedCurrentEpoch()
+	require.Len(t, remote.slashingProtection.Data, 1)
+	require.Equal(t, currentEpoch, remote.slashingProtection.Data[0].SignedAttestations[0].TargetEpoch)
+
+	t.Run("sign root", func(t *testing.T) {
+		sig, err := km.SignRoot(msg, spectypes.QBFTSignatureType, pk)
+		require.NoError(t, err)
+
+		root, err := spectypes.ComputeSigningRoot(msg, spectypes.ComputeSignatureDomain(network.Domain, spectypes.QBFTSignatureType))
+		require.NoError(t, err)
+		require.Equal(t, sk.SignByte(root[:]).Serialize(), []byte(sig))
+	})
+
+	t.Run("sign attestation", func(t *testing.T) {
+		attestation := &phase0.AttestationData{
+			Slot:   network.Beacon.GetEpochFirstSlot(currentEpoch + 1),
+			Source: &phase0.Checkpoint{Epoch: currentEpoch},
+			Target: &phase0.Checkpoint{Epoch: currentEpoch + 1},
+		}
+		domain := phase0.Domain{}
+		sig, root, err := km.SignBeaconObject(attestation, domain, pk, spectypes.DomainAttester)
+		require.NoError(t, err)
+		require.Equal(t, sk.SignByte(root[:]).Serialize(), []byte(sig))
+
+		// the same target epoch is now slashable
+		_, _, err = km.SignBeaconObject(attestation, domain, pk, spectypes.DomainAttester)
+		require.ErrorContains(t, err, "slashable attestation")
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		setCorrupt := func(corrupt bool) {
+			remote.mu.Lock()
+			defer remote.mu.Unlock()
+			remote.corrupt = corrupt
+		}
+		setCorrupt(true)
+		defer setCorrupt(false)
+
+		_, err := km.SignRoot(msg, spectypes.QBFTSignatureType, pk)
+		require.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("typed signer", func(t *testing.T) {
+		setTypedOnly := func(typedOnly bool) {
+			remote.mu.Lock()
+			defer remote.mu.Unlock()
+			remote.typedOnly = typedOnly
+		}
+		setTypedOnly(true)
+		defer setTypedOnly(false)
+
+		_, err := km.SignRoot(msg, spectypes.QBFTSignatureType, pk)
+		require.ErrorContains(t, err, "must implement the EIP-3030 signing root API")
+	})
+
+	t.Run("remove share", func(t *testing.T) {
+		require.NoError(t, km.RemoveShare(hex.EncodeToString(pk)))
+		require.Empty(t, remote.keys)
+
+		s := NewSignerStorage(db, network.Beacon, logger)
+		_, found, err := s.RetrieveHighestAttestation(pk)
+		require.NoError(t, err)
+		require.False(t, found)
+
+		// removing a missing share is not an error
+		require.NoError(t, km.RemoveShare(hex.EncodeToString(pk)))
+	})
+}
@@ -256,3 +256,26 @@ func TestSignRoot(t *testing.T) {
 		// require.True(t, res)
 	})
 }
+
+func TestSignVoluntaryExit(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+
+	exit := &phase0.VoluntaryExit{Epoch: 100, ValidatorIndex: 7}
+	domain := phase0.Domain{4, 0, 0, 0, 1}
+	sig, root, err := km.SignBeaconObject(exit, domain, sk1.GetPublicKey().Serialize(), spectypes.DomainVoluntaryExit)
+	require.NoError(t, err)
+
+	expectedRoot, err := spectypes.ComputeETHSigningRoot(exit, domain)
+	require.NoError(t, err)
+	require.EqualValues(t, expectedRoot, root)
+
+	blsSig := &bls.Sign{}
+	require.NoError(t, blsSig.Deserialize(sig))
+	require.True(t, blsSig.VerifyByte(sk1.GetPublicKey(), root[:]))
+
+	_, _, err = km.SignBeaconObject(&phase0.AttestationData{}, domain, sk1.GetPublicKey().Serialize(), spectypes.DomainVoluntaryExit)
+	require.EqualError(t, err, "could not cast obj to VoluntaryExit")
+}
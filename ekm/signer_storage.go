@@ -39,6 +39,13 @@ type Storage interface {
 
 	RemoveHighestAttestation(pubKey []byte) error
 	RemoveHighestProposal(pubKey []byte) error
+
+	GetAllHighestAttestations(handler func(pubKey []byte, attestation *phase0.AttestationData) error) error
+	GetAllHighestProposals(handler func(pubKey []byte, slot phase0.Slot) error) error
+
+	// BeaconNetwork returns the beacon network of the storage, including the parameters of custom networks
+	// which core.Network doesn't support.
+	BeaconNetwork() beacon.Network
 }
 
 type storage struct {
@@ -75,6 +82,11 @@ func (s *storage) Network() core.Network {
 	return core.Network(s.network.BeaconNetwork)
 }
 
+// BeaconNetwork returns the beacon network storage is related to.
+func (s *storage) BeaconNetwork() beacon.Network {
+	return s.network
+}
+
 // SaveWallet stores the given wallet.
 func (s *storage) SaveWallet(wallet core.Wallet) error {
 	s.lock.Lock()
@@ -250,6 +262,20 @@ func (s *storage) RemoveHighestAttestation(pubKey []byte) error {
 	return s.db.Delete(s.objPrefix(highestAttPrefix), pubKey)
 }
 
+// GetAllHighestAttestations calls the given handler with the highest attestation of each public key.
+func (s *storage) GetAllHighestAttestations(handler func(pubKey []byte, attestation *phase0.AttestationData) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.db.GetAll(s.logger, s.objPrefix(highestAttPrefix), func(i int, obj basedb.Obj) error {
+		attestation := &phase0.AttestationData{}
+		if err := attestation.UnmarshalSSZ(obj.Value); err != nil {
+			return errors.Wrap(err, "could not unmarshal attestation data")
+		}
+		return handler(obj.Key, attestation)
+	})
+}
+
 func (s *storage) SaveHighestProposal(pubKey []byte, slot phase0.Slot) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -299,3 +325,16 @@ func (s *storage) RemoveHighestProposal(pubKey []byte) error {
 
 	return s.db.Delete(s.objPrefix(highestProposalPrefix), pubKey)
 }
+
+// GetAllHighestProposals calls the given handler with the highest proposal slot of each public key.
+func (s *storage) GetAllHighestProposals(handler func(pubKey []byte, slot phase0.Slot) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.db.GetAll(s.logger, s.objPrefix(highestProposalPrefix), func(i int, obj basedb.Obj) error {
+		if len(obj.Value) != 8 {
+			return errors.New("invalid highest proposal value")
+		}
+		return handler(obj.Key, phase0.Slot(ssz.UnmarshallUint64(obj.Value)))
+	})
+}
@@ -0,0 +1,309 @@
+package ekm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+// InterchangeFormatVersion is the supported version of the EIP-3076 slashing protection interchange format
+const InterchangeFormatVersion = "5"
+
+// Interchange is an EIP-3076 slashing protection interchange document
+type Interchange struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []InterchangeData   `json:"data"`
+}
+
+// InterchangeMetadata is the metadata of an interchange document
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+// InterchangeData holds the signing history of a single public key
+type InterchangeData struct {
+	PubKey             string                         `json:"pubkey"`
+	SignedBlocks       []InterchangeSignedBlock       `json:"signed_blocks"`
+	SignedAttestations []InterchangeSignedAttestation `json:"signed_attestations"`
+}
+
+// InterchangeSignedBlock is a block proposal signed by a public key
+type InterchangeSignedBlock struct {
+	Slot        phase0.Slot `json:"slot,string"`
+	SigningRoot string      `json:"signing_root,omitempty"`
+}
+
+// InterchangeSignedAttestation is an attestation signed by a public key
+type InterchangeSignedAttestation struct {
+	SourceEpoch phase0.Epoch `json:"source_epoch,string"`
+	TargetEpoch phase0.Epoch `json:"target_epoch,string"`
+	SigningRoot string       `json:"signing_root,omitempty"`
+}
+
+// ShareKeys maps the public keys of the operator's shares, which the slashing protection history is kept by,
+// to the public keys of their validators, which interchange documents refer to.
+type ShareKeys struct {
+	validatorByShare map[string][]byte
+	shareByValidator map[string][]byte
+}
+
+// NewShareKeys maps the shares of the operator to their validators, shares of other operators are skipped
+func NewShareKeys(shares []*types.SSVShare) *ShareKeys {
+	keys := &ShareKeys{
+		validatorByShare: map[string][]byte{},
+		shareByValidator: map[string][]byte{},
+	}
+	for _, share := range shares {
+		if len(share.SharePubKey) == 0 {
+			continue
+		}
+		keys.validatorByShare[string(share.SharePubKey)] = share.ValidatorPubKey
+		keys.shareByValidator[string(share.ValidatorPubKey)] = share.SharePubKey
+	}
+	return keys
+}
+
+// ImportResult summarizes the changes made by an import
+type ImportResult struct {
+	// Keys is the amount of public keys in the interchange
+	Keys int
+	// Skipped is the amount of public keys in the interchange which aren't validators of the operator
+	Skipped int
+	// Attestations is the amount of public keys whose highest attestation was raised
+	Attestations int
+	// Proposals is the amount of public keys whose highest proposal was raised
+	Proposals int
+}
+
+// ExportSlashingProtection exports the highest attestation and proposal of every share in the storage
+// under the public key of its validator. Shares which aren't mapped by keys are skipped.
+// As only the highest watermarks are kept, each public key has at most one block and one attestation.
+func ExportSlashingProtection(logger *zap.Logger, s Storage, keys *ShareKeys, genesisValidatorsRoot phase0.Root) (*Interchange, error) {
+	byPubKey := map[string]*InterchangeData{}
+	entry := func(sharePubKey []byte) *InterchangeData {
+		pubKey, ok := keys.validatorByShare[string(sharePubKey)]
+		if !ok {
+			logger.Warn("skipping slashing protection of unknown share", zap.String("share_pubkey", hex.EncodeToString(sharePubKey)))
+			return nil
+		}
+		key := hexPrefixed(pubKey)
+		data, ok := byPubKey[key]
+		if !ok {
+			data = &InterchangeData{
+				PubKey:             key,
+				SignedBlocks:       []InterchangeSignedBlock{},
+				SignedAttestations: []InterchangeSignedAttestation{},
+			}
+			byPubKey[key] = data
+		}
+		return data
+	}
+
+	err := s.GetAllHighestAttestations(func(pubKey []byte, attestation *phase0.AttestationData) error {
+		if attestation.Source == nil || attestation.Target == nil {
+			return errors.Errorf("invalid highest attestation of %x", pubKey)
+		}
+		data := entry(pubKey)
+		if data == nil {
+			return nil
+		}
+		data.SignedAttestations = append(data.SignedAttestations, InterchangeSignedAttestation{
+			SourceEpoch: attestation.Source.Epoch,
+			TargetEpoch: attestation.Target.Epoch,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get highest attestations")
+	}
+
+	err = s.GetAllHighestProposals(func(pubKey []byte, slot phase0.Slot) error {
+		data := entry(pubKey)
+		if data == nil {
+			return nil
+		}
+		data.SignedBlocks = append(data.SignedBlocks, InterchangeSignedBlock{Slot: slot})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get highest proposals")
+	}
+
+	interchange := &Interchange{
+		Metadata: InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    hexPrefixed(genesisValidatorsRoot[:]),
+		},
+		Data: make([]InterchangeData, 0, len(byPubKey)),
+	}
+	for _, data := range byPubKey {
+		interchange.Data = append(interchange.Data, *data)
+	}
+	sort.Slice(interchange.Data, func(i, j int) bool {
+		return interchange.Data[i].PubKey < interchange.Data[j].PubKey
+	})
+	return interchange, nil
+}
+
+// watermark is the highest signed slot and epochs of a validator public key in an interchange
+type watermark struct {
+	pubKey      []byte
+	slot        phase0.Slot
+	hasBlocks   bool
+	sourceEpoch phase0.Epoch
+	targetEpoch phase0.Epoch
+	hasAtts     bool
+}
+
+// ImportSlashingProtection merges the given interchange into the storage, under the public keys of the shares
+// of its validators. Validators which aren't mapped by keys are skipped.
+// The merge is conservative: the highest attestation and proposal of a public key are only ever raised,
+// so the storage refuses to sign anything that either the interchange or the storage considers slashable.
+// When genesisValidatorsRoot is zero (unknown), the genesis validators root of the interchange is not verified.
+func ImportSlashingProtection(logger *zap.Logger, s Storage, keys *ShareKeys, interchange *Interchange, genesisValidatorsRoot phase0.Root) (*ImportResult, error) {
+	if interchange.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return nil, errors.Errorf("unsupported interchange format version %q", interchange.Metadata.InterchangeFormatVersion)
+	}
+	root, err := decodeHex(interchange.Metadata.GenesisValidatorsRoot, len(phase0.Root{}))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid genesis validators root")
+	}
+	if genesisValidatorsRoot != (phase0.Root{}) && !bytes.Equal(root, genesisValidatorsRoot[:]) {
+		return nil, errors.Errorf("genesis validators root mismatch: expected %#x, got %#x", genesisValidatorsRoot, root)
+	}
+
+	// validate the whole interchange before changing anything
+	watermarks := map[string]*watermark{}
+	var order []string
+	for _, data := range interchange.Data {
+		pubKey, err := decodeHex(data.PubKey, 48)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid public key %q", data.PubKey)
+		}
+		key := string(pubKey)
+		w, ok := watermarks[key]
+		if !ok {
+			w = &watermark{pubKey: pubKey}
+			watermarks[key] = w
+			order = append(order, key)
+		}
+		for _, block := range data.SignedBlocks {
+			if !w.hasBlocks || block.Slot > w.slot {
+				w.slot = block.Slot
+			}
+			w.hasBlocks = true
+		}
+		for _, att := range data.SignedAttestations {
+			if att.SourceEpoch > att.TargetEpoch {
+				return nil, errors.Errorf("invalid attestation of %s: source epoch %d is after target epoch %d", data.PubKey, att.SourceEpoch, att.TargetEpoch)
+			}
+			if !w.hasAtts || att.SourceEpoch > w.sourceEpoch {
+				w.sourceEpoch = att.SourceEpoch
+			}
+			if !w.hasAtts || att.TargetEpoch > w.targetEpoch {
+				w.targetEpoch = att.TargetEpoch
+			}
+			w.hasAtts = true
+		}
+	}
+
+	result := &ImportResult{Keys: len(order)}
+	for _, key := range order {
+		w := watermarks[key]
+		sharePubKey, ok := keys.shareByValidator[key]
+		if !ok {
+			logger.Debug("skipping slashing protection of a validator of other operators", fields.PubKey(w.pubKey))
+			result.Skipped++
+			continue
+		}
+		if w.hasAtts {
+			raised, err := mergeHighestAttestation(s, sharePubKey, w)
+			if err != nil {
+				return result, errors.Wrapf(err, "could not merge attestations of %x", w.pubKey)
+			}
+			if raised {
+				result.Attestations++
+			}
+		}
+		if w.hasBlocks {
+			raised, err := mergeHighestProposal(s, sharePubKey, w)
+			if err != nil {
+				return result, errors.Wrapf(err, "could not merge proposals of %x", w.pubKey)
+			}
+			if raised {
+				result.Proposals++
+			}
+		}
+		logger.Debug("imported slashing protection", fields.PubKey(w.pubKey), fields.Slot(w.slot),
+			zap.Uint64("source_epoch", uint64(w.sourceEpoch)), zap.Uint64("target_epoch", uint64(w.targetEpoch)))
+	}
+	return result, nil
+}
+
+func mergeHighestAttestation(s Storage, sharePubKey []byte, w *watermark) (bool, error) {
+	highest, found, err := s.RetrieveHighestAttestation(sharePubKey)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		highest = &phase0.AttestationData{
+			Source: &phase0.Checkpoint{Epoch: w.sourceEpoch},
+			Target: &phase0.Checkpoint{Epoch: w.targetEpoch},
+		}
+		return true, s.SaveHighestAttestation(sharePubKey, highest)
+	}
+
+	raised := false
+	if w.sourceEpoch > highest.Source.Epoch {
+		highest.Source.Epoch = w.sourceEpoch
+		raised = true
+	}
+	if w.targetEpoch > highest.Target.Epoch {
+		highest.Target.Epoch = w.targetEpoch
+		raised = true
+	}
+	if !raised {
+		return false, nil
+	}
+	return true, s.SaveHighestAttestation(sharePubKey, highest)
+}
+
+func mergeHighestProposal(s Storage, sharePubKey []byte, w *watermark) (bool, error) {
+	if w.slot == 0 {
+		// nothing can be proposed at slot 0, there is nothing to protect
+		return false, nil
+	}
+	highest, found, err := s.RetrieveHighestProposal(sharePubKey)
+	if err != nil {
+		return false, err
+	}
+	if found && highest >= w.slot {
+		return false, nil
+	}
+	return true, s.SaveHighestProposal(sharePubKey, w.slot)
+}
+
+func hexPrefixed(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+func decodeHex(s string, size int) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != size {
+		return nil, fmt.Errorf("expected %d bytes, got %d", size, len(b))
+	}
+	return b, nil
+}
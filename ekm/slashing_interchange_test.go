@@ -0,0 +1,177 @@
+package ekm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+func TestSlashingProtectionInterchange(t *testing.T) {
+	logger := logging.TestLogger(t)
+	genesisValidatorsRoot := networkconfig.TestNetwork.GenesisValidatorsRoot()
+	require.NotEqual(t, phase0.Root{}, genesisValidatorsRoot)
+
+	pk1 := _byteArray("a6ac72ad2e3b6a3da7a0e3db8d4b1b3f01ef1ff3b1b2bde2ad1f1e7d0dd1e0b1d4b8d3d0f4c1d2cc5e3d2c0cd4b1c2d1")
+	pk2 := _byteArray("b6ac72ad2e3b6a3da7a0e3db8d4b1b3f01ef1ff3b1b2bde2ad1f1e7d0dd1e0b1d4b8d3d0f4c1d2cc5e3d2c0cd4b1c2d1")
+	pk3 := _byteArray("c6ac72ad2e3b6a3da7a0e3db8d4b1b3f01ef1ff3b1b2bde2ad1f1e7d0dd1e0b1d4b8d3d0f4c1d2cc5e3d2c0cd4b1c2d1")
+
+	// the history is kept by share public key, interchange files refer to the validators' public keys
+	validatorKey := func(sharePubKey []byte) []byte {
+		pk := append([]byte{}, sharePubKey...)
+		pk[47] ^= 0xff
+		return pk
+	}
+	var shares []*types.SSVShare
+	for _, pk := range [][]byte{pk1, pk2, pk3} {
+		share := &types.SSVShare{}
+		share.SharePubKey = pk
+		share.ValidatorPubKey = validatorKey(pk)
+		shares = append(shares, share)
+	}
+	keys := NewShareKeys(shares)
+
+	src, done := newStorageForTest(t)
+	defer done()
+	require.NoError(t, src.SaveHighestAttestation(pk1, &phase0.AttestationData{
+		Source: &phase0.Checkpoint{Epoch: 10},
+		Target: &phase0.Checkpoint{Epoch: 11},
+	}))
+	require.NoError(t, src.SaveHighestProposal(pk1, 100))
+	require.NoError(t, src.SaveHighestAttestation(pk2, &phase0.AttestationData{
+		Source: &phase0.Checkpoint{Epoch: 20},
+		Target: &phase0.Checkpoint{Epoch: 21},
+	}))
+
+	// a share of another operator's registry isn't exported
+	require.NoError(t, src.SaveHighestProposal(_byteArray("d6ac72ad2e3b6a3da7a0e3db8d4b1b3f01ef1ff3b1b2bde2ad1f1e7d0dd1e0b1d4b8d3d0f4c1d2cc5e3d2c0cd4b1c2d1"), 1))
+
+	interchange, err := ExportSlashingProtection(logger, src, keys, genesisValidatorsRoot)
+	require.NoError(t, err)
+	require.Len(t, interchange.Data, 2)
+	require.Equal(t, hexPrefixed(validatorKey(pk1)), interchange.Data[0].PubKey)
+	require.Equal(t, hexPrefixed(validatorKey(pk2)), interchange.Data[1].PubKey)
+
+	// round trip through the JSON encoding, numbers are encoded as strings
+	raw, err := json.Marshal(interchange)
+	require.NoError(t, err)
+	require.Contains(t, string(raw), `"slot":"100"`)
+	require.Contains(t, string(raw), `"source_epoch":"10"`)
+	interchange = &Interchange{}
+	require.NoError(t, json.Unmarshal(raw, interchange))
+	// a validator of other operators isn't imported
+	interchange.Data = append(interchange.Data, InterchangeData{
+		PubKey:       "0xe6ac72ad2e3b6a3da7a0e3db8d4b1b3f01ef1ff3b1b2bde2ad1f1e7d0dd1e0b1d4b8d3d0f4c1d2cc5e3d2c0cd4b1c2d1",
+		SignedBlocks: []InterchangeSignedBlock{{Slot: 1000}},
+	})
+
+	dst, done := newStorageForTest(t)
+	defer done()
+	// the destination is ahead for pk2's source and behind for its target
+	require.NoError(t, dst.SaveHighestAttestation(pk2, &phase0.AttestationData{
+		Source: &phase0.Checkpoint{Epoch: 25},
+		Target: &phase0.Checkpoint{Epoch: 15},
+	}))
+	require.NoError(t, dst.SaveHighestProposal(pk2, 500))
+	require.NoError(t, dst.SaveHighestProposal(pk3, 50))
+
+	result, err := ImportSlashingProtection(logger, dst, keys, interchange, genesisValidatorsRoot)
+	require.NoError(t, err)
+	require.Equal(t, &ImportResult{Keys: 3, Skipped: 1, Attestations: 2, Proposals: 1}, result)
+
+	att, found, err := dst.RetrieveHighestAttestation(pk1)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, phase0.Epoch(10), att.Source.Epoch)
+	require.Equal(t, phase0.Epoch(11), att.Target.Epoch)
+	slot, found, err := dst.RetrieveHighestProposal(pk1)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, phase0.Slot(100), slot)
+
+	att, _, err = dst.RetrieveHighestAttestation(pk2)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Epoch(25), att.Source.Epoch)
+	require.Equal(t, phase0.Epoch(21), att.Target.Epoch)
+	slot, _, err = dst.RetrieveHighestProposal(pk2)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(500), slot)
+
+	slot, _, err = dst.RetrieveHighestProposal(pk3)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(50), slot)
+
+	// importing again changes nothing
+	result, err = ImportSlashingProtection(logger, dst, keys, interchange, genesisValidatorsRoot)
+	require.NoError(t, err)
+	require.Equal(t, &ImportResult{Keys: 3, Skipped: 1}, result)
+}
+
+func TestImportSlashingProtectionValidation(t *testing.T) {
+	logger := logging.TestLogger(t)
+	genesisValidatorsRoot := networkconfig.TestNetwork.GenesisValidatorsRoot()
+	pubKey := "0xa6ac72ad2e3b6a3da7a0e3db8d4b1b3f01ef1ff3b1b2bde2ad1f1e7d0dd1e0b1d4b8d3d0f4c1d2cc5e3d2c0cd4b1c2d1"
+	share := &types.SSVShare{}
+	share.SharePubKey = _byteArray(pubKey[2:])
+	share.ValidatorPubKey = _byteArray(pubKey[2:])
+	keys := NewShareKeys([]*types.SSVShare{share})
+
+	valid := func() *Interchange {
+		return &Interchange{
+			Metadata: InterchangeMetadata{
+				InterchangeFormatVersion: InterchangeFormatVersion,
+				GenesisValidatorsRoot:    hexPrefixed(genesisValidatorsRoot[:]),
+			},
+			Data: []InterchangeData{{
+				PubKey:             pubKey,
+				SignedBlocks:       []InterchangeSignedBlock{{Slot: 5}},
+				SignedAttestations: []InterchangeSignedAttestation{{SourceEpoch: 1, TargetEpoch: 2}},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*Interchange)
+		err    string
+	}{
+		{"format version", func(i *Interchange) { i.Metadata.InterchangeFormatVersion = "4" }, "format version"},
+		{"genesis validators root", func(i *Interchange) {
+			i.Metadata.GenesisValidatorsRoot = hexPrefixed(make([]byte, 32))
+		}, "genesis validators root mismatch"},
+		{"public key", func(i *Interchange) { i.Data[0].PubKey = "0x1234" }, "invalid public key"},
+		{"source after target", func(i *Interchange) {
+			i.Data[0].SignedAttestations[0].SourceEpoch = 3
+		}, "source epoch"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, done := newStorageForTest(t)
+			defer done()
+
+			interchange := valid()
+			test.mutate(interchange)
+			_, err := ImportSlashingProtection(logger, s, keys, interchange, genesisValidatorsRoot)
+			require.ErrorContains(t, err, test.err)
+
+			_, found, err := s.RetrieveHighestProposal(_byteArray(pubKey[2:]))
+			require.NoError(t, err)
+			require.False(t, found)
+		})
+	}
+
+	t.Run("unknown genesis validators root", func(t *testing.T) {
+		s, done := newStorageForTest(t)
+		defer done()
+
+		interchange := valid()
+		interchange.Metadata.GenesisValidatorsRoot = hexPrefixed(make([]byte, 32))
+		_, err := ImportSlashingProtection(logger, s, keys, interchange, phase0.Root{})
+		require.NoError(t, err)
+	})
+}
@@ -81,6 +81,32 @@ func TestParseValidatorAddedEvent(t *testing.T) {
 	})
 }
 
+func TestParseValidatorExitedEvent(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(ContractABI(V1)))
+	require.NoError(t, err)
+
+	ev := contractAbi.Events[abiparser.ValidatorExited]
+	owner := common.HexToAddress("0x77fc6e8b24a623725d935bc88057098d0bca6eb3")
+	pubKey, err := hex.DecodeString("b24454393691331ee6eba4ffa2dbb2600b9859f908c3e648b6c6de9e1dea3e9329866015d08355c8d451427762b913d1")
+	require.NoError(t, err)
+	data, err := ev.Inputs.NonIndexed().Pack([]uint64{1, 2, 3, 4}, pubKey)
+	require.NoError(t, err)
+
+	abiParser := NewParser(logging.TestLogger(t), V1)
+	parsed, err := abiParser.ParseValidatorExitedEvent(types.Log{
+		Topics: []common.Hash{ev.ID, common.BytesToHash(owner.Bytes())},
+		Data:   data,
+	}, contractAbi)
+	require.NoError(t, err)
+	require.Equal(t, owner, parsed.Owner)
+	require.Equal(t, []uint64{1, 2, 3, 4}, parsed.OperatorIds)
+	require.Equal(t, pubKey, parsed.PublicKey)
+
+	_, err = abiParser.ParseValidatorExitedEvent(types.Log{Topics: []common.Hash{ev.ID}, Data: data}, contractAbi)
+	var malformedEventErr *abiparser.MalformedEventError
+	require.True(t, errors.As(err, &malformedEventErr))
+}
+
 func unmarshalLog(t *testing.T, rawOperatorAdded string, abiVersion Version) (*types.Log, abi.ABI) {
 	var vLogOperatorAdded types.Log
 	err := json.Unmarshal([]byte(rawOperatorAdded), &vLogOperatorAdded)
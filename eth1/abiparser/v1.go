@@ -25,6 +25,7 @@ const (
 	OperatorRemoved            = "OperatorRemoved"
 	ValidatorAdded             = "ValidatorAdded"
 	ValidatorRemoved           = "ValidatorRemoved"
+	ValidatorExited            = "ValidatorExited"
 	ClusterLiquidated          = "ClusterLiquidated"
 	ClusterReactivated         = "ClusterReactivated"
 	FeeRecipientAddressUpdated = "FeeRecipientAddressUpdated"
@@ -64,6 +65,15 @@ type ValidatorRemovedEvent struct {
 	Cluster     Cluster
 }
 
+// ValidatorExitedEvent struct represents event received by the smart contract
+type ValidatorExitedEvent struct {
+	Owner       common.Address // indexed
+	OperatorIds []uint64
+	PublicKey   []byte
+	// BlockTimestamp is the timestamp of the block of the event, which determines the slot of the exit duty
+	BlockTimestamp uint64
+}
+
 // ClusterLiquidatedEvent struct represents event received by the smart contract
 type ClusterLiquidatedEvent struct {
 	Owner       common.Address // indexed
@@ -177,6 +187,26 @@ func (v1 *AbiV1) ParseValidatorRemovedEvent(log types.Log, contractAbi abi.ABI)
 	return &event, nil
 }
 
+// ParseValidatorExitedEvent parses ValidatorExitedEvent
+func (v1 *AbiV1) ParseValidatorExitedEvent(log types.Log, contractAbi abi.ABI) (*ValidatorExitedEvent, error) {
+	var event ValidatorExitedEvent
+	err := contractAbi.UnpackIntoInterface(&event, ValidatorExited, log.Data)
+	if err != nil {
+		return nil, &MalformedEventError{
+			Err: errors.Wrap(err, "could not unpack event"),
+		}
+	}
+
+	if len(log.Topics) < 2 {
+		return nil, &MalformedEventError{
+			Err: errors.Errorf("%s event missing topics", ValidatorExited),
+		}
+	}
+	event.Owner = common.HexToAddress(log.Topics[1].Hex())
+
+	return &event, nil
+}
+
 // ParseClusterLiquidatedEvent parses ClusterLiquidatedEvent
 func (v1 *AbiV1) ParseClusterLiquidatedEvent(log types.Log, contractAbi abi.ABI) (*ClusterLiquidatedEvent, error) {
 	var event ClusterLiquidatedEvent
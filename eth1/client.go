@@ -30,6 +30,13 @@ type SyncEndedEvent struct {
 	Block uint64
 }
 
+// ConfirmedBlockEvent meant to notify an observer that the events up to a block, which is buried under
+// the follow distance, were sent
+type ConfirmedBlockEvent struct {
+	// Block is the block number of the confirmed block
+	Block uint64
+}
+
 // Client represents the required interface for eth1 client
 type Client interface {
 	EventsFeed() *event.Feed
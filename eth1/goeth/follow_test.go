@@ -0,0 +1,186 @@
+package goeth
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/logging"
+)
+
+// testChain is an execution client serving a chain whose blocks can be reorged
+type testChain struct {
+	mu   sync.Mutex
+	head uint64
+	// forks changes the hash of a block when reorged
+	forks map[uint64]byte
+	logs  []types.Log
+	err   error
+}
+
+func (c *testChain) header(number uint64) *types.Header {
+	return &types.Header{Number: new(big.Int).SetUint64(number), Extra: []byte{c.forks[number]}}
+}
+
+func (c *testChain) BlockNumber(context.Context) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.head, c.err
+}
+
+func (c *testChain) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.header(number.Uint64()), c.err
+}
+
+func (c *testChain) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	var logs []types.Log
+	for _, vLog := range c.logs {
+		if vLog.BlockNumber >= q.FromBlock.Uint64() && vLog.BlockNumber <= q.ToBlock.Uint64() {
+			vLog.BlockHash = c.header(vLog.BlockNumber).Hash()
+			logs = append(logs, vLog)
+		}
+	}
+	return logs, nil
+}
+
+func (c *testChain) SyncProgress(context.Context) (*ethereum.SyncProgress, error) {
+	return nil, c.err
+}
+
+func (c *testChain) Close() {}
+
+func (c *testChain) reorg(from uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for number := from; number <= c.head; number++ {
+		c.forks[number]++
+	}
+}
+
+func newTestFollower(t *testing.T, followDistance uint64, chains ...*testChain) (*eth1Client, chan *eth1.Event) {
+	ec := newEth1Client(eth1.V1)
+	ec.followDistance = followDistance
+	ec.endpoints = nil
+	for i, chain := range chains {
+		ec.endpoints = append(ec.endpoints, &endpoint{addr: string(rune('a' + i)), conn: chain})
+	}
+	ec.dial = func(context.Context, string) (executionClient, error) {
+		return nil, errors.New("can't dial")
+	}
+
+	events := make(chan *eth1.Event, 64)
+	sub := ec.EventsFeed().Subscribe(events)
+	t.Cleanup(sub.Unsubscribe)
+	return ec, events
+}
+
+func testOperatorAddedLog(t *testing.T, block uint64) types.Log {
+	var vLog types.Log
+	require.NoError(t, json.Unmarshal([]byte(rawOperatorAdded), &vLog))
+	vLog.BlockNumber = block
+	return vLog
+}
+
+func drain(events chan *eth1.Event) []*eth1.Event {
+	var result []*eth1.Event
+	for {
+		select {
+		case e := <-events:
+			result = append(result, e)
+		default:
+			return result
+		}
+	}
+}
+
+func TestFollowConfirmedBlocks(t *testing.T) {
+	logger := logging.TestLogger(t)
+	contractAbi, err := abi.JSON(strings.NewReader(eth1.ContractABI(eth1.V1)))
+	require.NoError(t, err)
+
+	chain := &testChain{head: 100, forks: map[uint64]byte{}}
+	chain.logs = []types.Log{testOperatorAddedLog(t, 95), testOperatorAddedLog(t, 98)}
+	ec, events := newTestFollower(t, 4, chain)
+	ec.lastBlock = 90
+
+	// only the block buried under the follow distance is processed
+	require.NoError(t, ec.followConfirmedBlocks(logger, contractAbi))
+	received := drain(events)
+	require.Len(t, received, 2)
+	require.Equal(t, uint64(95), received[0].Log.BlockNumber)
+	require.Equal(t, eth1.ConfirmedBlockEvent{Block: 96}, received[1].Data)
+
+	chain.head = 102
+	require.NoError(t, ec.followConfirmedBlocks(logger, contractAbi))
+	received = drain(events)
+	require.Len(t, received, 2)
+	require.Equal(t, uint64(98), received[0].Log.BlockNumber)
+	require.Equal(t, eth1.ConfirmedBlockEvent{Block: 98}, received[1].Data)
+
+	t.Run("reorg deeper than the follow distance", func(t *testing.T) {
+		chain.reorg(97)
+		chain.logs = []types.Log{chain.logs[0], testOperatorAddedLog(t, 97), chain.logs[1]}
+
+		require.NoError(t, ec.followConfirmedBlocks(logger, contractAbi))
+		received := drain(events)
+		require.Len(t, received, 3)
+		// processing was rewound to block 96, which is still canonical
+		require.Equal(t, uint64(97), received[0].Log.BlockNumber)
+		require.Equal(t, uint64(98), received[1].Log.BlockNumber)
+		require.Equal(t, eth1.ConfirmedBlockEvent{Block: 98}, received[2].Data)
+	})
+}
+
+func TestFollowFailover(t *testing.T) {
+	logger := logging.TestLogger(t)
+	contractAbi, err := abi.JSON(strings.NewReader(eth1.ContractABI(eth1.V1)))
+	require.NoError(t, err)
+
+	primary := &testChain{head: 100, forks: map[uint64]byte{}, err: errors.New("connection refused")}
+	secondary := &testChain{head: 100, forks: map[uint64]byte{}}
+	secondary.logs = []types.Log{testOperatorAddedLog(t, 92)}
+	ec, events := newTestFollower(t, 0, primary, secondary)
+	ec.lastBlock = 90
+
+	require.NoError(t, ec.followConfirmedBlocks(logger, contractAbi))
+	require.Len(t, drain(events), 2)
+	require.Equal(t, 1, ec.active)
+	// the failed connection is dropped and redialed once needed
+	require.Nil(t, ec.endpoints[0].conn)
+
+	secondary.err = errors.New("connection refused")
+	require.ErrorContains(t, ec.followConfirmedBlocks(logger, contractAbi), "failed to get")
+}
+
+func TestSyncSmartContractsEventsFollowDistance(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	chain := &testChain{head: 100, forks: map[uint64]byte{}}
+	chain.logs = []types.Log{testOperatorAddedLog(t, 50), testOperatorAddedLog(t, 99)}
+	ec, events := newTestFollower(t, 8, chain)
+	ec.contractABI = eth1.ContractABI(eth1.V1)
+
+	require.NoError(t, ec.Sync(logger, big.NewInt(10)))
+	received := drain(events)
+	require.Len(t, received, 2)
+	require.Equal(t, uint64(50), received[0].Log.BlockNumber)
+	require.Equal(t, eth1.SyncEndedEvent{Block: 92, Success: true}, received[1].Data)
+	require.Equal(t, uint64(92), ec.lastBlock)
+}
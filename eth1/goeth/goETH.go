@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bloxapp/ssv/eth1"
@@ -12,7 +13,6 @@ import (
 	"github.com/bloxapp/ssv/logging"
 	"github.com/bloxapp/ssv/logging/fields"
 	"github.com/bloxapp/ssv/monitoring/metrics"
-	"github.com/bloxapp/ssv/utils/tasks"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -27,28 +27,68 @@ import (
 const (
 	healthCheckTimeout        = 500 * time.Millisecond
 	blocksInBatch      uint64 = 5000
+	// followInterval is the interval of polling the execution client for new confirmed blocks
+	followInterval = 6 * time.Second
+	// followFailureLimit is how long following may fail before giving up, as the execution client is required
+	followFailureLimit = 64 * time.Second
+	// maxTrackedBlocks is the amount of recent blocks whose hashes are kept and verified for reorg detection
+	maxTrackedBlocks = 256
 )
 
 // ClientOptions are the options for the client
 type ClientOptions struct {
-	Ctx                  context.Context
+	Ctx context.Context
+	// NodeAddr is the address of the execution client, multiple addresses are separated by ';'
 	NodeAddr             string
 	RegistryContractAddr string
 	ContractABI          string
 	ConnectionTimeout    time.Duration
+	// FollowDistance is the amount of blocks a block must be buried under before its events are processed
+	FollowDistance uint64
 
 	AbiVersion eth1.Version
 }
 
+// executionClient is the subset of ethclient.Client used by eth1Client
+type executionClient interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error)
+	Close()
+}
+
+// endpoint is a single execution client address and its connection, if connected
+type endpoint struct {
+	addr string
+	conn executionClient
+}
+
+// blockRef is a processed block, kept to detect reorgs
+type blockRef struct {
+	number uint64
+	hash   common.Hash
+}
+
 // eth1Client is the internal implementation of Client
 type eth1Client struct {
 	ctx  context.Context
-	conn *ethclient.Client
+	dial func(ctx context.Context, addr string) (executionClient, error)
+
+	mu        sync.Mutex
+	endpoints []*endpoint
+	active    int
 
-	nodeAddr             string
 	registryContractAddr string
 	contractABI          string
 	connectionTimeout    time.Duration
+	followDistance       uint64
+	followInterval       time.Duration
+
+	// lastBlock is the last confirmed block whose events were processed
+	lastBlock uint64
+	// processed holds the most recent processed blocks in ascending order
+	processed []blockRef
 
 	eventsFeed *event.Feed
 
@@ -61,14 +101,26 @@ var _ metrics.HealthCheckAgent = &eth1Client{}
 // NewEth1Client creates a new instance
 func NewEth1Client(logger *zap.Logger, opts ClientOptions) (eth1.Client, error) {
 	ec := eth1Client{
-		ctx:                  opts.Ctx,
-		nodeAddr:             opts.NodeAddr,
+		ctx: opts.Ctx,
+		dial: func(ctx context.Context, addr string) (executionClient, error) {
+			return ethclient.DialContext(ctx, addr)
+		},
 		registryContractAddr: opts.RegistryContractAddr,
 		contractABI:          opts.ContractABI,
 		connectionTimeout:    opts.ConnectionTimeout,
+		followDistance:       opts.FollowDistance,
+		followInterval:       followInterval,
 		eventsFeed:           new(event.Feed),
 		abiVersion:           opts.AbiVersion,
 	}
+	for _, addr := range strings.Split(opts.NodeAddr, ";") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			ec.endpoints = append(ec.endpoints, &endpoint{addr: addr})
+		}
+	}
+	if len(ec.endpoints) == 0 {
+		return nil, errors.New("no execution client address")
+	}
 
 	if err := ec.connect(logger); err != nil {
 		logger.Error("failed to connect to the execution client", zap.Error(err))
@@ -83,7 +135,7 @@ func (ec *eth1Client) EventsFeed() *event.Feed {
 	return ec.eventsFeed
 }
 
-// Start streams events from the contract
+// Start follows the events of the contract from the last synced block
 func (ec *eth1Client) Start(logger *zap.Logger) error {
 	logger = logger.Named(logging.NameEthClient)
 	err := ec.streamSmartContractEvents(logger)
@@ -104,7 +156,11 @@ func (ec *eth1Client) Sync(logger *zap.Logger, fromBlock *big.Int) error {
 
 // IsReady returns if eth1 is currently ready: responds to requests and not in the syncing state.
 func (ec *eth1Client) IsReady(ctx context.Context) (bool, error) {
-	sp, err := ec.conn.SyncProgress(ctx)
+	var sp *ethereum.SyncProgress
+	err := ec.withConn(zap.NewNop(), func(conn executionClient) (err error) {
+		sp, err = conn.SyncProgress(ctx)
+		return err
+	})
 	if err != nil {
 		reportNodeStatus(statusUnknown)
 		return false, err
@@ -121,12 +177,15 @@ func (ec *eth1Client) IsReady(ctx context.Context) (bool, error) {
 
 // HealthCheck provides health status of eth1 node
 func (ec *eth1Client) HealthCheck() []string {
-	if ec.conn == nil {
+	ec.mu.Lock()
+	conn := ec.endpoints[ec.active].conn
+	ec.mu.Unlock()
+	if conn == nil {
 		return []string{"not connected to eth1 node"}
 	}
 	ctx, cancel := context.WithTimeout(ec.ctx, healthCheckTimeout)
 	defer cancel()
-	sp, err := ec.conn.SyncProgress(ctx)
+	sp, err := conn.SyncProgress(ctx)
 	if err != nil {
 		reportNodeStatus(statusUnknown)
 		return []string{"could not get eth1 node sync progress"}
@@ -142,42 +201,74 @@ func (ec *eth1Client) HealthCheck() []string {
 	return []string{}
 }
 
-// connect connects to eth1 client
+// connect connects to the first reachable execution client
 func (ec *eth1Client) connect(logger *zap.Logger) error {
+	var err error
+	for i := range ec.endpoints {
+		if _, err = ec.endpointConn(logger, i); err == nil {
+			ec.mu.Lock()
+			ec.active = i
+			ec.mu.Unlock()
+			return nil
+		}
+	}
+	return err
+}
+
+// endpointConn returns the connection of the given endpoint, connecting to it if needed
+func (ec *eth1Client) endpointConn(logger *zap.Logger, i int) (executionClient, error) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	e := ec.endpoints[i]
+	if e.conn != nil {
+		return e.conn, nil
+	}
 	// Create an IPC based RPC connection to a remote node
-	logger.Info("execution client: connecting", fields.Address(ec.nodeAddr))
+	logger.Info("execution client: connecting", fields.Address(e.addr))
 	ctx, cancel := context.WithTimeout(context.Background(), ec.connectionTimeout)
 	defer cancel()
-	conn, err := ethclient.DialContext(ctx, ec.nodeAddr)
+	conn, err := ec.dial(ctx, e.addr)
 	if err != nil {
-		logger.Error("execution client: can't connect", zap.Error(err))
-		return err
+		logger.Error("execution client: can't connect", fields.Address(e.addr), zap.Error(err))
+		return nil, err
 	}
-	logger.Info("execution client: connected")
-	ec.conn = conn
-	return nil
+	logger.Info("execution client: connected", fields.Address(e.addr))
+	e.conn = conn
+	return conn, nil
 }
 
-// reconnect tries to reconnect multiple times with an exponent interval
-func (ec *eth1Client) reconnect(logger *zap.Logger) {
-	limit := 64 * time.Second
-	tasks.ExecWithInterval(func(lastTick time.Duration) (stop bool, cont bool) {
-		logger.Info("reconnecting")
-		if err := ec.connect(logger); err != nil {
-			// continue until reaching to limit, and then panic as eth1 connection is required
-			if lastTick >= limit {
-				logger.Panic("failed to reconnect", zap.Error(err))
-			} else {
-				logger.Warn("could not reconnect, still trying", zap.Error(err))
+// withConn calls f with the active execution client, failing over to the other clients on errors.
+// The connection of a failed client is dropped, so it's reconnected the next time it's used.
+func (ec *eth1Client) withConn(logger *zap.Logger, f func(conn executionClient) error) error {
+	ec.mu.Lock()
+	active := ec.active
+	ec.mu.Unlock()
+
+	var err error
+	for i := 0; i < len(ec.endpoints); i++ {
+		idx := (active + i) % len(ec.endpoints)
+		var conn executionClient
+		if conn, err = ec.endpointConn(logger, idx); err != nil {
+			continue
+		}
+		if err = f(conn); err == nil {
+			if idx != active {
+				ec.mu.Lock()
+				ec.active = idx
+				ec.mu.Unlock()
+				logger.Warn("execution client: failed over", fields.Address(ec.endpoints[idx].addr))
 			}
-			return false, false
+			return nil
+		}
+		logger.Warn("execution client: request failed", fields.Address(ec.endpoints[idx].addr), zap.Error(err))
+		ec.mu.Lock()
+		if ec.endpoints[idx].conn == conn {
+			ec.endpoints[idx].conn = nil
+			conn.Close()
 		}
-		return true, false
-	}, 1*time.Second, limit+(1*time.Second))
-	logger.Debug("managed to reconnect")
-	if err := ec.streamSmartContractEvents(logger); err != nil {
-		logger.Panic("failed to stream events after reconnection", zap.Error(err))
+		ec.mu.Unlock()
 	}
+	return err
 }
 
 // fireEvent notifies observers about some contract event
@@ -188,75 +279,165 @@ func (ec *eth1Client) fireEvent(log types.Log, name string, data interface{}) {
 	// logger.Debug("events was sent to subscribers", zap.Int("num of subscribers", n))
 }
 
-// streamSmartContractEvents sync events history of the given contract
-func (ec *eth1Client) streamSmartContractEvents(logger *zap.Logger) error {
-	currentBlock, err := ec.conn.BlockNumber(ec.ctx)
+// confirmedBlock returns the highest block which is buried under the follow distance
+func (ec *eth1Client) confirmedBlock(logger *zap.Logger) (uint64, error) {
+	var head uint64
+	err := ec.withConn(logger, func(conn executionClient) (err error) {
+		head, err = conn.BlockNumber(ec.ctx)
+		return err
+	})
 	if err != nil {
-		return errors.Wrap(err, "failed to get current block")
+		return 0, errors.Wrap(err, "failed to get current block")
 	}
-	logger.Debug("streaming smart contract events",
-		zap.Uint64("current_block", currentBlock))
+	if head < ec.followDistance {
+		return 0, nil
+	}
+	return head - ec.followDistance, nil
+}
 
-	contractAbi, err := abi.JSON(strings.NewReader(ec.contractABI))
+func (ec *eth1Client) blockHash(logger *zap.Logger, number uint64) (common.Hash, error) {
+	header, err := ec.blockHeader(logger, number)
 	if err != nil {
-		return errors.Wrap(err, "failed to parse ABI interface")
+		return common.Hash{}, err
 	}
+	return header.Hash(), nil
+}
 
-	sub, logs, err := ec.subscribeToLogs(logger)
+func (ec *eth1Client) blockHeader(logger *zap.Logger, number uint64) (*types.Header, error) {
+	var header *types.Header
+	err := ec.withConn(logger, func(conn executionClient) (err error) {
+		header, err = conn.HeaderByNumber(ec.ctx, new(big.Int).SetUint64(number))
+		return err
+	})
 	if err != nil {
-		return errors.Wrap(err, "Failed to subscribe to logs")
+		return nil, errors.Wrapf(err, "failed to get header of block %d", number)
 	}
+	return header, nil
+}
 
-	go func() {
-		if err := ec.listenToSubscription(logger, logs, sub, contractAbi); err != nil {
-			ec.reconnect(logger)
-		}
-	}()
+// trackBlock keeps the hash of a processed block for reorg detection
+func (ec *eth1Client) trackBlock(number uint64, hash common.Hash) {
+	ec.processed = append(ec.processed, blockRef{number: number, hash: hash})
+	if len(ec.processed) > maxTrackedBlocks {
+		ec.processed = ec.processed[len(ec.processed)-maxTrackedBlocks:]
+	}
+}
+
+// checkReorg verifies that the processed blocks are still canonical.
+// A reorg deeper than the follow distance rewinds processing to the last processed block which is still canonical,
+// so the events of the new canonical blocks are processed. Events of the removed blocks were already handled
+// and can't be reverted, which is why the follow distance must be deep enough.
+func (ec *eth1Client) checkReorg(logger *zap.Logger) error {
+	if len(ec.processed) == 0 {
+		return nil
+	}
+	last := ec.processed[len(ec.processed)-1]
+	hash, err := ec.blockHash(logger, last.number)
+	if err != nil {
+		return err
+	}
+	if hash == last.hash {
+		return nil
+	}
 
+	reportReorg()
+	rewindTo := ec.processed[0].number - 1
+	for len(ec.processed) > 0 {
+		ref := ec.processed[len(ec.processed)-1]
+		if hash, err = ec.blockHash(logger, ref.number); err != nil {
+			return err
+		}
+		if hash == ref.hash {
+			rewindTo = ref.number
+			break
+		}
+		ec.processed = ec.processed[:len(ec.processed)-1]
+	}
+	logger.Error("execution chain reorged deeper than the follow distance, events of removed blocks were already handled",
+		zap.Uint64("last_block", ec.lastBlock),
+		zap.Uint64("rewind_to_block", rewindTo),
+		zap.Uint64("follow_distance", ec.followDistance))
+	ec.lastBlock = rewindTo
 	return nil
 }
 
-func (ec *eth1Client) subscribeToLogs(logger *zap.Logger) (ethereum.Subscription, chan types.Log, error) {
-	contractAddress := common.HexToAddress(ec.registryContractAddr)
-	query := ethereum.FilterQuery{
-		Addresses: []common.Address{contractAddress},
-	}
-	logs := make(chan types.Log)
-	sub, err := ec.conn.SubscribeFilterLogs(ec.ctx, query, logs)
+// streamSmartContractEvents follows the events of confirmed blocks of the given contract
+func (ec *eth1Client) streamSmartContractEvents(logger *zap.Logger) error {
+	contractAbi, err := abi.JSON(strings.NewReader(ec.contractABI))
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "Failed to subscribe to logs")
+		return errors.Wrap(err, "failed to parse ABI interface")
 	}
-	logger.Debug("subscribed to results of the streaming filter query")
 
-	return sub, logs, nil
+	if ec.lastBlock == 0 {
+		// history wasn't synced, follow from the current confirmed block
+		if ec.lastBlock, err = ec.confirmedBlock(logger); err != nil {
+			return err
+		}
+	}
+	logger.Debug("streaming smart contract events",
+		zap.Uint64("last_block", ec.lastBlock),
+		zap.Uint64("follow_distance", ec.followDistance))
+
+	go ec.follow(logger, contractAbi)
+
+	return nil
 }
 
-// listenToSubscription listen to new event logs from the contract
-func (ec *eth1Client) listenToSubscription(logger *zap.Logger, logs chan types.Log, sub ethereum.Subscription, contractAbi abi.ABI) error {
+// follow polls for new confirmed blocks and processes their events
+func (ec *eth1Client) follow(logger *zap.Logger, contractAbi abi.ABI) {
+	ticker := time.NewTicker(ec.followInterval)
+	defer ticker.Stop()
+
+	var failingSince time.Time
 	for {
 		select {
-		case err := <-sub.Err():
-			logger.Warn("failed to read logs from subscription", zap.Error(err))
-			return err
-		case vLog := <-logs:
-			if vLog.Removed {
-				continue
+		case <-ec.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := ec.followConfirmedBlocks(logger, contractAbi); err != nil {
+			if failingSince.IsZero() {
+				failingSince = time.Now()
 			}
-			logger.Debug("received contract event from stream")
-			eventName, err := ec.handleEvent(logger, vLog, contractAbi)
-			if err != nil {
-				logger.Warn("could not parse ongoing event, the event is malformed",
-					fields.EventName(eventName),
-					fields.BlockNumber(vLog.BlockNumber),
-					fields.TxHash(vLog.TxHash),
-					zap.Error(err),
-				)
-				continue
+			// the execution client is required
+			if time.Since(failingSince) >= followFailureLimit {
+				logger.Panic("failed to follow contract events", zap.Error(err))
 			}
+			logger.Warn("failed to follow contract events, still trying", zap.Error(err))
+			continue
 		}
+		failingSince = time.Time{}
 	}
 }
 
+// followConfirmedBlocks processes the events of the blocks confirmed since the last call,
+// and notifies observers about the new confirmed block once its events were sent.
+func (ec *eth1Client) followConfirmedBlocks(logger *zap.Logger, contractAbi abi.ABI) error {
+	if err := ec.checkReorg(logger); err != nil {
+		return err
+	}
+	confirmed, err := ec.confirmedBlock(logger)
+	if err != nil {
+		return err
+	}
+	if confirmed <= ec.lastBlock {
+		return nil
+	}
+
+	for fromBlock := ec.lastBlock + 1; fromBlock <= confirmed; fromBlock = ec.lastBlock + 1 {
+		toBlock := fromBlock + blocksInBatch
+		if toBlock > confirmed {
+			toBlock = confirmed
+		}
+		if _, _, err := ec.fetchAndProcessEvents(logger, new(big.Int).SetUint64(fromBlock), new(big.Int).SetUint64(toBlock), contractAbi); err != nil {
+			return errors.Wrap(err, "failed to get events")
+		}
+		ec.fireEvent(types.Log{BlockNumber: toBlock}, "ConfirmedBlockEvent", eth1.ConfirmedBlockEvent{Block: toBlock})
+	}
+	return nil
+}
+
 func (ec *eth1Client) syncSmartContractsEvents(logger *zap.Logger, fromBlock *big.Int) error {
 	logger.Debug("syncing smart contract events", fields.FromBlock(fromBlock))
 
@@ -264,15 +445,15 @@ func (ec *eth1Client) syncSmartContractsEvents(logger *zap.Logger, fromBlock *bi
 	if err != nil {
 		return errors.Wrap(err, "failed to parse ABI interface")
 	}
-	highestBlock, err := ec.conn.BlockNumber(ec.ctx)
+	highestBlock, err := ec.confirmedBlock(logger)
 	if err != nil {
-		return errors.Wrap(err, "failed to get current block")
+		return err
 	}
 
 	var logs []types.Log
 	var nSuccess int
 
-	for {
+	for fromBlock.Uint64() <= highestBlock {
 		toBlock := new(big.Int).SetUint64(fromBlock.Uint64() + blocksInBatch)
 		if toBlock.Uint64() > highestBlock {
 			toBlock.SetUint64(highestBlock)
@@ -286,11 +467,11 @@ func (ec *eth1Client) syncSmartContractsEvents(logger *zap.Logger, fromBlock *bi
 		nSuccess += _nSuccess
 		logs = append(logs, _logs...)
 
-		// If toBlock reached the highest block, check for new blocks
+		// If toBlock reached the highest block, check for new confirmed blocks
 		if toBlock.Uint64() >= highestBlock {
-			currentBlock, err := ec.conn.BlockNumber(ec.ctx)
+			currentBlock, err := ec.confirmedBlock(logger)
 			if err != nil {
-				return errors.Wrap(err, "failed to get current block")
+				return err
 			}
 
 			// If Eth1 advanced while we were syncing, let's sync the new blocks.
@@ -307,29 +488,32 @@ func (ec *eth1Client) syncSmartContractsEvents(logger *zap.Logger, fromBlock *bi
 
 		fromBlock.SetUint64(toBlock.Uint64() + 1)
 	}
-
 	logger.Debug("finished syncing registry contract",
-		zap.Int("total events", len(logs)), zap.Int("total success", nSuccess))
+		zap.Int("total events", len(logs)), zap.Int("total success", nSuccess),
+		zap.Uint64("follow_distance", ec.followDistance))
 
 	ec.fireEvent(types.Log{}, "SyncEndedEvent", eth1.SyncEndedEvent{Block: highestBlock, Success: nSuccess == len(logs)})
 
 	return nil
 }
 
+// fetchAndProcessEvents fetches and handles the events of the given blocks.
+// Logs of recent blocks are verified to be canonical, as the chain might reorg while they are fetched.
 func (ec *eth1Client) fetchAndProcessEvents(logger *zap.Logger, fromBlock, toBlock *big.Int, contractAbi abi.ABI) ([]types.Log, int, error) {
-	logger = logger.With(fields.FromBlock(fromBlock))
+	logger = logger.With(fields.FromBlock(fromBlock), fields.ToBlock(toBlock))
 	contractAddress := common.HexToAddress(ec.registryContractAddr)
 	query := ethereum.FilterQuery{
 		Addresses: []common.Address{contractAddress},
 		FromBlock: fromBlock,
-	}
-	if toBlock != nil {
-		query.ToBlock = toBlock
-		logger = logger.With(fields.ToBlock(toBlock))
+		ToBlock:   toBlock,
 	}
 	logger.Debug("fetching event logs")
 	start := time.Now()
-	logs, err := ec.conn.FilterLogs(ec.ctx, query)
+	var logs []types.Log
+	err := ec.withConn(logger, func(conn executionClient) (err error) {
+		logs, err = conn.FilterLogs(ec.ctx, query)
+		return err
+	})
 	if err != nil {
 		return nil, 0, errors.Wrap(err, "failed to get event logs")
 	}
@@ -337,6 +521,27 @@ func (ec *eth1Client) fetchAndProcessEvents(logger *zap.Logger, fromBlock, toBlo
 	logger = logger.With(zap.Int("logs", len(logs)))
 	logger.Debug("got event logs", zap.Duration("took", time.Since(start)))
 
+	toHash, err := ec.blockHash(logger, toBlock.Uint64())
+	if err != nil {
+		return nil, 0, err
+	}
+	hashes := map[uint64]common.Hash{toBlock.Uint64(): toHash}
+	for _, vLog := range logs {
+		if vLog.BlockNumber+maxTrackedBlocks <= toBlock.Uint64() {
+			continue
+		}
+		hash, ok := hashes[vLog.BlockNumber]
+		if !ok {
+			if hash, err = ec.blockHash(logger, vLog.BlockNumber); err != nil {
+				return nil, 0, err
+			}
+			hashes[vLog.BlockNumber] = hash
+		}
+		if vLog.Removed || vLog.BlockHash != hash {
+			return nil, 0, errors.Errorf("log of block %d is not canonical, the chain reorged", vLog.BlockNumber)
+		}
+	}
+
 	start = time.Now()
 	for _, vLog := range logs {
 		eventName, err := ec.handleEvent(logger, vLog, contractAbi)
@@ -361,6 +566,9 @@ func (ec *eth1Client) fetchAndProcessEvents(logger *zap.Logger, fromBlock, toBlo
 		zap.Int("fails", fails),
 		zap.Duration("took", time.Since(start)))
 
+	ec.trackBlock(toBlock.Uint64(), toHash)
+	ec.lastBlock = toBlock.Uint64()
+
 	return logs, len(logs) - fails, nil
 }
 
@@ -408,6 +616,18 @@ func (ec *eth1Client) handleEvent(logger *zap.Logger, vLog types.Log, contractAb
 			return ev.Name, err
 		}
 		ec.fireEvent(vLog, ev.Name, *parsed)
+	case abiparser.ValidatorExited:
+		parsed, err := abiParser.ParseValidatorExitedEvent(vLog, contractAbi)
+		reportSyncEvent(ev.Name, err)
+		if err != nil {
+			return ev.Name, err
+		}
+		header, err := ec.blockHeader(logger, vLog.BlockNumber)
+		if err != nil {
+			return ev.Name, err
+		}
+		parsed.BlockTimestamp = header.Time
+		ec.fireEvent(vLog, ev.Name, *parsed)
 	case abiparser.ClusterLiquidated:
 		parsed, err := abiParser.ParseClusterLiquidatedEvent(vLog, contractAbi)
 		reportSyncEvent(ev.Name, err)
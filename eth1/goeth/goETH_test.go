@@ -89,7 +89,7 @@ func TestEth1Client_handleEvent(t *testing.T) {
 func newEth1Client(abiVersion eth1.Version) *eth1Client {
 	ec := eth1Client{
 		ctx:        context.TODO(),
-		conn:       nil,
+		endpoints:  []*endpoint{{addr: "test"}},
 		eventsFeed: new(event.Feed),
 		abiVersion: abiVersion,
 	}
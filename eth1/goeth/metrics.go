@@ -22,6 +22,10 @@ var (
 		Name: "ssv_eth1_status",
 		Help: "Status of the connected eth1 node",
 	})
+	metricsEth1Reorgs = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv_eth1_reorgs_count",
+		Help: "Count reorgs of the eth1 chain deeper than the follow distance",
+	})
 	statusUnknown eth1NodeStatus = 0
 	statusSyncing eth1NodeStatus = 1
 	statusOK      eth1NodeStatus = 2
@@ -47,6 +51,10 @@ func reportSyncEvent(eventType string, err error) {
 	metricSyncEventsCountSuccess.WithLabelValues(eventType).Inc()
 }
 
+func reportReorg() {
+	metricsEth1Reorgs.Inc()
+}
+
 func reportNodeStatus(status eth1NodeStatus) {
 	metricsEth1NodeStatus.Set(float64(status))
 }
@@ -9,7 +9,8 @@ import (
 // NewFork returns a new fork instance from the given version
 func NewFork(forkVersion forksprotocol.ForkVersion) forks.Fork {
 	switch forkVersion {
-	case forksprotocol.GenesisForkVersion:
+	case forksprotocol.GenesisForkVersion, forksprotocol.V3ForkVersion:
+		// v3 doesn't change the storage encoding
 		return &genesis.ForkGenesis{}
 	case forksprotocol.ForkVersionEmpty:
 		fallthrough
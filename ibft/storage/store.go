@@ -141,20 +141,30 @@ func (i *ibftStorage) GetInstance(identifier []byte, height specqbft.Height) (*q
 }
 
 // GetInstancesInRange returns historical StoredInstance's in the given range.
+// Instances are read from a single snapshot, so the result is consistent even if instances are saved concurrently.
 func (i *ibftStorage) GetInstancesInRange(identifier []byte, from specqbft.Height, to specqbft.Height) ([]*qbftstorage.StoredInstance, error) {
 	i.forkLock.RLock()
 	defer i.forkLock.RUnlock()
 
+	snapshot := i.db.Snapshot()
+	defer snapshot.Discard()
+
+	prefix := append(i.prefix, identifier...)
 	instances := make([]*qbftstorage.StoredInstance, 0)
 
 	for seq := from; seq <= to; seq++ {
-		instance, err := i.GetInstance(identifier, seq)
+		obj, found, err := snapshot.Get(prefix, i.key(instanceKey, uInt64ToByteSlice(uint64(seq))))
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get instance")
 		}
-		if instance != nil {
-			instances = append(instances, instance)
+		if !found {
+			continue
+		}
+		instance := &qbftstorage.StoredInstance{}
+		if err := instance.Decode(obj.Value); err != nil {
+			return nil, errors.Wrap(err, "could not decode instance")
 		}
+		instances = append(instances, instance)
 	}
 
 	return instances, nil
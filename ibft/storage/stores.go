@@ -4,6 +4,8 @@ import (
 	"sync"
 
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"go.uber.org/zap"
+
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
@@ -44,3 +46,19 @@ func (qs *QBFTStores) Get(role spectypes.BeaconRole) qbftstorage.QBFTStore {
 func (qs *QBFTStores) Add(role spectypes.BeaconRole, store qbftstorage.QBFTStore) {
 	qs.m.Store(role, store)
 }
+
+// OnFork forks all the stores
+func (qs *QBFTStores) OnFork(logger *zap.Logger, forkVersion forksprotocol.ForkVersion) error {
+	var err error
+	qs.m.Range(func(role, s interface{}) bool {
+		handler, ok := s.(forksprotocol.ForkHandler)
+		if !ok {
+			return true
+		}
+		if err = handler.OnFork(logger.With(zap.String("role", role.(spectypes.BeaconRole).String())), forkVersion); err != nil {
+			return false
+		}
+		return true
+	})
+	return err
+}
@@ -17,6 +17,7 @@ const (
 	NameBadgerDBLog       = "BadgerDBLog"
 	NameBadgerDBReporting = "BadgerDBReporting"
 	NameCreateThreshold   = "CreateThreshold"
+	NameDKG               = "DKG"
 	NameDiscoveryV5Logger = "DiscoveryV5Logger"
 	NameExportKeys        = "ExportKeys"
 	NameOnFork            = "OnFork"
@@ -32,6 +32,16 @@ func Run(ctx context.Context, logger *zap.Logger, opt Options) (applied int, err
 	return defaultMigrations.Run(ctx, logger, opt)
 }
 
+// Level returns the amount of default migrations which were applied to the given db.
+func Level(db basedb.IDb) (int, error) {
+	return defaultMigrations.Level(db)
+}
+
+// LatestLevel returns the level of a db to which all default migrations were applied.
+func LatestLevel() int {
+	return len(defaultMigrations)
+}
+
 // MigrationFunc is a function that performs a migration.
 type MigrationFunc func(ctx context.Context, logger *zap.Logger, opt Options, key []byte) error
 
@@ -71,6 +81,20 @@ func (o Options) signerStorage(logger *zap.Logger) ekm.Storage {
 	return ekm.NewSignerStorage(o.Db, o.Network, logger)
 }
 
+// Level returns the amount of migrations which were applied, in order, to the given db.
+func (m Migrations) Level(db basedb.IDb) (int, error) {
+	for i, migration := range m {
+		obj, _, err := db.Get(migrationsPrefix, []byte(migration.Name))
+		if err != nil {
+			return 0, err
+		}
+		if !bytes.Equal(obj.Value, migrationCompleted) {
+			return i, nil
+		}
+	}
+	return len(m), nil
+}
+
 // Run executes the migrations.
 func (m Migrations) Run(ctx context.Context, logger *zap.Logger, opt Options) (applied int, err error) {
 	logger.Info("Running migrations")
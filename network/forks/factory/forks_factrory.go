@@ -3,6 +3,7 @@ package factory
 import (
 	"github.com/bloxapp/ssv/network/forks"
 	"github.com/bloxapp/ssv/network/forks/genesis"
+	v3 "github.com/bloxapp/ssv/network/forks/v3"
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 )
 
@@ -11,6 +12,8 @@ func NewFork(forkVersion forksprotocol.ForkVersion) forks.Fork {
 	switch forkVersion {
 	case forksprotocol.GenesisForkVersion:
 		return genesis.New()
+	case forksprotocol.V3ForkVersion:
+		return v3.New()
 	default:
 		return genesis.New()
 	}
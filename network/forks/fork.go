@@ -6,9 +6,14 @@ import (
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/protocol"
 
+	"github.com/bloxapp/ssv/protocol/v2/message"
 	p2pprotocol "github.com/bloxapp/ssv/protocol/v2/p2p"
 )
 
+// MaxNetworkMsgSize is the maximum size of an encoded network message (MsgType, MsgID, Data offset and Data),
+// larger messages are rejected before they're decoded
+const MaxNetworkMsgSize = 8 + 56 + 4 + 1<<23
+
 // Fork is an interface for network specific fork implementations
 type Fork interface {
 	encoding
@@ -56,6 +61,8 @@ type encoding interface {
 	EncodeNetworkMsg(msg *spectypes.SSVMessage) ([]byte, error)
 	// DecodeNetworkMsg decodes the given message
 	DecodeNetworkMsg(data []byte) (*spectypes.SSVMessage, error)
+	// SyncEncoding returns the encoding of sync requests, responses are encoded like the request
+	SyncEncoding() message.SyncEncoding
 }
 
 type sync interface {
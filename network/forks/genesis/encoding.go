@@ -2,6 +2,10 @@ package genesis
 
 import (
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/network/forks"
+	"github.com/bloxapp/ssv/protocol/v2/message"
 )
 
 // EncodeNetworkMsg encodes network message
@@ -11,6 +15,9 @@ func (g *ForkGenesis) EncodeNetworkMsg(msg *spectypes.SSVMessage) ([]byte, error
 
 // DecodeNetworkMsg decodes network message
 func (g *ForkGenesis) DecodeNetworkMsg(data []byte) (*spectypes.SSVMessage, error) {
+	if len(data) > forks.MaxNetworkMsgSize {
+		return nil, errors.Errorf("message size %d exceeds the maximum of %d", len(data), forks.MaxNetworkMsgSize)
+	}
 	msg := spectypes.SSVMessage{}
 	err := msg.Decode(data)
 	if err != nil {
@@ -18,3 +25,8 @@ func (g *ForkGenesis) DecodeNetworkMsg(data []byte) (*spectypes.SSVMessage, erro
 	}
 	return &msg, nil
 }
+
+// SyncEncoding returns the encoding of sync requests
+func (g *ForkGenesis) SyncEncoding() message.SyncEncoding {
+	return message.SyncEncodingJSON
+}
@@ -8,6 +8,8 @@ import (
 	spectypes "github.com/bloxapp/ssv-spec/types"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/network/forks"
 )
 
 func TestForkV1_Encoding(t *testing.T) {
@@ -27,3 +29,9 @@ func TestForkV1_Encoding(t *testing.T) {
 	require.Equal(t, msg.MsgType, res.MsgType)
 	require.True(t, bytes.Equal(msg.Data, res.Data))
 }
+
+func TestForkGenesis_DecodeOversized(t *testing.T) {
+	f := &ForkGenesis{}
+	_, err := f.DecodeNetworkMsg(make([]byte, forks.MaxNetworkMsgSize+1))
+	require.ErrorContains(t, err, "exceeds the maximum")
+}
@@ -0,0 +1,109 @@
+package forks
+
+import (
+	"sync/atomic"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/bloxapp/ssv/protocol/v2/message"
+	p2pprotocol "github.com/bloxapp/ssv/protocol/v2/p2p"
+)
+
+// Switcher is a Fork which delegates to the current fork,
+// it enables the components that hold it to follow a fork without being recreated.
+type Switcher struct {
+	current atomic.Pointer[Fork]
+}
+
+// NewSwitcher returns a switcher of the given fork
+func NewSwitcher(fork Fork) *Switcher {
+	s := &Switcher{}
+	s.Switch(fork)
+	return s
+}
+
+// Switch replaces the current fork
+func (s *Switcher) Switch(fork Fork) {
+	s.current.Store(&fork)
+}
+
+// Current returns the current fork
+func (s *Switcher) Current() Fork {
+	return *s.current.Load()
+}
+
+// EncodeNetworkMsg encodes the given message
+func (s *Switcher) EncodeNetworkMsg(msg *spectypes.SSVMessage) ([]byte, error) {
+	return s.Current().EncodeNetworkMsg(msg)
+}
+
+// DecodeNetworkMsg decodes the given message
+func (s *Switcher) DecodeNetworkMsg(data []byte) (*spectypes.SSVMessage, error) {
+	return s.Current().DecodeNetworkMsg(data)
+}
+
+// SyncEncoding returns the encoding of sync requests, responses are encoded like the request
+func (s *Switcher) SyncEncoding() message.SyncEncoding {
+	return s.Current().SyncEncoding()
+}
+
+// SubnetTopicID returns the topic id for the given subnet
+func (s *Switcher) SubnetTopicID(subnet int) string {
+	return s.Current().SubnetTopicID(subnet)
+}
+
+// ValidatorTopicID maps the given validator public key to the corresponding pubsub topic
+func (s *Switcher) ValidatorTopicID(pk []byte) []string {
+	return s.Current().ValidatorTopicID(pk)
+}
+
+// GetTopicFullName returns the topic full name, including prefix
+func (s *Switcher) GetTopicFullName(baseName string) string {
+	return s.Current().GetTopicFullName(baseName)
+}
+
+// GetTopicBaseName return the base topic name of the topic, w/o ssv prefix
+func (s *Switcher) GetTopicBaseName(topicName string) string {
+	return s.Current().GetTopicBaseName(topicName)
+}
+
+// ValidatorSubnet returns the subnet for the given validator
+func (s *Switcher) ValidatorSubnet(validatorPKHex string) int {
+	return s.Current().ValidatorSubnet(validatorPKHex)
+}
+
+// MsgID is the msgID function to use for pubsub
+func (s *Switcher) MsgID() MsgIDFunc {
+	return func(msg []byte) string {
+		return s.Current().MsgID()(msg)
+	}
+}
+
+// Subnets returns the subnets count for this fork
+func (s *Switcher) Subnets() int {
+	return s.Current().Subnets()
+}
+
+// Topics returns the available topics for this fork.
+func (s *Switcher) Topics() []string {
+	return s.Current().Topics()
+}
+
+// ProtocolID returns the protocol id of given protocol,
+// and the amount of peers for distribution
+func (s *Switcher) ProtocolID(prot p2pprotocol.SyncProtocol) (protocol.ID, int) {
+	return s.Current().ProtocolID(prot)
+}
+
+// DecorateNode will enrich the local node record with more entries, according to current fork
+func (s *Switcher) DecorateNode(node *enode.LocalNode, args map[string]interface{}) error {
+	return s.Current().DecorateNode(node, args)
+}
+
+// AddOptions enables to inject libp2p options according to the given fork
+func (s *Switcher) AddOptions(opts []libp2p.Option) []libp2p.Option {
+	return s.Current().AddOptions(opts)
+}
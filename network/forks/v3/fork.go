@@ -0,0 +1,90 @@
+package v3
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/bloxapp/ssv/network/forks"
+	"github.com/bloxapp/ssv/network/forks/genesis"
+	"github.com/bloxapp/ssv/network/records"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/protocol/v2/message"
+	p2pprotocol "github.com/bloxapp/ssv/protocol/v2/p2p"
+)
+
+const (
+	topicPrefix = "ssv.v3"
+
+	lastDecidedProtocol = "/ssv/sync/decided/last/0.0.2"
+	historyProtocol     = "/ssv/sync/decided/history/0.0.2"
+
+	peersForSync = 10
+)
+
+// ForkV3 moves the network to new topics and sync protocols,
+// so nodes of different forks don't exchange messages, and encodes sync requests with SSZ.
+// The rest is inherited from genesis.
+type ForkV3 struct {
+	forks.Fork
+	topics []string
+}
+
+// New returns an instance of ForkV3
+func New() forks.Fork {
+	f := &ForkV3{Fork: genesis.New()}
+	f.topics = make([]string, f.Subnets())
+	for i := 0; i < f.Subnets(); i++ {
+		f.topics[i] = f.GetTopicFullName(f.SubnetTopicID(i))
+	}
+	return f
+}
+
+// GetTopicFullName returns the topic full name, including prefix
+func (f *ForkV3) GetTopicFullName(baseName string) string {
+	return fmt.Sprintf("%s.%s", topicPrefix, baseName)
+}
+
+// GetTopicBaseName return the base topic name of the topic, w/o ssv prefix
+func (f *ForkV3) GetTopicBaseName(topicName string) string {
+	return strings.Replace(topicName, fmt.Sprintf("%s.", topicPrefix), "", 1)
+}
+
+// Topics returns the available topics for this fork.
+func (f *ForkV3) Topics() []string {
+	return f.topics
+}
+
+// ProtocolID returns the protocol id of the given protocol,
+// and the amount of peers for distribution
+func (f *ForkV3) ProtocolID(prot p2pprotocol.SyncProtocol) (protocol.ID, int) {
+	switch prot {
+	case p2pprotocol.LastDecidedProtocol:
+		return lastDecidedProtocol, peersForSync
+	case p2pprotocol.DecidedHistoryProtocol:
+		return historyProtocol, peersForSync
+	}
+	return "", 0
+}
+
+// SyncEncoding returns the encoding of sync requests
+func (f *ForkV3) SyncEncoding() message.SyncEncoding {
+	return message.SyncEncodingSSZ
+}
+
+// DecorateNode will enrich the local node record with more entries, according to current fork
+func (f *ForkV3) DecorateNode(node *enode.LocalNode, args map[string]interface{}) error {
+	if err := records.SetForkVersionEntry(node, forksprotocol.V3ForkVersion.String()); err != nil {
+		return err
+	}
+	var subnets []byte
+	raw, ok := args["subnets"]
+	if !ok {
+		subnets = make([]byte, f.Subnets())
+	} else {
+		subnets = raw.([]byte)
+	}
+	return records.SetSubnetsEntry(node, subnets)
+}
@@ -0,0 +1,43 @@
+package v3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/network/forks"
+	"github.com/bloxapp/ssv/network/forks/genesis"
+	"github.com/bloxapp/ssv/protocol/v2/message"
+	p2pprotocol "github.com/bloxapp/ssv/protocol/v2/p2p"
+)
+
+func TestForkV3(t *testing.T) {
+	g, f := genesis.New(), New()
+
+	// subnets are kept, under new topics
+	require.Equal(t, g.Subnets(), f.Subnets())
+	require.Equal(t, g.ValidatorTopicID([]byte{1, 2, 3, 4, 5}), f.ValidatorTopicID([]byte{1, 2, 3, 4, 5}))
+	require.Equal(t, "ssv.v3.5", f.GetTopicFullName("5"))
+	require.Equal(t, "5", f.GetTopicBaseName("ssv.v3.5"))
+	// topics of genesis aren't of this fork
+	require.Equal(t, "ssv.v2.5", f.GetTopicBaseName("ssv.v2.5"))
+	require.Len(t, f.Topics(), f.Subnets())
+	require.Equal(t, "ssv.v3.0", f.Topics()[0])
+
+	for _, prot := range []p2pprotocol.SyncProtocol{p2pprotocol.LastDecidedProtocol, p2pprotocol.DecidedHistoryProtocol} {
+		gpid, _ := g.ProtocolID(prot)
+		pid, peers := f.ProtocolID(prot)
+		require.NotEqual(t, gpid, pid)
+		require.Greater(t, peers, 0)
+	}
+
+	require.Equal(t, message.SyncEncodingJSON, g.SyncEncoding())
+	require.Equal(t, message.SyncEncodingSSZ, f.SyncEncoding())
+}
+
+func TestSwitcher(t *testing.T) {
+	s := forks.NewSwitcher(genesis.New())
+	require.Equal(t, "ssv.v2.1", s.GetTopicFullName("1"))
+	s.Switch(New())
+	require.Equal(t, "ssv.v3.1", s.GetTopicFullName("1"))
+}
@@ -3,10 +3,12 @@ package network
 import (
 	"io"
 
+	"github.com/libp2p/go-libp2p/core/protocol"
 	"go.uber.org/zap"
 
 	spectypes "github.com/bloxapp/ssv-spec/types"
 
+	"github.com/bloxapp/ssv/network/streams"
 	protocolp2p "github.com/bloxapp/ssv/protocol/v2/p2p"
 )
 
@@ -35,6 +37,8 @@ type P2PNetwork interface {
 	UpdateSubnets(logger *zap.Logger)
 	// SubscribeAll subscribes to all subnets
 	SubscribeAll(logger *zap.Logger) error
+	// RegisterStreamHandler registers a handler of raw requests for the given stream protocol, must be called after Setup
+	RegisterStreamHandler(logger *zap.Logger, pid protocol.ID, handler streams.RequestHandler)
 }
 
 // GetValidatorStats returns stats of validators, including the following:
@@ -20,6 +20,7 @@ import (
 	"github.com/bloxapp/ssv/networkconfig"
 	"github.com/bloxapp/ssv/operator/storage"
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
 	uc "github.com/bloxapp/ssv/utils/commons"
 )
 
@@ -66,6 +67,8 @@ type Config struct {
 	NodeStorage storage.Storage
 	// Network defines a network configuration.
 	Network networkconfig.NetworkConfig
+	// Shares is used to validate incoming messages against the validators' committees, optional.
+	Shares registrystorage.Shares
 
 	PubsubMsgCacheTTL         time.Duration `yaml:"PubsubMsgCacheTTL" env:"PUBSUB_MSG_CACHE_TTL" env-description:"How long a message ID will be remembered as seen"`
 	PubsubOutQueueSize        int           `yaml:"PubsubOutQueueSize" env:"PUBSUB_OUT_Q_SIZE" env-description:"The size that we assign to the outbound pubsub message queue"`
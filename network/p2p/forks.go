@@ -1,17 +1,49 @@
 package p2pv1
 
 import (
-	"fmt"
-
-	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	forksfactory "github.com/bloxapp/ssv/network/forks/factory"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 )
 
-// OnFork handles a fork event, it will close the current p2p network
-// and recreate it with while preserving previous state (active validators)
-// NOTE: ths method MUST be called once per fork version, otherwise we are just restarting the network
+// OnFork handles a fork event, it moves the subscribed topics to the topics of the new fork,
+// serves the sync protocols of the new fork and updates the node records.
+// Connections and active validators are preserved, and the sync protocols of the previous fork keep being served
+// for peers that didn't fork yet.
 func (n *p2pNetwork) OnFork(logger *zap.Logger, forkVersion forksprotocol.ForkVersion) error {
+	if forkVersion == n.forkVersion {
+		return nil
+	}
+	logger = logger.With(fields.Fork(forkVersion))
 	logger.Info("forking network")
-	return errors.New(fmt.Sprintf("no handler for fork - %s", forkVersion.String()))
+
+	// topics are listed by their base names, which are the same across forks
+	topics := n.topicsCtrl.Topics()
+	for _, topic := range topics {
+		if err := n.topicsCtrl.Unsubscribe(logger, topic, false); err != nil {
+			logger.Debug("could not unsubscribe from topic of previous fork", zap.String("topic", topic), zap.Error(err))
+		}
+	}
+
+	n.fork.Switch(forksfactory.NewFork(forkVersion))
+	n.forkVersion = forkVersion
+
+	n.registerSyncHandlers(logger, n.syncHandlers...)
+
+	self := n.idx.Self()
+	self.ForkVersion = forkVersion
+	n.idx.UpdateSelfRecord(self)
+	if err := n.disc.UpdateForkVersion(logger, forkVersion); err != nil {
+		return errors.Wrap(err, "could not update fork version in discovery")
+	}
+
+	for _, topic := range topics {
+		if err := n.topicsCtrl.Subscribe(logger, topic); err != nil {
+			return errors.Wrapf(err, "could not subscribe to topic %s", topic)
+		}
+	}
+	return nil
 }
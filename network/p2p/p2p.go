@@ -27,6 +27,8 @@ import (
 	"github.com/bloxapp/ssv/network/syncing"
 	"github.com/bloxapp/ssv/network/topics"
 	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	p2pprotocol "github.com/bloxapp/ssv/protocol/v2/p2p"
 	"github.com/bloxapp/ssv/utils/async"
 	"github.com/bloxapp/ssv/utils/tasks"
 )
@@ -54,7 +56,8 @@ type p2pNetwork struct {
 	cancel    context.CancelFunc
 
 	interfaceLogger *zap.Logger // struct logger to log in interface methods that do not accept a logger
-	fork            forks.Fork
+	fork            *forks.Switcher
+	forkVersion     forksprotocol.ForkVersion
 	cfg             *Config
 
 	host        host.Host
@@ -74,6 +77,7 @@ type p2pNetwork struct {
 	subnets          []byte
 	libConnManager   connmgrcore.ConnManager
 	syncer           syncing.Syncer
+	syncHandlers     []*p2pprotocol.SyncHandler
 	nodeStorage      operatorstorage.Storage
 	operatorPKCache  sync.Map
 }
@@ -89,7 +93,8 @@ func New(logger *zap.Logger, cfg *Config) network.P2PNetwork {
 		ctx:              ctx,
 		cancel:           cancel,
 		interfaceLogger:  logger,
-		fork:             forksfactory.NewFork(cfg.ForkVersion),
+		fork:             forks.NewSwitcher(forksfactory.NewFork(cfg.ForkVersion)),
+		forkVersion:      cfg.ForkVersion,
 		cfg:              cfg,
 		msgRouter:        cfg.Router,
 		state:            stateClosed,
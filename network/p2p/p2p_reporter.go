@@ -6,6 +6,7 @@ import (
 	"github.com/bloxapp/ssv/logging/fields"
 
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"go.uber.org/zap"
 
 	ssvpeers "github.com/bloxapp/ssv/network/peers"
@@ -25,11 +26,15 @@ func (n *p2pNetwork) ReportValidation(logger *zap.Logger, msg *spectypes.SSVMess
 	}
 	peers := n.msgResolver.GetPeers(data)
 	for _, pi := range peers {
-		err := n.idx.Score(pi, &ssvpeers.NodeScore{Name: "validation", Value: msgValidationScore(res)})
-		if err != nil {
-			logger.Warn("could not score peer", fields.PeerID(pi), zap.Error(err))
-			continue
-		}
+		n.reportPeerValidation(logger, pi, res)
+	}
+}
+
+// reportPeerValidation converts the given result to a score and reports it for the given peer
+func (n *p2pNetwork) reportPeerValidation(logger *zap.Logger, pi peer.ID, res protocolp2p.MsgValidationResult) {
+	err := n.idx.Score(pi, &ssvpeers.NodeScore{Name: "validation", Value: msgValidationScore(res)})
+	if err != nil {
+		logger.Warn("could not score peer", fields.PeerID(pi), zap.Error(err))
 	}
 }
 
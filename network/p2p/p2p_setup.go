@@ -11,6 +11,7 @@ import (
 
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 	libp2pdiscbackoff "github.com/libp2p/go-libp2p/p2p/discovery/backoff"
 	basichost "github.com/libp2p/go-libp2p/p2p/host/basic"
 	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
@@ -28,6 +29,7 @@ import (
 	"github.com/bloxapp/ssv/network/records"
 	"github.com/bloxapp/ssv/network/streams"
 	"github.com/bloxapp/ssv/network/topics"
+	protocolp2p "github.com/bloxapp/ssv/protocol/v2/p2p"
 	"github.com/bloxapp/ssv/utils/commons"
 )
 
@@ -278,11 +280,25 @@ func (n *p2pNetwork) setupDiscovery(logger *zap.Logger) error {
 }
 
 func (n *p2pNetwork) setupPubsub(logger *zap.Logger) error {
+	validatorOpts := []topics.MsgValidatorOption{
+		topics.WithValidationReporter(func(pid peer.ID, res protocolp2p.MsgValidationResult) {
+			n.reportPeerValidation(logger, pid, res)
+		}),
+	}
+	if n.cfg.Shares != nil {
+		validatorOpts = append(validatorOpts, topics.WithShares(n.cfg.Shares))
+	}
+	if n.cfg.Network.Beacon.BeaconNetwork != "" {
+		validatorOpts = append(validatorOpts, topics.WithNetworkConfig(n.cfg.Network))
+	}
+	// a single validator is shared by all topics, so duplicates are tracked across topics
+	msgValidator := topics.NewSSVMsgValidator(n.fork, validatorOpts...)
+
 	cfg := &topics.PububConfig{
 		Host:     n.host,
 		TraceLog: n.cfg.PubSubTrace,
 		MsgValidatorFactory: func(s string) topics.MsgValidatorFunc {
-			return topics.NewSSVMsgValidator(n.fork)
+			return msgValidator
 		},
 		MsgHandler: n.handlePubsubMessages(logger),
 		ScoreIndex: n.idx,
@@ -20,6 +20,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/network/streams"
 	"github.com/bloxapp/ssv/protocol/v2/message"
 	p2pprotocol "github.com/bloxapp/ssv/protocol/v2/p2p"
 )
@@ -139,6 +140,12 @@ func (n *p2pNetwork) GetHistory(logger *zap.Logger, mid spectypes.MessageID, fro
 
 // RegisterHandlers registers the given handlers
 func (n *p2pNetwork) RegisterHandlers(logger *zap.Logger, handlers ...*p2pprotocol.SyncHandler) {
+	n.syncHandlers = append(n.syncHandlers, handlers...)
+	n.registerSyncHandlers(logger, handlers...)
+}
+
+// registerSyncHandlers registers the given handlers under the protocols of the current fork
+func (n *p2pNetwork) registerSyncHandlers(logger *zap.Logger, handlers ...*p2pprotocol.SyncHandler) {
 	m := make(map[libp2p_protocol.ID][]p2pprotocol.RequestHandler)
 	for _, handler := range handlers {
 		pid, _ := n.fork.ProtocolID(handler.Protocol)
@@ -166,6 +173,27 @@ func (n *p2pNetwork) registerHandlers(logger *zap.Logger, pid libp2p_protocol.ID
 	})
 }
 
+// RegisterStreamHandler registers a handler of raw requests for the given stream protocol
+func (n *p2pNetwork) RegisterStreamHandler(logger *zap.Logger, pid libp2p_protocol.ID, handler streams.RequestHandler) {
+	n.host.SetStreamHandler(pid, func(stream libp2pnetwork.Stream) {
+		logger := logger.With(zap.String("protocol", string(pid)), fields.PeerID(stream.Conn().RemotePeer()))
+		req, respond, done, err := n.streamCtrl.HandleStream(logger, stream)
+		defer done()
+		if err != nil {
+			logger.Debug("could not handle stream", zap.Error(err))
+			return
+		}
+		res, err := handler(logger, stream.Conn().RemotePeer(), req)
+		if err != nil {
+			logger.Debug("stream handler failed", zap.Error(err))
+			return
+		}
+		if err := respond(res); err != nil {
+			logger.Debug("could not respond to stream", zap.Error(err))
+		}
+	})
+}
+
 func (n *p2pNetwork) handleStream(logger *zap.Logger, handler p2pprotocol.RequestHandler) func(stream libp2pnetwork.Stream) error {
 	return func(stream libp2pnetwork.Stream) error {
 		req, respond, done, err := n.streamCtrl.HandleStream(logger, stream)
@@ -227,6 +255,7 @@ func (n *p2pNetwork) getSubsetOfPeers(logger *zap.Logger, vpk spectypes.Validato
 
 func (n *p2pNetwork) makeSyncRequest(logger *zap.Logger, peers []peer.ID, mid spectypes.MessageID, protocol libp2p_protocol.ID, syncMsg *message.SyncMessage) ([]p2pprotocol.SyncResult, error) {
 	var results []p2pprotocol.SyncResult
+	syncMsg.SetEncoding(n.fork.SyncEncoding())
 	data, err := syncMsg.Encode()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not encode sync message")
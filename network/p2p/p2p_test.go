@@ -18,6 +18,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/network/forks"
 	forksfactory "github.com/bloxapp/ssv/network/forks/factory"
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	protcolp2p "github.com/bloxapp/ssv/protocol/v2/p2p"
@@ -27,7 +28,7 @@ import (
 func TestGetMaxPeers(t *testing.T) {
 	n := &p2pNetwork{
 		cfg:  &Config{MaxPeers: 40, TopicMaxPeers: 8},
-		fork: forksfactory.NewFork(forksprotocol.GenesisForkVersion),
+		fork: forks.NewSwitcher(forksfactory.NewFork(forksprotocol.GenesisForkVersion)),
 	}
 
 	require.Equal(t, 40, n.getMaxPeers(""))
@@ -107,6 +108,57 @@ func TestP2pNetwork_SubscribeBroadcast(t *testing.T) {
 	}
 }
 
+func TestP2pNetwork_OnFork(t *testing.T) {
+	n := 4
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := logging.TestLogger(t)
+
+	pk := "b768cdc2b2e0a859052bf04d1cd66383c96d95096a5287d08151494ce709556ba39c1300fbb902a0e2ebb7c31dc4e400"
+	ln, routers, err := createNetworkAndSubscribe(t, ctx, n, forksprotocol.GenesisForkVersion, pk)
+	require.NoError(t, err)
+
+	for _, node := range ln.Nodes {
+		p := node.(*p2pNetwork)
+		subnets := p.topicsCtrl.Topics()
+		require.NoError(t, p.OnFork(logger, forksprotocol.V3ForkVersion))
+		// forking twice is a noop
+		require.NoError(t, p.OnFork(logger, forksprotocol.V3ForkVersion))
+
+		require.Equal(t, forksprotocol.V3ForkVersion, p.idx.Self().ForkVersion)
+		// the same subnets are subscribed under the topics of the new fork
+		require.ElementsMatch(t, subnets, p.topicsCtrl.Topics())
+		for _, topic := range p.topicsCtrl.Topics() {
+			require.Equal(t, "ssv.v3."+topic, p.fork.GetTopicFullName(topic))
+		}
+	}
+
+	// wait for the peers to subscribe to the topic of the new fork
+	vpk, err := hex.DecodeString(pk)
+	require.NoError(t, err)
+	for _, node := range ln.Nodes {
+		require.Eventually(t, func() bool {
+			peers, err := node.Peers(vpk)
+			return err == nil && len(peers) >= 2
+		}, 5*time.Second, 100*time.Millisecond)
+	}
+
+	// let the mesh of the new topics form
+	<-time.After(time.Second)
+	msg, err := dummyMsg(pk, 1)
+	require.NoError(t, err)
+	require.NoError(t, ln.Nodes[0].Broadcast(msg))
+	for i, r := range routers[1:] {
+		require.Eventually(t, func() bool {
+			return atomic.LoadUint64(&r.count) >= 1
+		}, 5*time.Second, 100*time.Millisecond, "router", i+1)
+	}
+
+	for _, node := range ln.Nodes {
+		require.NoError(t, node.(*p2pNetwork).Close())
+	}
+}
+
 func TestP2pNetwork_Stream(t *testing.T) {
 	n := 12
 	ctx, cancel := context.WithCancel(context.Background())
@@ -139,3 +139,13 @@ func (m NodeStorage) SetupPrivateKey(logger *zap.Logger, operatorKeyBase64 strin
 	//TODO implement me
 	panic("implement me")
 }
+
+func (m NodeStorage) SetPrivateKey(sk *rsa.PrivateKey) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m NodeStorage) DeletePrivateKey() error {
+	//TODO implement me
+	panic("implement me")
+}
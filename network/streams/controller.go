@@ -19,6 +19,9 @@ import (
 // StreamResponder abstracts the stream access with a simpler interface that accepts only the data to send
 type StreamResponder func([]byte) error
 
+// RequestHandler handles a single raw request of a stream protocol and returns the response
+type RequestHandler func(logger *zap.Logger, peerID peer.ID, req []byte) ([]byte, error)
+
 // StreamController simplifies the interaction with libp2p streams.
 type StreamController interface {
 	// Request sends a message to the given stream and returns the response
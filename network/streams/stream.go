@@ -6,6 +6,8 @@ import (
 
 	"github.com/libp2p/go-libp2p/core"
 	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/network/forks"
 )
 
 // Stream represents a stream in the system
@@ -38,7 +40,14 @@ func (ts *streamWrapper) ReadWithTimeout(timeout time.Duration) ([]byte, error)
 	if err := ts.Stream.SetReadDeadline(time.Now().Add(timeout)); err != nil {
 		return nil, errors.Wrap(err, "could not set read deadline")
 	}
-	return io.ReadAll(ts.Stream)
+	data, err := io.ReadAll(io.LimitReader(ts.Stream, forks.MaxNetworkMsgSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > forks.MaxNetworkMsgSize {
+		return nil, errors.Errorf("stream message exceeds the maximum size of %d", forks.MaxNetworkMsgSize)
+	}
+	return data, nil
 }
 
 // WriteWithTimeout reads next message with timeout
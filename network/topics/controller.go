@@ -173,6 +173,7 @@ func (ctrl *topicsCtrl) Broadcast(name string, data []byte, timeout time.Duratio
 // Unsubscribe unsubscribes from the given topic, only if there are no other subscribers of the given topic
 // if hard is true, we will unsubscribe the topic even if there are more subscribers.
 func (ctrl *topicsCtrl) Unsubscribe(logger *zap.Logger, name string, hard bool) error {
+	name = ctrl.fork.GetTopicFullName(name)
 	ctrl.container.Unsubscribe(name)
 
 	if ctrl.msgValidatorFactory != nil {
@@ -58,8 +58,25 @@ func init() {
 type msgValidationResult string
 
 var (
-	validationResultNoData   msgValidationResult = "no_data"
-	validationResultEncoding msgValidationResult = "encoding"
+	validationResultValid            msgValidationResult = "valid"
+	validationResultNoData           msgValidationResult = "no_data"
+	validationResultEncoding         msgValidationResult = "encoding"
+	validationResultMsgType          msgValidationResult = "msg_type"
+	validationResultTopic            msgValidationResult = "topic"
+	validationResultDomain           msgValidationResult = "domain"
+	validationResultRole             msgValidationResult = "role"
+	validationResultUnknownValidator msgValidationResult = "unknown_validator"
+	validationResultLiquidated       msgValidationResult = "liquidated"
+	validationResultMalformed        msgValidationResult = "malformed"
+	validationResultIdentifier       msgValidationResult = "identifier"
+	validationResultSigner           msgValidationResult = "signer"
+	validationResultPartialSigType   msgValidationResult = "partial_sig_type"
+	validationResultLate             msgValidationResult = "late"
+	validationResultEarly            msgValidationResult = "early"
+	validationResultRound            msgValidationResult = "round"
+	validationResultDuplicate        msgValidationResult = "duplicate"
+	validationResultSignature        msgValidationResult = "signature"
+	validationResultEquivocation     msgValidationResult = "equivocation"
 )
 
 func reportValidationResult(result msgValidationResult) {
@@ -1,66 +1,460 @@
 package topics
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
-	"github.com/bloxapp/ssv/network/forks"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/jellydator/ttlcache/v3"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/bloxapp/ssv/network/forks"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/protocol/v2/message"
+	protocolp2p "github.com/bloxapp/ssv/protocol/v2/p2p"
+	"github.com/bloxapp/ssv/protocol/v2/qbft/roundtimer"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
 )
 
 // MsgValidatorFunc represents a message validator
 type MsgValidatorFunc = func(ctx context.Context, p peer.ID, msg *pubsub.Message) pubsub.ValidationResult
 
+// ValidationReporter is called with the result of a failed validation of a message that was received from the given peer
+type ValidationReporter func(p peer.ID, res protocolp2p.MsgValidationResult)
+
+const (
+	// lateSlotAllowance is the default amount of slots a message can be behind the current slot
+	lateSlotAllowance = 32
+	// earlySlotAllowance is the amount of slots a message can be ahead of the current slot (clock skew)
+	earlySlotAllowance = 1
+	// lateRoundAllowance is the amount of rounds a message can be ahead of the estimated round
+	lateRoundAllowance = 2
+	// seenCacheTTL is how long we remember the roots of messages we've seen for duplicate and equivocation checks
+	seenCacheTTL = 32 * 12 * time.Second
+	// seenCacheGCInterval is the minimum time between cleanups of expired entries in the seen cache
+	seenCacheGCInterval = time.Minute
+)
+
+// lateSlotAllowanceByRole overrides lateSlotAllowance for roles that become useless sooner
+var lateSlotAllowanceByRole = map[spectypes.BeaconRole]phase0.Slot{
+	spectypes.BNRoleProposer:                  3,
+	spectypes.BNRoleSyncCommittee:             3,
+	spectypes.BNRoleSyncCommitteeContribution: 3,
+}
+
+// partialSigTypesByRole maps each role to the partial signature types it may produce
+var partialSigTypesByRole = map[spectypes.BeaconRole][]spectypes.PartialSigMsgType{
+	spectypes.BNRoleAttester:                  {spectypes.PostConsensusPartialSig},
+	spectypes.BNRoleAggregator:                {spectypes.PostConsensusPartialSig, spectypes.SelectionProofPartialSig},
+	spectypes.BNRoleProposer:                  {spectypes.PostConsensusPartialSig, spectypes.RandaoPartialSig},
+	spectypes.BNRoleSyncCommittee:             {spectypes.PostConsensusPartialSig},
+	spectypes.BNRoleSyncCommitteeContribution: {spectypes.PostConsensusPartialSig, spectypes.ContributionProofs},
+	spectypes.BNRoleValidatorRegistration:     {spectypes.ValidatorRegistrationPartialSig},
+	message.BNRoleVoluntaryExit:               {message.VoluntaryExitPartialSig},
+	message.BNRoleBLSToExecutionChange:        {message.BLSToExecutionChangePartialSig},
+}
+
+// MsgValidatorOption enables to configure the message validation pipeline
+type MsgValidatorOption func(*msgValidator)
+
+// WithShares enables the committee related checks (signers, signatures, duplicates and equivocation)
+func WithShares(shares registrystorage.Shares) MsgValidatorOption {
+	return func(mv *msgValidator) {
+		mv.shares = shares
+	}
+}
+
+// WithNetworkConfig enables the domain and the height/round sanity checks
+func WithNetworkConfig(netCfg networkconfig.NetworkConfig) MsgValidatorOption {
+	return func(mv *msgValidator) {
+		mv.netCfg = &netCfg
+	}
+}
+
+// WithValidationReporter sets a reporter that is called for every rejected message
+func WithValidationReporter(reporter ValidationReporter) MsgValidatorOption {
+	return func(mv *msgValidator) {
+		mv.reporter = reporter
+	}
+}
+
+// withClock overrides the clock used for slot and round estimations (used in tests)
+func withClock(now func() time.Time) MsgValidatorOption {
+	return func(mv *msgValidator) {
+		mv.now = now
+	}
+}
+
 // NewSSVMsgValidator creates a new msg validator that validates message structure,
 // and checks that the message was sent on the right topic.
-// TODO: enable post SSZ change, remove logs, break into smaller validators?
-func NewSSVMsgValidator(fork forks.Fork) func(ctx context.Context, p peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
-	return func(ctx context.Context, p peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
-		topic := pmsg.GetTopic()
-		metricPubsubActiveMsgValidation.WithLabelValues(topic).Inc()
-		defer metricPubsubActiveMsgValidation.WithLabelValues(topic).Dec()
-		if len(pmsg.GetData()) == 0 {
-			reportValidationResult(validationResultNoData)
-			return pubsub.ValidationReject
+// Depending on the given options, it also validates the message against the validator's committee
+// (signers, signatures, duplicates and equivocation) and the current slot (height and round sanity).
+// Messages are rejected or ignored as early as possible, before they reach the validators' queues.
+func NewSSVMsgValidator(fork forks.Fork, opts ...MsgValidatorOption) MsgValidatorFunc {
+	mv := &msgValidator{
+		fork: fork,
+		now:  time.Now,
+		seen: ttlcache.New(
+			ttlcache.WithTTL[seenKey, [32]byte](seenCacheTTL),
+			ttlcache.WithDisableTouchOnHit[seenKey, [32]byte](),
+		),
+	}
+	for _, opt := range opts {
+		opt(mv)
+	}
+	return mv.validate
+}
+
+// msgValidator runs the validation pipeline for pubsub messages
+type msgValidator struct {
+	fork     forks.Fork
+	shares   registrystorage.Shares
+	netCfg   *networkconfig.NetworkConfig
+	reporter ValidationReporter
+	now      func() time.Time
+
+	// seen holds the roots of messages that were already accepted, by signer and position in the duty
+	seen     *ttlcache.Cache[seenKey, [32]byte]
+	seenLock sync.Mutex
+	lastGC   int64
+}
+
+// seenKey identifies the position of a single-signer message within a duty
+type seenKey struct {
+	msgID   spectypes.MessageID
+	signer  spectypes.OperatorID
+	msgType spectypes.MsgType
+	// subType is the qbft message type or the partial signature message type
+	subType uint64
+	height  uint64
+	round   uint64
+}
+
+// validationError describes why a message failed validation
+type validationError struct {
+	reason msgValidationResult
+	result protocolp2p.MsgValidationResult
+	err    error
+}
+
+func (e *validationError) Error() string {
+	if e.err != nil {
+		return fmt.Sprintf("%s: %s", e.reason, e.err)
+	}
+	return string(e.reason)
+}
+
+func reject(reason msgValidationResult, result protocolp2p.MsgValidationResult, err error) *validationError {
+	return &validationError{reason: reason, result: result, err: err}
+}
+
+func ignore(reason msgValidationResult) *validationError {
+	return &validationError{reason: reason, result: protocolp2p.ValidationIgnore}
+}
+
+func (mv *msgValidator) validate(ctx context.Context, p peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+	topic := pmsg.GetTopic()
+	metricPubsubActiveMsgValidation.WithLabelValues(topic).Inc()
+	defer metricPubsubActiveMsgValidation.WithLabelValues(topic).Dec()
+
+	mv.gcIfNeeded()
+
+	msg, verr := mv.validateMessage(topic, pmsg.GetData())
+	if verr != nil {
+		reportValidationResult(verr.reason)
+		if verr.result == protocolp2p.ValidationIgnore {
+			return pubsub.ValidationIgnore
+		}
+		if mv.reporter != nil {
+			mv.reporter(p, verr.result)
+		}
+		return pubsub.ValidationReject
+	}
+
+	reportValidationResult(validationResultValid)
+	pmsg.ValidatorData = *msg
+	return pubsub.ValidationAccept
+}
+
+// validateMessage runs the validation pipeline, cheap checks first
+func (mv *msgValidator) validateMessage(topic string, data []byte) (*spectypes.SSVMessage, *validationError) {
+	if len(data) == 0 {
+		return nil, reject(validationResultNoData, protocolp2p.ValidationRejectMedium, nil)
+	}
+	msg, err := mv.fork.DecodeNetworkMsg(data)
+	if err != nil {
+		return nil, reject(validationResultEncoding, protocolp2p.ValidationRejectHigh, err)
+	}
+	if msg == nil {
+		return nil, reject(validationResultEncoding, protocolp2p.ValidationRejectHigh, nil)
+	}
+	if verr := mv.validateSSVMessage(topic, msg); verr != nil {
+		return nil, verr
+	}
+	if mv.shares == nil {
+		return msg, nil
+	}
+
+	share := mv.shares.Get(msg.GetID().GetPubKey())
+	if share == nil {
+		return nil, ignore(validationResultUnknownValidator)
+	}
+	if share.Liquidated {
+		return nil, ignore(validationResultLiquidated)
+	}
+
+	switch msg.MsgType {
+	case spectypes.SSVConsensusMsgType:
+		return msg, mv.validateConsensusMessage(share, msg)
+	case spectypes.SSVPartialSignatureMsgType:
+		return msg, mv.validatePartialSignatureMessage(share, msg)
+	}
+	return msg, nil
+}
+
+// validateSSVMessage checks the envelope of the message: topic, type, domain and role
+func (mv *msgValidator) validateSSVMessage(topic string, msg *spectypes.SSVMessage) *validationError {
+	switch msg.MsgType {
+	case spectypes.SSVConsensusMsgType, spectypes.SSVPartialSignatureMsgType:
+	default:
+		// sync and event messages are internal and never expected on pubsub
+		return reject(validationResultMsgType, protocolp2p.ValidationRejectHigh, nil)
+	}
+	if len(msg.Data) == 0 {
+		return reject(validationResultNoData, protocolp2p.ValidationRejectMedium, nil)
+	}
+
+	// check if the message was sent on the right topic
+	if !mv.isValidatorTopic(topic, msg.GetID().GetPubKey()) {
+		return reject(validationResultTopic, protocolp2p.ValidationRejectMedium, nil)
+	}
+
+	if mv.netCfg != nil && !bytes.Equal(msg.GetID().GetDomain(), mv.netCfg.Domain[:]) {
+		return reject(validationResultDomain, protocolp2p.ValidationRejectMedium, nil)
+	}
+	if _, ok := partialSigTypesByRole[msg.GetID().GetRoleType()]; !ok {
+		return reject(validationResultRole, protocolp2p.ValidationRejectMedium, nil)
+	}
+	return nil
+}
+
+func (mv *msgValidator) isValidatorTopic(topic string, pk []byte) bool {
+	baseName := mv.fork.GetTopicBaseName(topic)
+	for _, tp := range mv.fork.ValidatorTopicID(pk) {
+		if tp == baseName {
+			return true
 		}
-		msg, err := fork.DecodeNetworkMsg(pmsg.GetData())
-		if err != nil {
-			// can't decode message
-			// logger.Debug("invalid: can't decode message", zap.Error(err))
-			reportValidationResult(validationResultEncoding)
-			return pubsub.ValidationReject
+	}
+	return false
+}
+
+// validateConsensusMessage validates a qbft message against the validator's committee and the current slot
+func (mv *msgValidator) validateConsensusMessage(share *types.SSVShare, msg *spectypes.SSVMessage) *validationError {
+	signedMsg := &specqbft.SignedMessage{}
+	if err := signedMsg.Decode(msg.Data); err != nil {
+		return reject(validationResultMalformed, protocolp2p.ValidationRejectHigh, err)
+	}
+	if err := signedMsg.Validate(); err != nil {
+		return reject(validationResultMalformed, protocolp2p.ValidationRejectHigh, err)
+	}
+	if !bytes.Equal(signedMsg.Message.Identifier, msg.MsgID[:]) {
+		return reject(validationResultIdentifier, protocolp2p.ValidationRejectMedium, nil)
+	}
+	if !signersInCommittee(share, signedMsg.Signers...) {
+		return reject(validationResultSigner, protocolp2p.ValidationRejectHigh, nil)
+	}
+	if len(signedMsg.Signers) > 1 && signedMsg.Message.MsgType != specqbft.CommitMsgType {
+		// only decided (aggregated commit) messages may have multiple signers
+		return reject(validationResultSigner, protocolp2p.ValidationRejectHigh, nil)
+	}
+
+	role := msg.GetID().GetRoleType()
+	if verr := mv.validateSlot(role, phase0.Slot(signedMsg.Message.Height)); verr != nil {
+		return verr
+	}
+	if verr := mv.validateRound(phase0.Slot(signedMsg.Message.Height), signedMsg.Message.Round); verr != nil {
+		return verr
+	}
+
+	root, err := signedMsg.GetRoot()
+	if err != nil {
+		return reject(validationResultMalformed, protocolp2p.ValidationRejectHigh, err)
+	}
+	var key *seenKey
+	if len(signedMsg.Signers) == 1 {
+		key = &seenKey{
+			msgID:   msg.MsgID,
+			signer:  signedMsg.Signers[0],
+			msgType: msg.MsgType,
+			subType: uint64(signedMsg.Message.MsgType),
+			height:  uint64(signedMsg.Message.Height),
+			round:   uint64(signedMsg.Message.Round),
 		}
-		if msg == nil {
-			reportValidationResult(validationResultEncoding)
-			return pubsub.ValidationReject
+		if mv.isDuplicate(*key, root) {
+			return ignore(validationResultDuplicate)
 		}
-		pmsg.ValidatorData = *msg
-		return pubsub.ValidationAccept
-
-		// Check if the message was sent on the right topic.
-		// currentTopic := pmsg.GetTopic()
-		// currentTopicBaseName := fork.GetTopicBaseName(currentTopic)
-		// topics := fork.ValidatorTopicID(msg.GetID().GetPubKey())
-		// for _, tp := range topics {
-		//	if tp == currentTopicBaseName {
-		//		reportValidationResult(validationResultValid)
-		//		return pubsub.ValidationAccept
-		//	}
-		//}
-		// reportValidationResult(validationResultTopic)
-		// return pubsub.ValidationReject
-	}
-}
-
-//// CombineMsgValidators executes multiple validators
-// func CombineMsgValidators(validators ...MsgValidatorFunc) MsgValidatorFunc {
-//	return func(ctx context.Context, p peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
-//		res := pubsub.ValidationAccept
-//		for _, v := range validators {
-//			if res = v(ctx, p, msg); res == pubsub.ValidationReject {
-//				break
-//			}
-//		}
-//		return res
-//	}
-//}
+	}
+
+	if err := types.VerifyByOperators(signedMsg.Signature, signedMsg, share.DomainType, spectypes.QBFTSignatureType, share.Committee); err != nil {
+		return reject(validationResultSignature, protocolp2p.ValidationRejectHigh, err)
+	}
+
+	if key != nil {
+		return mv.markSeen(*key, root)
+	}
+	return nil
+}
+
+// validatePartialSignatureMessage validates a partial signature message against the validator's committee and the current slot
+func (mv *msgValidator) validatePartialSignatureMessage(share *types.SSVShare, msg *spectypes.SSVMessage) *validationError {
+	signedMsg := &spectypes.SignedPartialSignatureMessage{}
+	if err := signedMsg.Decode(msg.Data); err != nil {
+		return reject(validationResultMalformed, protocolp2p.ValidationRejectHigh, err)
+	}
+	if err := signedMsg.Validate(); err != nil {
+		return reject(validationResultMalformed, protocolp2p.ValidationRejectHigh, err)
+	}
+
+	role := msg.GetID().GetRoleType()
+	if !partialSigTypeAllowed(role, signedMsg.Message.Type) {
+		return reject(validationResultPartialSigType, protocolp2p.ValidationRejectMedium, nil)
+	}
+	if !signersInCommittee(share, signedMsg.Signer) {
+		return reject(validationResultSigner, protocolp2p.ValidationRejectHigh, nil)
+	}
+	if verr := mv.validateSlot(role, signedMsg.Message.Slot); verr != nil {
+		return verr
+	}
+
+	root, err := signedMsg.GetRoot()
+	if err != nil {
+		return reject(validationResultMalformed, protocolp2p.ValidationRejectHigh, err)
+	}
+	key := seenKey{
+		msgID:   msg.MsgID,
+		signer:  signedMsg.Signer,
+		msgType: msg.MsgType,
+		subType: uint64(signedMsg.Message.Type),
+		height:  uint64(signedMsg.Message.Slot),
+	}
+	if mv.isDuplicate(key, root) {
+		return ignore(validationResultDuplicate)
+	}
+
+	if err := types.VerifyByOperators(signedMsg.Signature, signedMsg, share.DomainType, spectypes.PartialSignatureType, share.Committee); err != nil {
+		return reject(validationResultSignature, protocolp2p.ValidationRejectHigh, err)
+	}
+
+	return mv.markSeen(key, root)
+}
+
+// validateSlot checks that the given slot is not too far in the past or the future
+func (mv *msgValidator) validateSlot(role spectypes.BeaconRole, slot phase0.Slot) *validationError {
+	if mv.netCfg == nil {
+		return nil
+	}
+	currentSlot := mv.netCfg.Beacon.EstimatedSlotAtTime(mv.now().Unix())
+	if slot > currentSlot+earlySlotAllowance {
+		return reject(validationResultEarly, protocolp2p.ValidationRejectLow, nil)
+	}
+	allowance, ok := lateSlotAllowanceByRole[role]
+	if !ok {
+		allowance = lateSlotAllowance
+	}
+	if slot+allowance < currentSlot {
+		return ignore(validationResultLate)
+	}
+	return nil
+}
+
+// validateRound checks that the given round could have been reached since the beginning of the given slot
+func (mv *msgValidator) validateRound(slot phase0.Slot, round specqbft.Round) *validationError {
+	if round < specqbft.FirstRound {
+		return reject(validationResultRound, protocolp2p.ValidationRejectMedium, nil)
+	}
+	if mv.netCfg == nil {
+		return nil
+	}
+	elapsed := mv.now().Sub(mv.netCfg.Beacon.GetSlotStartTime(slot))
+	if round > estimatedRound(elapsed)+lateRoundAllowance {
+		return reject(validationResultRound, protocolp2p.ValidationRejectMedium, nil)
+	}
+	return nil
+}
+
+// estimatedRound returns the highest round an instance could have reached after the given duration
+func estimatedRound(elapsed time.Duration) specqbft.Round {
+	round := specqbft.FirstRound
+	for elapsed > 0 {
+		elapsed -= roundtimer.RoundTimeout(round)
+		if elapsed > 0 {
+			round++
+		}
+	}
+	return round
+}
+
+// isDuplicate returns true if a message with the same root was already seen at the given position
+func (mv *msgValidator) isDuplicate(key seenKey, root [32]byte) bool {
+	item := mv.seen.Get(key)
+	return item != nil && item.Value() == root
+}
+
+// markSeen remembers the root of an accepted message,
+// a different root at the same position means the signer equivocated
+func (mv *msgValidator) markSeen(key seenKey, root [32]byte) *validationError {
+	mv.seenLock.Lock()
+	defer mv.seenLock.Unlock()
+
+	if item := mv.seen.Get(key); item != nil {
+		if item.Value() != root {
+			return reject(validationResultEquivocation, protocolp2p.ValidationRejectHigh, nil)
+		}
+		return nil
+	}
+	mv.seen.Set(key, root, ttlcache.DefaultTTL)
+	return nil
+}
+
+// gcIfNeeded removes expired entries from the seen cache, at most once per seenCacheGCInterval
+func (mv *msgValidator) gcIfNeeded() {
+	now := mv.now().UnixNano()
+	last := atomic.LoadInt64(&mv.lastGC)
+	if now-last < int64(seenCacheGCInterval) || !atomic.CompareAndSwapInt64(&mv.lastGC, last, now) {
+		return
+	}
+	go mv.seen.DeleteExpired()
+}
+
+func signersInCommittee(share *types.SSVShare, signers ...spectypes.OperatorID) bool {
+	for _, signer := range signers {
+		found := false
+		for _, operator := range share.Committee {
+			if operator.OperatorID == signer {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func partialSigTypeAllowed(role spectypes.BeaconRole, msgType spectypes.PartialSigMsgType) bool {
+	for _, t := range partialSigTypesByRole[role] {
+		if t == msgType {
+			return true
+		}
+	}
+	return false
+}
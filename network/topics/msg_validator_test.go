@@ -5,15 +5,27 @@ import (
 	"encoding/hex"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	spectestingutils "github.com/bloxapp/ssv-spec/types/testingutils"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	ps_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bloxapp/ssv/logging"
 	"github.com/bloxapp/ssv/network/forks/genesis"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	protocolp2p "github.com/bloxapp/ssv/protocol/v2/p2p"
 	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
 	"github.com/bloxapp/ssv/utils/threshold"
 )
 
@@ -37,20 +49,19 @@ func TestMsgValidator(t *testing.T) {
 		require.Equal(t, res, pubsub.ValidationAccept)
 	})
 
-	// TODO: enable once topic validation is in place
-	// t.Run("wrong topic", func(t *testing.T) {
-	//	pkHex := "b5de683dbcb3febe8320cc741948b9282d59b75a6970ed55d6f389da59f26325331b7ea0e71a2552373d0debb6048b8a"
-	//	msg, err := dummySSVConsensusMsg(pkHex, 15160)
-	//	require.NoError(t, err)
-	//	raw, err := msg.Encode()
-	//	require.NoError(t, err)
-	//	pk, err := hex.DecodeString("a297599ccf617c3b6118bbd248494d7072bb8c6c1cc342ea442a289415987d306bad34415f89469221450a2501a832ec")
-	//	require.NoError(t, err)
-	//	topics := f.ValidatorTopicID(pk)
-	//	pmsg := newPBMsg(raw, topics[0], []byte("16Uiu2HAkyWQyCb6reWXGQeBUt9EXArk6h3aq3PsFMwLNq3pPGH1r"))
-	//	res := mv(context.Background(), "16Uiu2HAkyWQyCb6reWXGQeBUt9EXArk6h3aq3PsFMwLNq3pPGH1r", pmsg)
-	//	require.Equal(t, res, pubsub.ValidationReject)
-	// })
+	t.Run("wrong topic", func(t *testing.T) {
+		pkHex := "b5de683dbcb3febe8320cc741948b9282d59b75a6970ed55d6f389da59f26325331b7ea0e71a2552373d0debb6048b8a"
+		msg, err := dummySSVConsensusMsg(pkHex, 15160)
+		require.NoError(t, err)
+		raw, err := msg.Encode()
+		require.NoError(t, err)
+		pk, err := hex.DecodeString("a297599ccf617c3b6118bbd248494d7072bb8c6c1cc342ea442a289415987d306bad34415f89469221450a2501a832ec")
+		require.NoError(t, err)
+		topics := f.ValidatorTopicID(pk)
+		pmsg := newPBMsg(raw, f.GetTopicFullName(topics[0]), []byte("16Uiu2HAkyWQyCb6reWXGQeBUt9EXArk6h3aq3PsFMwLNq3pPGH1r"))
+		res := mv(context.Background(), "16Uiu2HAkyWQyCb6reWXGQeBUt9EXArk6h3aq3PsFMwLNq3pPGH1r", pmsg)
+		require.Equal(t, res, pubsub.ValidationReject)
+	})
 
 	t.Run("empty message", func(t *testing.T) {
 		pmsg := newPBMsg([]byte{}, "xxx", []byte{})
@@ -58,17 +69,151 @@ func TestMsgValidator(t *testing.T) {
 		require.Equal(t, res, pubsub.ValidationReject)
 	})
 
-	// TODO: enable once topic validation is in place
-	// t.Run("invalid validator public key", func(t *testing.T) {
-	//	msg, err := dummySSVConsensusMsg("10101011", 1)
-	//	require.NoError(t, err)
-	//	raw, err := msg.Encode()
-	//	require.NoError(t, err)
-	//	pmsg := newPBMsg(raw, "xxx", []byte{})
-	//	res := mv(context.Background(), "xxxx", pmsg)
-	//	require.Equal(t, res, pubsub.ValidationReject)
-	// })
+	t.Run("invalid validator public key", func(t *testing.T) {
+		msg, err := dummySSVConsensusMsg("10101011", 1)
+		require.NoError(t, err)
+		raw, err := msg.Encode()
+		require.NoError(t, err)
+		pmsg := newPBMsg(raw, "xxx", []byte{})
+		res := mv(context.Background(), "xxxx", pmsg)
+		require.Equal(t, res, pubsub.ValidationReject)
+	})
+}
+
+func TestMsgValidatorWithShares(t *testing.T) {
+	logger := logging.TestLogger(t)
+	ks := spectestingutils.Testing4SharesSet()
+	f := genesis.ForkGenesis{}
+
+	db, err := kv.New(logger, basedb.Options{Type: "badger-memory", Path: ""})
+	require.NoError(t, err)
+	defer db.Close(logger)
+	shares, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+	share := &types.SSVShare{Share: *spectestingutils.TestingShare(ks)}
+	require.NoError(t, shares.Save(share))
+
+	netCfg := networkconfig.NetworkConfig{
+		Beacon: beacon.NewNetwork(spectypes.PraterNetwork),
+		Domain: spectestingutils.TestingSSVDomainType,
+	}
+	const slot = phase0.Slot(1000)
+	now := netCfg.Beacon.GetSlotStartTime(slot).Add(time.Second)
+
+	var reported []protocolp2p.MsgValidationResult
+	mv := NewSSVMsgValidator(&f,
+		WithShares(shares),
+		WithNetworkConfig(netCfg),
+		WithValidationReporter(func(p peer.ID, res protocolp2p.MsgValidationResult) {
+			reported = append(reported, res)
+		}),
+		withClock(func() time.Time { return now }),
+	)
 
+	msgID := spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, spectypes.BNRoleAttester)
+	topic := f.GetTopicFullName(f.ValidatorTopicID(share.ValidatorPubKey)[0])
+
+	prepare := func(signer spectypes.OperatorID, height specqbft.Height, round specqbft.Round, root [32]byte) *specqbft.SignedMessage {
+		return spectestingutils.TestingPrepareMessageWithParams(ks.Shares[signer], signer, round, height, msgID[:], root)
+	}
+	validate := func(msgType spectypes.MsgType, id spectypes.MessageID, data interface{ Encode() ([]byte, error) }) pubsub.ValidationResult {
+		encoded, err := data.Encode()
+		require.NoError(t, err)
+		raw, err := (&spectypes.SSVMessage{MsgType: msgType, MsgID: id, Data: encoded}).Encode()
+		require.NoError(t, err)
+		return mv(context.Background(), "peer", newPBMsg(raw, topic, []byte("peer")))
+	}
+
+	t.Run("valid consensus msg", func(t *testing.T) {
+		msg := prepare(1, specqbft.Height(slot), specqbft.FirstRound, spectestingutils.TestingQBFTRootData)
+		require.Equal(t, pubsub.ValidationAccept, validate(spectypes.SSVConsensusMsgType, msgID, msg))
+	})
+
+	t.Run("duplicate consensus msg", func(t *testing.T) {
+		msg := prepare(1, specqbft.Height(slot), specqbft.FirstRound, spectestingutils.TestingQBFTRootData)
+		require.Equal(t, pubsub.ValidationIgnore, validate(spectypes.SSVConsensusMsgType, msgID, msg))
+	})
+
+	t.Run("equivocation", func(t *testing.T) {
+		msg := prepare(1, specqbft.Height(slot), specqbft.FirstRound, spectestingutils.DifferentRoot)
+		require.Equal(t, pubsub.ValidationReject, validate(spectypes.SSVConsensusMsgType, msgID, msg))
+	})
+
+	t.Run("valid partial signature msg", func(t *testing.T) {
+		msg := spectestingutils.PostConsensusAttestationMsg(ks.Shares[2], 2, specqbft.Height(slot))
+		msg.Message.Slot = slot
+		msg.Signature = signPartialSigMsg(t, ks, 2, &msg.Message)
+		require.Equal(t, pubsub.ValidationAccept, validate(spectypes.SSVPartialSignatureMsgType, msgID, msg))
+	})
+
+	t.Run("unknown validator", func(t *testing.T) {
+		id := spectypes.NewMsgID(netCfg.Domain, ks.Shares[1].GetPublicKey().Serialize(), spectypes.BNRoleAttester)
+		msg := prepare(1, specqbft.Height(slot), specqbft.FirstRound, spectestingutils.TestingQBFTRootData)
+		encoded, err := msg.Encode()
+		require.NoError(t, err)
+		raw, err := (&spectypes.SSVMessage{MsgType: spectypes.SSVConsensusMsgType, MsgID: id, Data: encoded}).Encode()
+		require.NoError(t, err)
+		unknownTopic := f.GetTopicFullName(f.ValidatorTopicID(id.GetPubKey())[0])
+		require.Equal(t, pubsub.ValidationIgnore, mv(context.Background(), "peer", newPBMsg(raw, unknownTopic, []byte("peer"))))
+	})
+
+	t.Run("signer not in committee", func(t *testing.T) {
+		msg := prepare(1, specqbft.Height(slot), 2, spectestingutils.TestingQBFTRootData)
+		msg.Signers = []spectypes.OperatorID{5}
+		require.Equal(t, pubsub.ValidationReject, validate(spectypes.SSVConsensusMsgType, msgID, msg))
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		msg := prepare(1, specqbft.Height(slot), 2, spectestingutils.TestingQBFTRootData)
+		msg.Signers = []spectypes.OperatorID{2}
+		require.Equal(t, pubsub.ValidationReject, validate(spectypes.SSVConsensusMsgType, msgID, msg))
+	})
+
+	t.Run("wrong domain", func(t *testing.T) {
+		id := spectypes.NewMsgID(spectypes.DomainType{0x1, 0x2, 0x3, 0x4}, share.ValidatorPubKey, spectypes.BNRoleAttester)
+		msg := prepare(3, specqbft.Height(slot), specqbft.FirstRound, spectestingutils.TestingQBFTRootData)
+		require.Equal(t, pubsub.ValidationReject, validate(spectypes.SSVConsensusMsgType, id, msg))
+	})
+
+	t.Run("late msg", func(t *testing.T) {
+		msg := prepare(3, specqbft.Height(slot-lateSlotAllowance-1), specqbft.FirstRound, spectestingutils.TestingQBFTRootData)
+		require.Equal(t, pubsub.ValidationIgnore, validate(spectypes.SSVConsensusMsgType, msgID, msg))
+	})
+
+	t.Run("early msg", func(t *testing.T) {
+		msg := prepare(3, specqbft.Height(slot+earlySlotAllowance+1), specqbft.FirstRound, spectestingutils.TestingQBFTRootData)
+		require.Equal(t, pubsub.ValidationReject, validate(spectypes.SSVConsensusMsgType, msgID, msg))
+	})
+
+	t.Run("round too high", func(t *testing.T) {
+		msg := prepare(3, specqbft.Height(slot), 10, spectestingutils.TestingQBFTRootData)
+		require.Equal(t, pubsub.ValidationReject, validate(spectypes.SSVConsensusMsgType, msgID, msg))
+	})
+
+	require.Equal(t, []protocolp2p.MsgValidationResult{
+		protocolp2p.ValidationRejectHigh,   // equivocation
+		protocolp2p.ValidationRejectHigh,   // signer not in committee
+		protocolp2p.ValidationRejectHigh,   // invalid signature
+		protocolp2p.ValidationRejectMedium, // wrong domain
+		protocolp2p.ValidationRejectLow,    // early msg
+		protocolp2p.ValidationRejectMedium, // round too high
+	}, reported)
+}
+
+func TestEstimatedRound(t *testing.T) {
+	require.Equal(t, specqbft.FirstRound, estimatedRound(0))
+	require.Equal(t, specqbft.FirstRound, estimatedRound(time.Second))
+	require.Equal(t, specqbft.Round(2), estimatedRound(3*time.Second))
+	require.Equal(t, specqbft.Round(9), estimatedRound(17*time.Second))
+}
+
+func signPartialSigMsg(t *testing.T, ks *spectestingutils.TestKeySet, signer spectypes.OperatorID, msg *spectypes.PartialSignatureMessages) spectypes.Signature {
+	for _, m := range msg.Messages {
+		m.Signer = signer
+	}
+	sig, err := spectestingutils.NewTestingKeyManager().SignRoot(msg, spectypes.PartialSignatureType, ks.Shares[signer].GetPublicKey().Serialize())
+	require.NoError(t, err)
+	return sig
 }
 
 func createSharePublicKeys(n int) []string {
@@ -9,6 +9,7 @@ import (
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 )
 
@@ -36,6 +37,8 @@ type NetworkConfig struct {
 	RegistryContractAddr    string
 	Bootnodes               []string
 	WhitelistedOperatorKeys []string
+	// Forks schedules the SSV forks of the network, the genesis fork is active until the first one
+	Forks forksprotocol.Schedule
 }
 
 func (n NetworkConfig) String() string {
@@ -52,6 +55,16 @@ func (n NetworkConfig) ForkVersion() [4]byte {
 	return n.Beacon.ForkVersion()
 }
 
+// SSVForkVersion returns the SSV fork version active at the given epoch.
+func (n NetworkConfig) SSVForkVersion(epoch spec.Epoch) forksprotocol.ForkVersion {
+	return n.Forks.VersionAt(epoch)
+}
+
+// GenesisValidatorsRoot returns the genesis validators root of the beacon network, or zero if it's unknown.
+func (n NetworkConfig) GenesisValidatorsRoot() spec.Root {
+	return n.Beacon.GenesisValidatorsRoot()
+}
+
 // SlotDurationSec returns slot duration
 func (n NetworkConfig) SlotDurationSec() time.Duration {
 	return n.Beacon.SlotDurationSec()
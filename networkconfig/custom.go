@@ -0,0 +1,168 @@
+package networkconfig
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+// CustomConfig is the file representation of a NetworkConfig,
+// used to run networks which aren't compiled into the node, such as private devnets.
+// It's read as YAML, which also accepts JSON with the same keys.
+type CustomConfig struct {
+	Name                    string                 `yaml:"Name"`
+	Beacon                  CustomBeaconConfig     `yaml:"Beacon"`
+	DomainType              hexutil.Bytes          `yaml:"DomainType"`
+	GenesisEpoch            spec.Epoch             `yaml:"GenesisEpoch"`
+	ETH1SyncOffset          uint64                 `yaml:"ETH1SyncOffset"`
+	RegistryContractAddr    string                 `yaml:"RegistryContractAddr"`
+	Bootnodes               []string               `yaml:"Bootnodes"`
+	WhitelistedOperatorKeys []string               `yaml:"WhitelistedOperatorKeys"`
+	Forks                   forksprotocol.Schedule `yaml:"Forks"`
+}
+
+// CustomBeaconConfig holds the parameters of the beacon chain network of a CustomConfig.
+type CustomBeaconConfig struct {
+	// Network is the spec network the custom network is based on, e.g. prater,
+	// it's used where the spec and the signer don't allow overriding the parameters.
+	// Since the signer's far future protection estimates the current slot by it, GenesisTime must not precede it.
+	Network               spectypes.BeaconNetwork `yaml:"Network"`
+	GenesisTime           uint64                  `yaml:"GenesisTime"`
+	GenesisForkVersion    hexutil.Bytes           `yaml:"GenesisForkVersion"`
+	GenesisValidatorsRoot hexutil.Bytes           `yaml:"GenesisValidatorsRoot"`
+	SecondsPerSlot        uint64                  `yaml:"SecondsPerSlot"`
+	SlotsPerEpoch         uint64                  `yaml:"SlotsPerEpoch"`
+}
+
+// LoadNetworkConfig reads and validates a custom network config from the given file.
+func LoadNetworkConfig(path string) (NetworkConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return NetworkConfig{}, errors.Wrap(err, "could not read network config")
+	}
+	var custom CustomConfig
+	if err := yaml.Unmarshal(data, &custom); err != nil {
+		return NetworkConfig{}, errors.Wrap(err, "could not decode network config")
+	}
+	if _, ok := SupportedConfigs[custom.Name]; ok {
+		return NetworkConfig{}, fmt.Errorf("network name %q is already taken by a supported network", custom.Name)
+	}
+	return custom.NetworkConfig()
+}
+
+// NewCustomConfig returns the file representation of the given network config.
+func NewCustomConfig(n NetworkConfig) CustomConfig {
+	gvr := n.GenesisValidatorsRoot()
+	fv := n.ForkVersion()
+	c := CustomConfig{
+		Name: n.Name,
+		Beacon: CustomBeaconConfig{
+			Network:               n.Beacon.BeaconNetwork,
+			GenesisTime:           n.Beacon.MinGenesisTime(),
+			GenesisForkVersion:    fv[:],
+			GenesisValidatorsRoot: gvr[:],
+			SecondsPerSlot:        uint64(n.SlotDurationSec() / time.Second),
+			SlotsPerEpoch:         n.SlotsPerEpoch(),
+		},
+		DomainType:              n.Domain[:],
+		GenesisEpoch:            n.GenesisEpoch,
+		RegistryContractAddr:    n.RegistryContractAddr,
+		Bootnodes:               n.Bootnodes,
+		WhitelistedOperatorKeys: n.WhitelistedOperatorKeys,
+		Forks:                   n.Forks,
+	}
+	if n.ETH1SyncOffset != nil {
+		c.ETH1SyncOffset = n.ETH1SyncOffset.Uint64()
+	}
+	return c
+}
+
+// NetworkConfig validates the custom config and converts it into a NetworkConfig.
+func (c CustomConfig) NetworkConfig() (NetworkConfig, error) {
+	if err := c.validate(); err != nil {
+		return NetworkConfig{}, errors.Wrap(err, "invalid network config")
+	}
+
+	params := beacon.CustomParams{
+		GenesisTime:   c.Beacon.GenesisTime,
+		SlotDuration:  time.Duration(c.Beacon.SecondsPerSlot) * time.Second,
+		SlotsPerEpoch: c.Beacon.SlotsPerEpoch,
+	}
+	copy(params.GenesisForkVersion[:], c.Beacon.GenesisForkVersion)
+	copy(params.GenesisValidatorsRoot[:], c.Beacon.GenesisValidatorsRoot)
+
+	n := NetworkConfig{
+		Name:                    c.Name,
+		Beacon:                  beacon.NewCustomNetwork(c.Beacon.Network, params),
+		GenesisEpoch:            c.GenesisEpoch,
+		RegistryContractAddr:    c.RegistryContractAddr,
+		Bootnodes:               c.Bootnodes,
+		WhitelistedOperatorKeys: c.WhitelistedOperatorKeys,
+		Forks:                   c.Forks,
+	}
+	copy(n.Domain[:], c.DomainType)
+	if c.ETH1SyncOffset != 0 {
+		n.ETH1SyncOffset = new(big.Int).SetUint64(c.ETH1SyncOffset)
+	}
+	return n, nil
+}
+
+func (c CustomConfig) validate() error {
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	if c.Beacon.Network == "" {
+		return errors.New("beacon network is required")
+	}
+	base := spectypes.NetworkFromString(string(c.Beacon.Network))
+	if base == "" {
+		return fmt.Errorf("unknown beacon network %q", c.Beacon.Network)
+	}
+	if c.Beacon.GenesisTime == 0 {
+		return errors.New("beacon genesis time is required")
+	}
+	// the signer estimates the current slot from the base network's genesis, so an earlier genesis
+	// would get the duties of the custom network rejected as far future duties
+	if c.Beacon.GenesisTime < base.MinGenesisTime() {
+		return fmt.Errorf("beacon genesis time must not precede the genesis of %s (%d)", base, base.MinGenesisTime())
+	}
+	if len(c.Beacon.GenesisForkVersion) != 4 {
+		return fmt.Errorf("beacon genesis fork version must be 4 bytes, got %d", len(c.Beacon.GenesisForkVersion))
+	}
+	if len(c.Beacon.GenesisValidatorsRoot) != 0 && len(c.Beacon.GenesisValidatorsRoot) != 32 {
+		return fmt.Errorf("beacon genesis validators root must be 32 bytes, got %d", len(c.Beacon.GenesisValidatorsRoot))
+	}
+	if c.Beacon.SecondsPerSlot == 0 {
+		return errors.New("beacon seconds per slot must be positive")
+	}
+	if c.Beacon.SlotsPerEpoch == 0 {
+		return errors.New("beacon slots per epoch must be positive")
+	}
+	if len(c.DomainType) != 4 {
+		return fmt.Errorf("domain type must be 4 bytes, got %d", len(c.DomainType))
+	}
+	if !common.IsHexAddress(c.RegistryContractAddr) {
+		return fmt.Errorf("invalid registry contract address %q", c.RegistryContractAddr)
+	}
+	for _, bootnode := range c.Bootnodes {
+		if !strings.HasPrefix(bootnode, "enr:") {
+			return fmt.Errorf("bootnode %q is not an ENR", bootnode)
+		}
+	}
+	if err := c.Forks.Validate(); err != nil {
+		return errors.Wrap(err, "invalid fork schedule")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package networkconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+)
+
+func TestLoadNetworkConfig(t *testing.T) {
+	custom := NewCustomConfig(JatoV2)
+	custom.Name = "devnet"
+	custom.Beacon.GenesisTime = 1700000000
+	custom.Beacon.SecondsPerSlot = 6
+	custom.Beacon.SlotsPerEpoch = 8
+	custom.Forks = forksprotocol.Schedule{{Version: forksprotocol.V3ForkVersion, Epoch: 10}}
+
+	path := writeCustomConfig(t, custom)
+	n, err := LoadNetworkConfig(path)
+	require.NoError(t, err)
+
+	require.Equal(t, "devnet", n.Name)
+	require.Equal(t, JatoV2.Domain, n.Domain)
+	require.Equal(t, JatoV2.ForkVersion(), n.ForkVersion())
+	require.Equal(t, JatoV2.GenesisValidatorsRoot(), n.GenesisValidatorsRoot())
+	require.Equal(t, JatoV2.ETH1SyncOffset, n.ETH1SyncOffset)
+	require.Equal(t, JatoV2.Bootnodes, n.Bootnodes)
+	require.Equal(t, uint64(1700000000), n.Beacon.MinGenesisTime())
+	require.Equal(t, 6*time.Second, n.SlotDurationSec())
+	require.Equal(t, uint64(8), n.SlotsPerEpoch())
+	require.Equal(t, time.Unix(1700000000+6*8*2, 0), n.Beacon.EpochStartTime(2))
+	require.Equal(t, forksprotocol.V3ForkVersion, n.SSVForkVersion(10))
+}
+
+func TestLoadNetworkConfig_Invalid(t *testing.T) {
+	tests := map[string]func(c *CustomConfig){
+		"taken name":         func(c *CustomConfig) { c.Name = Mainnet.Name },
+		"no slot duration":   func(c *CustomConfig) { c.Beacon.SecondsPerSlot = 0 },
+		"no slots per epoch": func(c *CustomConfig) { c.Beacon.SlotsPerEpoch = 0 },
+		"early genesis":      func(c *CustomConfig) { c.Beacon.GenesisTime = 1 },
+		"unknown network":    func(c *CustomConfig) { c.Beacon.Network = "holesky" },
+		"short fork version": func(c *CustomConfig) { c.Beacon.GenesisForkVersion = []byte{0x1} },
+		"short domain":       func(c *CustomConfig) { c.DomainType = []byte{0x1, 0x2} },
+		"bad contract":       func(c *CustomConfig) { c.RegistryContractAddr = "0x1234" },
+		"bad bootnode":       func(c *CustomConfig) { c.Bootnodes = []string{"enode://abc"} },
+		"bad forks": func(c *CustomConfig) {
+			c.Forks = forksprotocol.Schedule{{Version: "v9", Epoch: 1}}
+		},
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			custom := NewCustomConfig(JatoV2)
+			custom.Name = "devnet"
+			mutate(&custom)
+
+			_, err := LoadNetworkConfig(writeCustomConfig(t, custom))
+			require.Error(t, err)
+		})
+	}
+}
+
+func writeCustomConfig(t *testing.T, custom CustomConfig) string {
+	data, err := yaml.Marshal(custom)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "network.yaml")
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
@@ -18,6 +18,19 @@ type StatusChecker interface {
 	IsReady(ctx context.Context) (bool, error)
 }
 
+// NodeStatus is the last known status of a single node behind a MultiNodeChecker
+type NodeStatus struct {
+	Name  string
+	Ready bool
+	Err   error
+}
+
+// MultiNodeChecker is a StatusChecker of several nodes which can report the status of each of them
+type MultiNodeChecker interface {
+	StatusChecker
+	NodeStatuses() []NodeStatus
+}
+
 type Prober struct {
 	logger         *zap.Logger
 	interval       time.Duration
@@ -101,6 +114,10 @@ func (p *Prober) probe(ctx context.Context) {
 			} else {
 				p.logger.Info("node is ready", nodeKind)
 			}
+
+			if multiNode, ok := node.(MultiNodeChecker); ok {
+				p.logNodeStatuses(nodeKind, multiNode.NodeStatuses())
+			}
 		}(node)
 	}
 	wg.Wait()
@@ -125,6 +142,19 @@ func (p *Prober) probe(ctx context.Context) {
 	p.cond.Broadcast()
 }
 
+func (p *Prober) logNodeStatuses(nodeKind zap.Field, statuses []NodeStatus) {
+	for _, status := range statuses {
+		nodeName := zap.String("node", status.Name)
+		if status.Err != nil {
+			p.logger.Warn("failed to check if node is ready", nodeKind, nodeName, zap.Error(status.Err))
+		} else if !status.Ready {
+			p.logger.Warn("node is not ready", nodeKind, nodeName)
+		} else {
+			p.logger.Debug("node is ready", nodeKind, nodeName)
+		}
+	}
+}
+
 func (p *Prober) Wait() {
 	p.logger.Info("waiting until nodes are ready")
 
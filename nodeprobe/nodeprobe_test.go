@@ -2,12 +2,14 @@ package nodeprobe
 
 import (
 	"context"
+	"errors"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestProber(t *testing.T) {
@@ -54,3 +56,41 @@ type statusChecker struct {
 func (sc *statusChecker) IsReady(context.Context) (bool, error) {
 	return sc.ready.Load(), nil
 }
+
+func TestProberMultiNode(t *testing.T) {
+	ctx := context.Background()
+
+	core, logs := observer.New(zap.DebugLevel)
+	checker := &multiNodeChecker{statuses: []NodeStatus{
+		{Name: "node-1", Ready: true},
+		{Name: "node-2", Err: errors.New("connection refused")},
+	}}
+	checker.ready.Store(true)
+
+	prober := NewProber(zap.New(core), checker)
+	prober.probe(ctx)
+
+	ready, err := prober.IsReady(ctx)
+	require.NoError(t, err)
+	require.True(t, ready)
+
+	reported := map[string]string{}
+	for _, entry := range logs.All() {
+		if node, ok := entry.ContextMap()["node"]; ok {
+			reported[node.(string)] = entry.Message
+		}
+	}
+	require.Equal(t, map[string]string{
+		"node-1": "node is ready",
+		"node-2": "failed to check if node is ready",
+	}, reported)
+}
+
+type multiNodeChecker struct {
+	statusChecker
+	statuses []NodeStatus
+}
+
+func (mc *multiNodeChecker) NodeStatuses() []NodeStatus {
+	return mc.statuses
+}
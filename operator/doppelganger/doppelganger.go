@@ -0,0 +1,199 @@
+package doppelganger
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.uber.org/zap"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+// Config holds the configuration of the doppelganger protection
+type Config struct {
+	Enabled bool   `yaml:"Enabled" env:"DOPPELGANGER_PROTECTION" env-default:"false" env-description:"Hold back the duties of started validators until they weren't seen live elsewhere for a number of epochs"`
+	Epochs  uint64 `yaml:"Epochs" env:"DOPPELGANGER_EPOCHS" env-default:"2" env-description:"Amount of epochs to observe the liveness of started validators for"`
+}
+
+// LivenessProvider reports whether validators were seen live in an epoch
+type LivenessProvider interface {
+	ValidatorLiveness(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*beaconprotocol.ValidatorLiveness, error)
+}
+
+// Options holds the needed dependencies of the handler
+type Options struct {
+	Ctx     context.Context
+	Beacon  LivenessProvider
+	Network beaconprotocol.Network
+	Config  Config
+}
+
+// Status is the doppelganger protection state of a validator
+type Status string
+
+const (
+	// StatusObserving is a validator whose liveness is being observed, its duties are held back
+	StatusObserving Status = "observing"
+	// StatusSafe is a validator which wasn't seen live elsewhere, its duties are allowed
+	StatusSafe Status = "safe"
+	// StatusDetected is a validator which was seen live elsewhere, its duties are held back until the node restarts
+	StatusDetected Status = "detected"
+)
+
+// ValidatorStatus is the doppelganger protection state of a validator
+type ValidatorStatus struct {
+	Status          Status       `json:"status"`
+	RemainingEpochs uint64       `json:"remaining_epochs"`
+	DetectedEpoch   phase0.Epoch `json:"detected_epoch,omitempty"`
+}
+
+// observed is a validator whose liveness is observed
+type observed struct {
+	ValidatorStatus
+	// nextEpoch is the next epoch to check the liveness of
+	nextEpoch phase0.Epoch
+	// clusterDecided reports whether the validator's own cluster decided a duty in the given epoch or later,
+	// which explains its liveness when the rest of the cluster signs without this node
+	clusterDecided func(epoch phase0.Epoch) bool
+}
+
+// Handler observes the liveness of started validators, and holds back their duties until
+// they weren't seen live elsewhere for the configured amount of epochs.
+type Handler struct {
+	ctx     context.Context
+	beacon  LivenessProvider
+	network beaconprotocol.Network
+	config  Config
+
+	mu         sync.Mutex
+	validators map[phase0.ValidatorIndex]*observed
+}
+
+// New creates a new handler
+func New(opts Options) *Handler {
+	return &Handler{
+		ctx:        opts.Ctx,
+		beacon:     opts.Beacon,
+		network:    opts.Network,
+		config:     opts.Config,
+		validators: map[phase0.ValidatorIndex]*observed{},
+	}
+}
+
+// Start checks the liveness of the observed validators once per epoch, blocks until the context is done
+func (h *Handler) Start(logger *zap.Logger) {
+	logger = logger.Named("Doppelganger")
+
+	ticker := time.NewTicker(h.network.SlotDurationSec())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.ctx.Done():
+			return
+		case <-ticker.C:
+			currentEpoch := h.network.EstimatedCurrentEpoch()
+			if currentEpoch == 0 {
+				continue
+			}
+			if err := h.check(logger, currentEpoch-1); err != nil {
+				logger.Warn("could not check validators liveness", zap.Error(err))
+			}
+		}
+	}
+}
+
+// StartObserving holds back the duties of the validator until it wasn't seen live elsewhere for the configured epochs.
+// The epoch in which it starts isn't observed, since the validator may have signed in it before a restart.
+func (h *Handler) StartObserving(index phase0.ValidatorIndex, clusterDecided func(epoch phase0.Epoch) bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	status := StatusObserving
+	if h.config.Epochs == 0 {
+		status = StatusSafe
+	}
+	h.validators[index] = &observed{
+		ValidatorStatus: ValidatorStatus{Status: status, RemainingEpochs: h.config.Epochs},
+		nextEpoch:       h.network.EstimatedCurrentEpoch() + 1,
+		clusterDecided:  clusterDecided,
+	}
+}
+
+// StopObserving forgets the validator
+func (h *Handler) StopObserving(index phase0.ValidatorIndex) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.validators, index)
+}
+
+// CanSign returns true if the validator is cleared to execute duties
+func (h *Handler) CanSign(index phase0.ValidatorIndex) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	v, ok := h.validators[index]
+	return ok && v.Status == StatusSafe
+}
+
+// Status returns the doppelganger protection state of the validator, if it's observed
+func (h *Handler) Status(index phase0.ValidatorIndex) (ValidatorStatus, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	v, ok := h.validators[index]
+	if !ok {
+		return ValidatorStatus{}, false
+	}
+	return v.ValidatorStatus, true
+}
+
+// check observes the liveness in the given epoch of the validators due for it
+func (h *Handler) check(logger *zap.Logger, epoch phase0.Epoch) error {
+	h.mu.Lock()
+	var indices []phase0.ValidatorIndex
+	for index, v := range h.validators {
+		if v.Status == StatusObserving && v.nextEpoch <= epoch {
+			indices = append(indices, index)
+		}
+	}
+	h.mu.Unlock()
+	if len(indices) == 0 {
+		return nil
+	}
+
+	liveness, err := h.beacon.ValidatorLiveness(epoch, indices)
+	if err != nil {
+		return err
+	}
+	live := make(map[phase0.ValidatorIndex]bool, len(liveness))
+	for _, l := range liveness {
+		live[l.Index] = l.IsLive
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, index := range indices {
+		v, ok := h.validators[index]
+		if !ok || v.Status != StatusObserving || v.nextEpoch > epoch {
+			continue
+		}
+		v.nextEpoch = epoch + 1
+		if live[index] && (v.clusterDecided == nil || !v.clusterDecided(epoch)) {
+			v.Status = StatusDetected
+			v.DetectedEpoch = epoch
+			logger.Error("🚨 doppelganger detected, the validator is live elsewhere and its duties are held back",
+				zap.Uint64("validator_index", uint64(index)), zap.Uint64("epoch", uint64(epoch)))
+			continue
+		}
+		v.RemainingEpochs--
+		if v.RemainingEpochs == 0 {
+			v.Status = StatusSafe
+			logger.Info("validator passed doppelganger protection", zap.Uint64("validator_index", uint64(index)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package doppelganger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/networkconfig"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+type testLiveness struct {
+	live  map[phase0.ValidatorIndex]bool
+	err   error
+	calls int
+}
+
+func (l *testLiveness) ValidatorLiveness(epoch phase0.Epoch, indices []phase0.ValidatorIndex) ([]*beaconprotocol.ValidatorLiveness, error) {
+	l.calls++
+	if l.err != nil {
+		return nil, l.err
+	}
+	res := make([]*beaconprotocol.ValidatorLiveness, len(indices))
+	for i, index := range indices {
+		res[i] = &beaconprotocol.ValidatorLiveness{Index: index, IsLive: l.live[index]}
+	}
+	return res, nil
+}
+
+func TestHandler(t *testing.T) {
+	logger := logging.TestLogger(t)
+	network := networkconfig.TestNetwork.Beacon
+	liveness := &testLiveness{live: map[phase0.ValidatorIndex]bool{2: true, 3: true}}
+	h := New(Options{
+		Ctx:     context.Background(),
+		Beacon:  liveness,
+		Network: network,
+		Config:  Config{Enabled: true, Epochs: 2},
+	})
+	epoch := network.EstimatedCurrentEpoch()
+
+	h.StartObserving(1, nil)
+	// live because its cluster signs
+	h.StartObserving(2, func(e phase0.Epoch) bool { return true })
+	// live elsewhere
+	h.StartObserving(3, func(e phase0.Epoch) bool { return false })
+	for _, index := range []phase0.ValidatorIndex{1, 2, 3} {
+		require.False(t, h.CanSign(index))
+	}
+	require.False(t, h.CanSign(4))
+
+	// the current epoch isn't observed
+	require.NoError(t, h.check(logger, epoch))
+	require.Zero(t, liveness.calls)
+
+	// failed checks are retried
+	liveness.err = errors.New("unavailable")
+	require.Error(t, h.check(logger, epoch+1))
+	status, ok := h.Status(1)
+	require.True(t, ok)
+	require.Equal(t, ValidatorStatus{Status: StatusObserving, RemainingEpochs: 2}, status)
+	liveness.err = nil
+
+	require.NoError(t, h.check(logger, epoch+1))
+	status, _ = h.Status(1)
+	require.Equal(t, ValidatorStatus{Status: StatusObserving, RemainingEpochs: 1}, status)
+	status, _ = h.Status(3)
+	require.Equal(t, ValidatorStatus{Status: StatusDetected, RemainingEpochs: 2, DetectedEpoch: epoch + 1}, status)
+
+	// an epoch is checked once
+	require.NoError(t, h.check(logger, epoch+1))
+	status, _ = h.Status(1)
+	require.Equal(t, uint64(1), status.RemainingEpochs)
+
+	require.NoError(t, h.check(logger, epoch+2))
+	require.True(t, h.CanSign(1))
+	require.True(t, h.CanSign(2))
+	require.False(t, h.CanSign(3))
+
+	h.StopObserving(1)
+	require.False(t, h.CanSign(1))
+	_, ok = h.Status(1)
+	require.False(t, ok)
+}
+
+func TestHandlerNoEpochs(t *testing.T) {
+	h := New(Options{
+		Ctx:     context.Background(),
+		Beacon:  &testLiveness{},
+		Network: networkconfig.TestNetwork.Beacon,
+		Config:  Config{Enabled: true},
+	})
+	h.StartObserving(1, nil)
+	require.True(t, h.CanSign(1))
+}
@@ -4,14 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/cornelk/hashmap"
-	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/beacon/goclient"
@@ -22,7 +20,6 @@ import (
 	"github.com/bloxapp/ssv/operator/validator"
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
-	"github.com/bloxapp/ssv/protocol/v2/message"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
 	"github.com/bloxapp/ssv/protocol/v2/types"
 )
@@ -148,7 +145,7 @@ func (dc *dutyController) ExecuteDuty(logger *zap.Logger, duty *spectypes.Duty)
 	copy(pk[:], duty.PubKey[:])
 
 	if v, ok := dc.validatorController.GetValidator(hex.EncodeToString(pk[:])); ok {
-		ssvMsg, err := CreateDutyExecuteMsg(duty, pk, dc.network.Domain)
+		ssvMsg, err := types.CreateDutyExecuteMsg(duty, pk, dc.network.Domain)
 		if err != nil {
 			return err
 		}
@@ -167,28 +164,6 @@ func (dc *dutyController) ExecuteDuty(logger *zap.Logger, duty *spectypes.Duty)
 	return nil
 }
 
-// CreateDutyExecuteMsg returns ssvMsg with event type of duty execute
-func CreateDutyExecuteMsg(duty *spectypes.Duty, pubKey phase0.BLSPubKey, domain spectypes.DomainType) (*spectypes.SSVMessage, error) {
-	executeDutyData := types.ExecuteDutyData{Duty: duty}
-	edd, err := json.Marshal(executeDutyData)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to marshal execute duty data")
-	}
-	msg := types.EventMsg{
-		Type: types.ExecuteDuty,
-		Data: edd,
-	}
-	data, err := msg.Encode()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to encode event msg")
-	}
-	return &spectypes.SSVMessage{
-		MsgType: message.SSVEventMsgType,
-		MsgID:   spectypes.NewMsgID(domain, pubKey[:], duty.Type),
-		Data:    data,
-	}, nil
-}
-
 // HandleHeadEvent handles the "head" events from the beacon node.
 func (dc *dutyController) HandleHeadEvent(logger *zap.Logger) func(event *eth2apiv1.Event) {
 	return func(event *eth2apiv1.Event) {
@@ -7,13 +7,14 @@ import (
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 )
 
-// getForkVersion returns the fork version of the given slot
+// getForkVersion returns the fork version of the given slot, according to the fork schedule of the network
 func (n *operatorNode) getForkVersion(slot phase0.Slot) forksprotocol.ForkVersion {
 	epoch := n.network.Beacon.EstimatedEpochAtSlot(slot)
-	return forksprotocol.GetCurrentForkVersion(epoch)
+	return n.network.SSVForkVersion(epoch)
 }
 
-// listenForCurrentSlot updates forkVersion and checks if a fork is needed
+// setFork updates forkVersion and forks the network and the validators (storage and runners)
+// once the slot crosses the activation epoch of a scheduled fork
 func (n *operatorNode) setFork(logger *zap.Logger, slot phase0.Slot) {
 	currentVersion := n.getForkVersion(slot)
 	if currentVersion == n.forkVersion {
@@ -34,12 +35,17 @@ func (n *operatorNode) setFork(logger *zap.Logger, slot phase0.Slot) {
 		logger.Panic("could not fork network", zap.Error(err))
 	}
 
+	// set exporter storage fork
+	if err := n.qbftStorage.OnFork(logger, currentVersion); err != nil {
+		logger.Panic("could not fork storage", zap.Error(err))
+	}
+
 	// set validator controller fork
 	vCtrlHandler, ok := n.validatorsCtrl.(forksprotocol.ForkHandler)
 	if !ok {
-		logger.Panic("network instance is not a fork handler")
+		logger.Panic("validator controller is not a fork handler")
 	}
 	if err := vCtrlHandler.OnFork(logger, currentVersion); err != nil {
-		logger.Panic("could not fork network", zap.Error(err))
+		logger.Panic("could not fork validator controller", zap.Error(err))
 	}
 }
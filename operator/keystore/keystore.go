@@ -0,0 +1,127 @@
+package keystore
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/bloxapp/eth2-key-manager/encryptor/keystorev4"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+)
+
+// Version is the version of the keystore format, following EIP-2335
+const Version = 4
+
+// KeyStore is an EIP-2335 style keystore of an operator private key.
+// Unlike EIP-2335, the secret is the PEM encoded RSA private key and PubKey is its base64 PEM encoded public key.
+type KeyStore struct {
+	Crypto      map[string]interface{} `json:"crypto"`
+	PubKey      string                 `json:"pubKey"`
+	Version     int                    `json:"version"`
+	UUID        string                 `json:"uuid"`
+	Description string                 `json:"description,omitempty"`
+}
+
+// Encrypt encrypts the given PEM encoded operator private key with the password
+func Encrypt(skPem []byte, password string) (*KeyStore, error) {
+	sk, err := rsaencryption.ConvertPemToPrivateKey(string(skPem))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid operator private key")
+	}
+	pubKey, err := rsaencryption.ExtractPublicKey(sk)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not extract operator public key")
+	}
+	crypto, err := keystorev4.New(keystorev4.WithCipher("pbkdf2")).Encrypt(skPem, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encrypt operator private key")
+	}
+	return &KeyStore{
+		Crypto:      crypto,
+		PubKey:      pubKey,
+		Version:     Version,
+		UUID:        uuid.New().String(),
+		Description: "ssv operator private key",
+	}, nil
+}
+
+// Decrypt decrypts the operator private key and verifies that it matches the public key of the keystore
+func Decrypt(ks *KeyStore, password string) (*rsa.PrivateKey, error) {
+	if ks.Version != Version {
+		return nil, errors.Errorf("unsupported keystore version %d", ks.Version)
+	}
+	skPem, err := keystorev4.New().Decrypt(ks.Crypto, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decrypt operator private key")
+	}
+	sk, err := rsaencryption.ConvertPemToPrivateKey(string(skPem))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid operator private key")
+	}
+	pubKey, err := rsaencryption.ExtractPublicKey(sk)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not extract operator public key")
+	}
+	if pubKey != ks.PubKey {
+		return nil, errors.New("operator private key does not match the public key of the keystore")
+	}
+	return sk, nil
+}
+
+// Load reads and decrypts a keystore file with the password in the password file
+func Load(keystorePath, passwordPath string) (*rsa.PrivateKey, error) {
+	password, err := ReadPassword(passwordPath)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(keystorePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read keystore file")
+	}
+	ks := &KeyStore{}
+	if err := json.Unmarshal(raw, ks); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal keystore file")
+	}
+	return Decrypt(ks, password)
+}
+
+// Save encrypts the operator private key into a new keystore file, it refuses to overwrite an existing file
+func Save(keystorePath string, skPem []byte, password string) (*KeyStore, error) {
+	ks, err := Encrypt(skPem, password)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.MarshalIndent(ks, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal keystore")
+	}
+	f, err := os.OpenFile(keystorePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create keystore file")
+	}
+	if _, err := f.Write(raw); err != nil {
+		_ = f.Close()
+		return nil, errors.Wrap(err, "could not write keystore file")
+	}
+	if err := f.Close(); err != nil {
+		return nil, errors.Wrap(err, "could not write keystore file")
+	}
+	return ks, nil
+}
+
+// ReadPassword reads a password file, ignoring trailing line breaks
+func ReadPassword(passwordPath string) (string, error) {
+	raw, err := os.ReadFile(passwordPath)
+	if err != nil {
+		return "", errors.Wrap(err, "could not read password file")
+	}
+	password := strings.TrimRight(string(raw), "\r\n")
+	if password == "" {
+		return "", errors.New("password file is empty")
+	}
+	return password, nil
+}
@@ -0,0 +1,72 @@
+package keystore
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+)
+
+func TestKeyStore(t *testing.T) {
+	dir := t.TempDir()
+	pk, skPem, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	passwordPath := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(passwordPath, []byte("secret\n"), 0600))
+	keystorePath := filepath.Join(dir, "encrypted_private_key.json")
+
+	ks, err := Save(keystorePath, skPem, "secret")
+	require.NoError(t, err)
+
+	raw, err := os.ReadFile(keystorePath)
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), "PRIVATE KEY")
+
+	sk, err := Load(keystorePath, passwordPath)
+	require.NoError(t, err)
+	pubKey, err := rsaencryption.ExtractPublicKey(sk)
+	require.NoError(t, err)
+	require.Equal(t, ks.PubKey, pubKey)
+	decodedPubKey, err := rsaencryption.ConvertPemToPublicKey(pk)
+	require.NoError(t, err)
+	require.Equal(t, decodedPubKey, &sk.PublicKey)
+
+	t.Run("existing file", func(t *testing.T) {
+		_, err := Save(keystorePath, skPem, "secret")
+		require.ErrorContains(t, err, "could not create keystore file")
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		_, err := Decrypt(ks, "wrong")
+		require.ErrorContains(t, err, "could not decrypt operator private key")
+	})
+
+	t.Run("public key mismatch", func(t *testing.T) {
+		other, err := Encrypt(skPem, "secret")
+		require.NoError(t, err)
+		other.PubKey = "other"
+		_, err = Decrypt(other, "secret")
+		require.ErrorContains(t, err, "does not match")
+	})
+
+	t.Run("empty password", func(t *testing.T) {
+		emptyPath := filepath.Join(dir, "empty")
+		require.NoError(t, os.WriteFile(emptyPath, []byte("\n"), 0600))
+		_, err := Load(keystorePath, emptyPath)
+		require.ErrorContains(t, err, "password file is empty")
+	})
+
+	t.Run("version", func(t *testing.T) {
+		raw, err := json.Marshal(ks)
+		require.NoError(t, err)
+		other := &KeyStore{}
+		require.NoError(t, json.Unmarshal(raw, other))
+		other.Version = 3
+		_, err = Decrypt(other, "secret")
+		require.ErrorContains(t, err, "unsupported keystore version")
+	})
+}
@@ -36,7 +36,10 @@ type Node interface {
 // Options contains options to create the node
 type Options struct {
 	// NetworkName is the network name of this node
-	NetworkName         string `yaml:"Network" env:"NETWORK" env-default:"mainnet" env-description:"Network is the network of this node"`
+	NetworkName string `yaml:"Network" env:"NETWORK" env-default:"mainnet" env-description:"Network is the network of this node"`
+	// NetworkConfigPath is a custom network config file, used instead of NetworkName when set
+	NetworkConfigPath string `yaml:"NetworkConfigPath" env:"NETWORK_CONFIG_PATH" env-description:"Path to a YAML/JSON custom network config, overrides Network when set"`
+
 	Network             networkconfig.NetworkConfig
 	BeaconNode          beaconprotocol.BeaconNode
 	Eth1Client          eth1.Client
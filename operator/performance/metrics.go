@@ -0,0 +1,43 @@
+package performance
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	metricsParticipated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:operator:performance:participated",
+		Help: "Count of decided heights the operator signed",
+	}, []string{"operator_id", "role"})
+	metricsMissed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:operator:performance:missed",
+		Help: "Count of decided heights the operator didn't sign",
+	}, []string{"operator_id", "role"})
+	metricsParticipationRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:operator:performance:participation_rate",
+		Help: "Ratio of decided heights the operator signed over the rolling window",
+	}, []string{"operator_id", "role"})
+	metricsRoundsToDecide = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ssv:operator:performance:rounds_to_decide",
+		Help:    "Rounds it took to decide",
+		Buckets: []float64{1, 2, 3, 4, 6, 8, 12},
+	}, []string{"role"})
+)
+
+func init() {
+	if err := prometheus.Register(metricsParticipated); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsMissed); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsParticipationRate); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsRoundsToDecide); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+}
@@ -0,0 +1,113 @@
+package performance
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+var storagePrefix = []byte("performance/")
+
+// Stats holds the participation counters of an operator in a role
+type Stats struct {
+	// Decided is the amount of decided heights in which the operator was part of the committee
+	Decided uint64 `json:"decided"`
+	// Participated is the amount of decided heights the operator signed
+	Participated uint64 `json:"participated"`
+	// Missed is the amount of decided heights the operator didn't sign
+	Missed uint64 `json:"missed"`
+	// Rounds is the sum of the rounds it took to decide
+	Rounds uint64 `json:"rounds"`
+}
+
+// Add adds the given counters
+func (s *Stats) Add(other *Stats) {
+	s.Decided += other.Decided
+	s.Participated += other.Participated
+	s.Missed += other.Missed
+	s.Rounds += other.Rounds
+}
+
+// statsKey identifies the counters of an operator in a role
+type statsKey struct {
+	OperatorID spectypes.OperatorID
+	Role       spectypes.BeaconRole
+}
+
+func (k statsKey) bytes() []byte {
+	b := make([]byte, 12)
+	binary.BigEndian.PutUint64(b, k.OperatorID)
+	binary.BigEndian.PutUint32(b[8:], uint32(k.Role))
+	return b
+}
+
+func statsKeyFromBytes(b []byte) (statsKey, error) {
+	if len(b) != 12 {
+		return statsKey{}, errors.Errorf("invalid key length %d", len(b))
+	}
+	return statsKey{
+		OperatorID: binary.BigEndian.Uint64(b),
+		Role:       spectypes.BeaconRole(binary.BigEndian.Uint32(b[8:])),
+	}, nil
+}
+
+// epochStats holds the counters of all operators in a single epoch
+type epochStats map[statsKey]*Stats
+
+// storage persists epoch counters, keyed by epoch and then by operator and role
+type storage struct {
+	db basedb.IDb
+}
+
+func epochPrefix(epoch phase0.Epoch) []byte {
+	b := make([]byte, len(storagePrefix)+8)
+	copy(b, storagePrefix)
+	binary.BigEndian.PutUint64(b[len(storagePrefix):], uint64(epoch))
+	return b
+}
+
+// loadEpoch returns the counters of the given epoch
+func (s *storage) loadEpoch(logger *zap.Logger, epoch phase0.Epoch) (epochStats, error) {
+	res := epochStats{}
+	prefix := epochPrefix(epoch)
+	err := s.db.GetAll(logger, prefix, func(i int, obj basedb.Obj) error {
+		key, err := statsKeyFromBytes(obj.Key)
+		if err != nil {
+			return err
+		}
+		stats := &Stats{}
+		if err := json.Unmarshal(obj.Value, stats); err != nil {
+			return errors.Wrap(err, "could not unmarshal stats")
+		}
+		res[key] = stats
+		return nil
+	})
+	return res, err
+}
+
+// saveEpoch saves the counters of the given epoch
+func (s *storage) saveEpoch(epoch phase0.Epoch, stats epochStats) error {
+	objs := make([]basedb.Obj, 0, len(stats))
+	for key, st := range stats {
+		raw, err := json.Marshal(st)
+		if err != nil {
+			return errors.Wrap(err, "could not marshal stats")
+		}
+		objs = append(objs, basedb.Obj{Key: key.bytes(), Value: raw})
+	}
+	return s.db.SetMany(epochPrefix(epoch), len(objs), func(i int) (basedb.Obj, error) {
+		return objs[i], nil
+	})
+}
+
+// deleteEpoch removes the counters of the given epoch
+func (s *storage) deleteEpoch(epoch phase0.Epoch) error {
+	_, err := s.db.DeleteByPrefix(epochPrefix(epoch))
+	return err
+}
@@ -0,0 +1,310 @@
+package performance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/jellydator/ttlcache/v3"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// Config holds the configuration of the performance tracker
+type Config struct {
+	WindowEpochs    uint64        `yaml:"WindowEpochs" env:"PERFORMANCE_WINDOW_EPOCHS" env-default:"225" env-description:"Default amount of epochs in the rolling window of operator performance stats"`
+	RetentionEpochs uint64        `yaml:"RetentionEpochs" env:"PERFORMANCE_RETENTION_EPOCHS" env-default:"1575" env-description:"Amount of epochs to keep operator performance stats for"`
+	FlushInterval   time.Duration `yaml:"FlushInterval" env:"PERFORMANCE_FLUSH_INTERVAL" env-default:"1m" env-description:"Interval for persisting operator performance stats"`
+}
+
+// Options holds the needed dependencies of the tracker
+type Options struct {
+	Ctx     context.Context
+	DB      basedb.IDb
+	Shares  registrystorage.Shares
+	Network beaconprotocol.Network
+	Config  Config
+}
+
+// OperatorStats holds the stats of an operator in a role over a range of epochs
+type OperatorStats struct {
+	OperatorID spectypes.OperatorID `json:"operator_id"`
+	Role       spectypes.BeaconRole `json:"role"`
+	Stats
+}
+
+// ParticipationRate returns the ratio of decided heights the operator signed
+func (s Stats) ParticipationRate() float64 {
+	if s.Decided == 0 {
+		return 0
+	}
+	return float64(s.Participated) / float64(s.Decided)
+}
+
+// AverageRounds returns the average amount of rounds it took to decide
+func (s Stats) AverageRounds() float64 {
+	if s.Decided == 0 {
+		return 0
+	}
+	return float64(s.Rounds) / float64(s.Decided)
+}
+
+// Tracker accounts the participation of operators in the decided messages of their validators
+type Tracker struct {
+	ctx     context.Context
+	storage *storage
+	shares  registrystorage.Shares
+	network beaconprotocol.Network
+	config  Config
+
+	mu sync.Mutex
+	// epochs holds the counters of recent epochs, which are persisted on flush
+	epochs map[phase0.Epoch]epochStats
+	dirty  map[phase0.Epoch]bool
+	// signers holds the signers that were already counted per decided height,
+	// so that decided messages with more signers only count the new ones
+	signers *ttlcache.Cache[string, map[spectypes.OperatorID]bool]
+	// prunedUntil is the epoch until which stats were removed from the DB
+	prunedUntil phase0.Epoch
+}
+
+// New creates a new tracker
+func New(opts Options) *Tracker {
+	return &Tracker{
+		ctx:     opts.Ctx,
+		storage: &storage{db: opts.DB},
+		shares:  opts.Shares,
+		network: opts.Network,
+		config:  opts.Config,
+		epochs:  map[phase0.Epoch]epochStats{},
+		dirty:   map[phase0.Epoch]bool{},
+		signers: ttlcache.New(
+			ttlcache.WithTTL[string, map[spectypes.OperatorID]bool](2 * time.Duration(opts.Network.SlotsPerEpoch()) * opts.Network.SlotDurationSec()),
+		),
+	}
+}
+
+// Start periodically persists the counters and removes expired stats, blocks until the context is done
+func (t *Tracker) Start(logger *zap.Logger) {
+	logger = logger.Named("PerformanceTracker")
+
+	ticker := time.NewTicker(t.config.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.ctx.Done():
+			if err := t.flush(logger); err != nil {
+				logger.Warn("could not flush performance stats", zap.Error(err))
+			}
+			return
+		case <-ticker.C:
+			if err := t.flush(logger); err != nil {
+				logger.Warn("could not flush performance stats", zap.Error(err))
+			}
+			t.signers.DeleteExpired()
+		}
+	}
+}
+
+// HandleDecided accounts the given decided message, it can be used as a qbft controller.NewDecidedHandler
+func (t *Tracker) HandleDecided(logger *zap.Logger) func(msg *specqbft.SignedMessage) {
+	return func(msg *specqbft.SignedMessage) {
+		if err := t.handleDecided(logger, msg); err != nil {
+			logger.Debug("could not account decided message", fields.Height(msg.Message.Height), zap.Error(err))
+		}
+	}
+}
+
+func (t *Tracker) handleDecided(logger *zap.Logger, msg *specqbft.SignedMessage) error {
+	msgID := spectypes.MessageIDFromBytes(msg.Message.Identifier)
+	share := t.shares.Get(msgID.GetPubKey())
+	if share == nil {
+		return fmt.Errorf("share not found")
+	}
+	role := msgID.GetRoleType()
+	epoch := t.network.EstimatedEpochAtSlot(phase0.Slot(msg.Message.Height))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := fmt.Sprintf("%x:%d", msg.Message.Identifier, msg.Message.Height)
+	var counted map[spectypes.OperatorID]bool
+	if item := t.signers.Get(key); item != nil {
+		counted = item.Value()
+	}
+	firstSeen := counted == nil
+	if firstSeen {
+		counted = map[spectypes.OperatorID]bool{}
+		t.signers.Set(key, counted, ttlcache.DefaultTTL)
+	}
+
+	stats, err := t.epochStats(logger, epoch)
+	if err != nil {
+		return err
+	}
+	signed := make(map[spectypes.OperatorID]bool, len(msg.Signers))
+	for _, signer := range msg.Signers {
+		signed[signer] = true
+	}
+	for _, operator := range share.Committee {
+		k := statsKey{OperatorID: operator.OperatorID, Role: role}
+		st, ok := stats[k]
+		if !ok {
+			st = &Stats{}
+			stats[k] = st
+		}
+		labels := []string{strconv.FormatUint(operator.OperatorID, 10), role.String()}
+		switch {
+		case firstSeen:
+			st.Decided++
+			st.Rounds += uint64(msg.Message.Round)
+			if signed[operator.OperatorID] {
+				st.Participated++
+				counted[operator.OperatorID] = true
+				metricsParticipated.WithLabelValues(labels...).Inc()
+			} else {
+				st.Missed++
+				metricsMissed.WithLabelValues(labels...).Inc()
+			}
+		case signed[operator.OperatorID] && !counted[operator.OperatorID]:
+			// a decided message with more signers arrived, the operator didn't miss after all
+			st.Participated++
+			st.Missed--
+			counted[operator.OperatorID] = true
+			metricsParticipated.WithLabelValues(labels...).Inc()
+		}
+	}
+	if firstSeen {
+		metricsRoundsToDecide.WithLabelValues(role.String()).Observe(float64(msg.Message.Round))
+	}
+	t.dirty[epoch] = true
+	return nil
+}
+
+// epochStats returns the counters of the given epoch, loading them from the DB if needed
+func (t *Tracker) epochStats(logger *zap.Logger, epoch phase0.Epoch) (epochStats, error) {
+	if stats, ok := t.epochs[epoch]; ok {
+		return stats, nil
+	}
+	stats, err := t.storage.loadEpoch(logger, epoch)
+	if err != nil {
+		return nil, err
+	}
+	t.epochs[epoch] = stats
+	return stats, nil
+}
+
+// flush persists the counters that were changed since the last flush,
+// evicts old epochs from memory and removes expired epochs from the DB.
+func (t *Tracker) flush(logger *zap.Logger) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for epoch := range t.dirty {
+		if err := t.storage.saveEpoch(epoch, t.epochs[epoch]); err != nil {
+			return err
+		}
+		delete(t.dirty, epoch)
+	}
+
+	currentEpoch := t.network.EstimatedCurrentEpoch()
+	for epoch := range t.epochs {
+		// decided messages usually arrive within the epoch of their height or the one after
+		if epoch+1 < currentEpoch {
+			delete(t.epochs, epoch)
+		}
+	}
+
+	t.reportParticipationRates(logger, currentEpoch)
+
+	if uint64(currentEpoch) <= t.config.RetentionEpochs {
+		return nil
+	}
+	cutoff := currentEpoch - phase0.Epoch(t.config.RetentionEpochs)
+	if t.prunedUntil == 0 && uint64(cutoff) > t.config.RetentionEpochs {
+		// after a restart, look back one retention period for stats that expired while the node was down
+		t.prunedUntil = cutoff - phase0.Epoch(t.config.RetentionEpochs)
+	}
+	for epoch := t.prunedUntil; epoch < cutoff; epoch++ {
+		if err := t.storage.deleteEpoch(epoch); err != nil {
+			return err
+		}
+	}
+	t.prunedUntil = cutoff
+	return nil
+}
+
+// reportParticipationRates updates the participation rate gauges over the default window
+func (t *Tracker) reportParticipationRates(logger *zap.Logger, currentEpoch phase0.Epoch) {
+	stats, err := t.statsInRange(logger, t.windowStart(currentEpoch, t.config.WindowEpochs), currentEpoch)
+	if err != nil {
+		logger.Debug("could not load performance stats", zap.Error(err))
+		return
+	}
+	for _, st := range stats {
+		metricsParticipationRate.WithLabelValues(strconv.FormatUint(st.OperatorID, 10), st.Role.String()).Set(st.ParticipationRate())
+	}
+}
+
+// Stats returns the stats of all known operators over the given amount of recent epochs,
+// when epochs is zero the configured window is used.
+func (t *Tracker) Stats(logger *zap.Logger, epochs uint64) ([]*OperatorStats, phase0.Epoch, phase0.Epoch, error) {
+	if epochs == 0 {
+		epochs = t.config.WindowEpochs
+	}
+	if epochs > t.config.RetentionEpochs {
+		epochs = t.config.RetentionEpochs
+	}
+	currentEpoch := t.network.EstimatedCurrentEpoch()
+	from := t.windowStart(currentEpoch, epochs)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats, err := t.statsInRange(logger, from, currentEpoch)
+	return stats, from, currentEpoch, err
+}
+
+func (t *Tracker) windowStart(currentEpoch phase0.Epoch, epochs uint64) phase0.Epoch {
+	if epochs == 0 || uint64(currentEpoch) < epochs {
+		return 0
+	}
+	return currentEpoch - phase0.Epoch(epochs) + 1
+}
+
+// statsInRange sums the counters of the given epoch range, the caller must hold the lock
+func (t *Tracker) statsInRange(logger *zap.Logger, from, to phase0.Epoch) ([]*OperatorStats, error) {
+	totals := map[statsKey]*OperatorStats{}
+	for epoch := from; epoch <= to; epoch++ {
+		stats, ok := t.epochs[epoch]
+		if !ok {
+			var err error
+			if stats, err = t.storage.loadEpoch(logger, epoch); err != nil {
+				return nil, err
+			}
+		}
+		for k, st := range stats {
+			total, ok := totals[k]
+			if !ok {
+				total = &OperatorStats{OperatorID: k.OperatorID, Role: k.Role}
+				totals[k] = total
+			}
+			total.Add(st)
+		}
+	}
+
+	res := make([]*OperatorStats, 0, len(totals))
+	for _, st := range totals {
+		res = append(res, st)
+	}
+	return res, nil
+}
@@ -0,0 +1,90 @@
+package performance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestTracker(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.New(logger, basedb.Options{Type: "badger-memory", Path: ""})
+	require.NoError(t, err)
+	defer db.Close(logger)
+
+	shares, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+	pk := make([]byte, 48)
+	require.NoError(t, shares.Save(&types.SSVShare{
+		Share: spectypes.Share{
+			ValidatorPubKey: pk,
+			Committee:       []*spectypes.Operator{{OperatorID: 1}, {OperatorID: 2}, {OperatorID: 3}, {OperatorID: 4}},
+		},
+	}))
+
+	network := networkconfig.TestNetwork.Beacon
+	currentSlot := network.EstimatedCurrentSlot()
+	newTracker := func() *Tracker {
+		return New(Options{
+			Ctx:     context.Background(),
+			DB:      db,
+			Shares:  shares,
+			Network: network,
+			Config:  Config{WindowEpochs: 10, RetentionEpochs: 100, FlushInterval: time.Minute},
+		})
+	}
+	tracker := newTracker()
+	handle := tracker.HandleDecided(logger)
+
+	msgID := spectypes.NewMsgID(types.GetDefaultDomain(), pk, spectypes.BNRoleAttester)
+	decided := func(slot phase0.Slot, round specqbft.Round, signers ...spectypes.OperatorID) *specqbft.SignedMessage {
+		return &specqbft.SignedMessage{
+			Signers: signers,
+			Message: specqbft.Message{
+				MsgType:    specqbft.CommitMsgType,
+				Height:     specqbft.Height(slot),
+				Round:      round,
+				Identifier: msgID[:],
+			},
+		}
+	}
+
+	handle(decided(currentSlot, 1, 1, 2, 3))
+	// same height with more signers
+	handle(decided(currentSlot, 1, 1, 2, 3, 4))
+	// same height with the same signers
+	handle(decided(currentSlot, 1, 1, 2, 3, 4))
+	handle(decided(currentSlot-1, 2, 1, 2, 3))
+
+	requireStats := func(tracker *Tracker) {
+		stats, _, _, err := tracker.Stats(logger, 0)
+		require.NoError(t, err)
+		require.Len(t, stats, 4)
+		byOperator := map[spectypes.OperatorID]Stats{}
+		for _, st := range stats {
+			require.Equal(t, spectypes.BNRoleAttester, st.Role)
+			byOperator[st.OperatorID] = st.Stats
+		}
+		require.Equal(t, Stats{Decided: 2, Participated: 2, Rounds: 3}, byOperator[1])
+		require.Equal(t, Stats{Decided: 2, Participated: 1, Missed: 1, Rounds: 3}, byOperator[4])
+		require.Equal(t, 0.5, byOperator[4].ParticipationRate())
+		require.Equal(t, 1.5, byOperator[4].AverageRounds())
+	}
+	requireStats(tracker)
+
+	// stats are persisted on flush and loaded by a new tracker
+	require.NoError(t, tracker.flush(logger))
+	requireStats(newTracker())
+}
@@ -0,0 +1,254 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/ibft/storage"
+	"github.com/bloxapp/ssv/logging/fields"
+	operatorvalidator "github.com/bloxapp/ssv/operator/validator"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/capture"
+	"github.com/bloxapp/ssv/protocol/v2/message"
+	"github.com/bloxapp/ssv/protocol/v2/qbft"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/runner"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/validator"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+// Options configures a replay
+type Options struct {
+	BeaconNetwork beacon.Network
+	Records       []*capture.Record
+	// PubKey is the public key of the validator to replay, it can be omitted if a single validator was captured
+	PubKey []byte
+}
+
+// Replayer feeds the captured records of a validator back through a validator built from its captured share.
+// The validator handles the captured messages and duties one by one, in the captured order,
+// processing whatever it can before the next record. Rounds time out when the captured timeouts are replayed
+// rather than on the wall clock, the current slot is estimated at the capture time of the replayed record,
+// and the beacon node answers with the captured responses, so that replays are deterministic.
+// Nothing is signed, broadcasted or submitted.
+type Replayer struct {
+	records []*capture.Record
+	next    int
+	// now is the capture time of the last replayed record, which is the current time of the replayed validator
+	now time.Time
+
+	db        basedb.IDb
+	validator *validator.Validator
+	cancel    context.CancelFunc
+	// msgIDs are the identifiers of the validator's queues, which are processed in this order
+	msgIDs []spectypes.MessageID
+
+	Beacon  *capture.ReplayBeacon
+	Network *Network
+}
+
+// Validators returns the public keys of the validators whose share was captured
+func Validators(records []*capture.Record) []string {
+	var validators []string
+	seen := map[string]bool{}
+	for _, record := range records {
+		if record.Kind != capture.KindShare {
+			continue
+		}
+		pk := hex.EncodeToString(record.Share.ValidatorPubKey)
+		if !seen[pk] {
+			seen[pk] = true
+			validators = append(validators, pk)
+		}
+	}
+	return validators
+}
+
+// New builds the validator of the replay from the first captured share of it
+func New(ctx context.Context, logger *zap.Logger, opts Options) (*Replayer, error) {
+	pubKey := opts.PubKey
+	if len(pubKey) == 0 {
+		validators := Validators(opts.Records)
+		if len(validators) != 1 {
+			return nil, errors.Errorf("a validator must be chosen out of the %d captured validators", len(validators))
+		}
+		pubKey, _ = hex.DecodeString(validators[0])
+	}
+
+	var share *types.SSVShare
+	var records []*capture.Record
+	for _, record := range opts.Records {
+		if !bytes.Equal(record.PubKey(), pubKey) {
+			continue
+		}
+		if record.Kind == capture.KindShare && share == nil {
+			share = record.Share
+		}
+		records = append(records, record)
+	}
+	if share == nil {
+		return nil, errors.Errorf("the share of validator %x wasn't captured", pubKey)
+	}
+	if !share.HasBeaconMetadata() {
+		return nil, errors.New("the captured share has no beacon metadata")
+	}
+
+	db, err := kv.New(logger, basedb.Options{Type: "badger-memory", Ctx: ctx})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open db")
+	}
+
+	r := &Replayer{
+		records: records,
+		now:     records[0].Time,
+		db:      db,
+		Beacon:  capture.NewReplayBeacon(logger, opts.BeaconNetwork.BeaconNetwork, records),
+		Network: &Network{logger: logger},
+	}
+
+	roles := []spectypes.BeaconRole{
+		spectypes.BNRoleAttester,
+		spectypes.BNRoleProposer,
+		spectypes.BNRoleAggregator,
+		spectypes.BNRoleSyncCommittee,
+		spectypes.BNRoleSyncCommitteeContribution,
+		spectypes.BNRoleValidatorRegistration,
+	}
+	validatorOptions := validator.Options{
+		Network:       r.Network,
+		Beacon:        r.Beacon,
+		BeaconNetwork: opts.BeaconNetwork.WithClock(func() time.Time { return r.now }),
+		Storage:       storage.NewStoresFromRoles(db, roles...),
+		SSVShare:      share,
+		Signer:        runner.NewShadowSigner(unprotectedSigner{}),
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	validatorOptions.DutyRunners = operatorvalidator.SetupRunners(ctx, logger, validatorOptions)
+	for _, dutyRunner := range validatorOptions.DutyRunners {
+		if ctrl := dutyRunner.GetBaseRunner().QBFTController; ctrl != nil {
+			if config, ok := ctrl.GetConfig().(*qbft.Config); ok {
+				config.Timer = capturedTimer{}
+			}
+		}
+	}
+	r.validator = validator.NewValidator(ctx, cancel, validatorOptions)
+	r.cancel = cancel
+	for _, role := range roles {
+		if _, ok := r.validator.Queues[role]; ok {
+			r.msgIDs = append(r.msgIDs, spectypes.NewMsgID(types.GetDefaultDomain(), share.ValidatorPubKey, role))
+		}
+	}
+	return r, nil
+}
+
+// Validator returns the replayed validator
+func (r *Replayer) Validator() *validator.Validator {
+	return r.validator
+}
+
+// Step replays the next record and returns it, or io.EOF once all the records were replayed
+func (r *Replayer) Step(logger *zap.Logger) (*capture.Record, error) {
+	if r.next >= len(r.records) {
+		return nil, io.EOF
+	}
+	record := r.records[r.next]
+	r.next++
+	r.now = record.Time
+
+	logger = logger.With(zap.Time("captured_at", record.Time), zap.Stringer("kind", record.Kind))
+	switch record.Kind {
+	case capture.KindMessage:
+		logger.Debug("🔁 replaying message", fields.MessageID(record.Message.MsgID), fields.MessageType(record.Message.MsgType))
+		r.validator.HandleMessage(logger, record.Message)
+	case capture.KindDroppedMessage:
+		logger.Info("🔁 skipping message which was dropped by the node",
+			fields.MessageID(record.Message.MsgID),
+			zap.String("msg_type", message.MsgTypeToString(record.Message.MsgType)))
+		return record, nil
+	case capture.KindDuty:
+		logger.Debug("🔁 replaying duty", fields.Slot(record.Duty.Slot), fields.Role(record.Duty.Type))
+		var pk phase0.BLSPubKey
+		copy(pk[:], record.Duty.PubKey[:])
+		msg, err := types.CreateDutyExecuteMsg(record.Duty, pk, types.GetDefaultDomain())
+		if err != nil {
+			return record, errors.Wrap(err, "could not create execute duty message")
+		}
+		r.validator.HandleMessage(logger, msg)
+	default:
+		// shares and beacon responses are used when the validator is built
+		return record, nil
+	}
+
+	for _, msgID := range r.msgIDs {
+		if err := r.validator.ProcessQueue(logger, msgID, r.validator.ProcessMessage); err != nil {
+			return record, errors.Wrapf(err, "could not process queue of %s", msgID.GetRoleType())
+		}
+	}
+	return record, nil
+}
+
+// Run replays all the records
+func (r *Replayer) Run(logger *zap.Logger) error {
+	for {
+		if _, err := r.Step(logger); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// Close stops the replayed validator and releases its resources
+func (r *Replayer) Close(logger *zap.Logger) error {
+	r.validator.Stop()
+	r.cancel()
+	return r.db.Close(logger)
+}
+
+// Network drops the broadcasts of the replayed validator, as the messages it sent were captured when received back
+type Network struct {
+	logger *zap.Logger
+	// Broadcasts holds the messages the replayed validator would have broadcasted
+	Broadcasts []*spectypes.SSVMessage
+}
+
+func (n *Network) Broadcast(msg *spectypes.SSVMessage) error {
+	n.logger.Debug("🔁 replay: dropped broadcast", fields.MessageID(msg.MsgID), fields.MessageType(msg.MsgType))
+	n.Broadcasts = append(n.Broadcasts, msg)
+	return nil
+}
+
+func (n *Network) SyncHighestDecided(identifier spectypes.MessageID) error {
+	return nil
+}
+
+func (n *Network) SyncDecidedByRange(identifier spectypes.MessageID, from, to specqbft.Height) {}
+
+// capturedTimer never times out, rounds time out when the captured timeout events are replayed
+type capturedTimer struct{}
+
+func (capturedTimer) TimeoutForRound(round specqbft.Round) {}
+
+// unprotectedSigner doesn't protect from slashing, the replayed duties were checked when they were captured
+type unprotectedSigner struct {
+	spectypes.KeyManager
+}
+
+func (unprotectedSigner) IsAttestationSlashable(pk []byte, data *phase0.AttestationData) error {
+	return nil
+}
+
+func (unprotectedSigner) IsBeaconBlockSlashable(pk []byte, slot phase0.Slot) error {
+	return nil
+}
@@ -0,0 +1,86 @@
+package replay
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	spectestingutils "github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	operatorvalidator "github.com/bloxapp/ssv/operator/validator"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/capture"
+	qbfttesting "github.com/bloxapp/ssv/protocol/v2/qbft/testing"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/validator"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+func TestReplay(t *testing.T) {
+	logger := logging.TestLogger(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "capture.gz")
+	recorder, err := capture.New(capture.Options{Ctx: ctx, Config: capture.Config{Path: path, FlushInterval: time.Minute}})
+	require.NoError(t, err)
+
+	// captures a validator registration, including the partial signature received back from the network
+	keySet := spectestingutils.Testing4SharesSet()
+	net := spectestingutils.NewTestingNetwork()
+	options := validator.Options{
+		Network:       net,
+		Beacon:        spectestingutils.NewTestingBeaconNode(),
+		BeaconNetwork: beacon.NewNetwork(spectypes.BeaconTestNetwork),
+		Storage:       qbfttesting.TestingStores(logger),
+		SSVShare: &types.SSVShare{
+			Share:    *spectestingutils.TestingShare(keySet),
+			Metadata: types.Metadata{BeaconMetadata: &beacon.ValidatorMetadata{Index: spectestingutils.TestingValidatorIndex}},
+		},
+		Signer:  spectestingutils.NewTestingKeyManager(),
+		Capture: recorder,
+	}
+	options.DutyRunners = operatorvalidator.SetupRunners(ctx, logger, options)
+	v := validator.NewValidator(ctx, cancel, options)
+
+	duty := spectestingutils.TestingValidatorRegistrationDuty
+	require.NoError(t, v.StartDuty(logger, &duty))
+	require.Len(t, net.BroadcastedMsgs, 1)
+	v.HandleMessage(logger, net.BroadcastedMsgs[0])
+	msgID := spectypes.NewMsgID(types.GetDefaultDomain(), keySet.ValidatorPK.Serialize(), spectypes.BNRoleValidatorRegistration)
+	require.NoError(t, v.ProcessQueue(logger, msgID, v.ProcessMessage))
+
+	cancel()
+	recorder.Start(logger)
+
+	records, err := capture.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{keySet.ValidatorPK.SerializeToHexStr()}, Validators(records))
+	// replays a day old capture
+	for _, record := range records {
+		record.Time = record.Time.Add(-24 * time.Hour)
+	}
+
+	r, err := New(context.Background(), logger, Options{
+		BeaconNetwork: beacon.NewNetwork(spectypes.BeaconTestNetwork),
+		Records:       records,
+	})
+	require.NoError(t, err)
+	require.NoError(t, r.Run(logger))
+	defer func() {
+		require.NoError(t, r.Close(logger))
+	}()
+
+	// the replayed validator signs the same registration, and doesn't reach a quorum with its own signature
+	require.Len(t, r.Network.Broadcasts, 1)
+	require.Equal(t, net.BroadcastedMsgs[0].MsgID, r.Network.Broadcasts[0].MsgID)
+	require.Empty(t, r.Beacon.Submissions)
+
+	// the replayed validator's current slot is the one of the last replayed record rather than of the wall clock
+	network := r.Validator().DutyRunners[spectypes.BNRoleValidatorRegistration].GetBaseRunner().BeaconNetwork
+	require.Equal(t, network.EstimatedSlotAtTime(records[len(records)-1].Time.Unix()), network.EstimatedCurrentSlot())
+	require.Less(t, network.EstimatedCurrentSlot(), network.EstimatedSlotAtTime(time.Now().Unix()))
+}
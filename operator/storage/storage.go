@@ -4,6 +4,7 @@ import (
 	"crypto/rsa"
 	"encoding/base64"
 	"math/big"
+	"sync"
 
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	spectypes "github.com/bloxapp/ssv-spec/types"
@@ -22,6 +23,7 @@ import (
 var (
 	storagePrefix = []byte("operator/")
 	syncOffsetKey = []byte("syncOffset")
+	privateKeyKey = []byte("private-key")
 )
 
 // Storage represents the interface for ssv node storage
@@ -38,6 +40,10 @@ type Storage interface {
 
 	GetPrivateKey() (*rsa.PrivateKey, bool, error)
 	SetupPrivateKey(logger *zap.Logger, operatorKeyBase64 string, generateIfNone bool) ([]byte, error)
+	// SetPrivateKey sets an operator private key loaded from outside the db (e.g. a keystore), it is kept in memory only
+	SetPrivateKey(sk *rsa.PrivateKey)
+	// DeletePrivateKey deletes the plaintext operator private key from the db, rewriting the db files without it
+	DeletePrivateKey() error
 }
 
 type storage struct {
@@ -47,6 +53,9 @@ type storage struct {
 	recipientStore registrystorage.Recipients
 	shareStore     registrystorage.Shares
 	eventStore     registrystorage.Events
+
+	privateKeyLock sync.RWMutex
+	privateKey     *rsa.PrivateKey
 }
 
 // NewNodeStorage creates a new instance of Storage
@@ -194,9 +203,33 @@ func (s *storage) GetSyncOffset() (*eth1.SyncOffset, bool, error) {
 	return offset, found, nil
 }
 
-// GetPrivateKey return rsa private key
+// GetPrivateKey return rsa private key, preferring a key that was set in memory over the one in the db
 func (s *storage) GetPrivateKey() (*rsa.PrivateKey, bool, error) {
-	obj, found, err := s.db.Get(storagePrefix, []byte("private-key"))
+	s.privateKeyLock.RLock()
+	sk := s.privateKey
+	s.privateKeyLock.RUnlock()
+	if sk != nil {
+		return sk, true, nil
+	}
+	return s.getStoredPrivateKey()
+}
+
+// SetPrivateKey sets the operator private key in memory
+func (s *storage) SetPrivateKey(sk *rsa.PrivateKey) {
+	s.privateKeyLock.Lock()
+	defer s.privateKeyLock.Unlock()
+	s.privateKey = sk
+}
+
+// DeletePrivateKey deletes the operator private key from the db.
+// The key is dropped rather than deleted, so that the db files are rewritten without it
+// instead of keeping it until compaction.
+func (s *storage) DeletePrivateKey() error {
+	return s.db.RemoveAllByCollection(append(storagePrefix, privateKeyKey...))
+}
+
+func (s *storage) getStoredPrivateKey() (*rsa.PrivateKey, bool, error) {
+	obj, found, err := s.db.Get(storagePrefix, privateKeyKey)
 	if err != nil {
 		return nil, false, err
 	}
@@ -245,7 +278,7 @@ func (s *storage) validateKey(generateIfNone bool, operatorKey string) error {
 		return s.savePrivateKey(operatorKey)
 	}
 	// new key not provided, check if key exist
-	_, found, err := s.GetPrivateKey()
+	_, found, err := s.getStoredPrivateKey()
 	if err != nil {
 		return err
 	}
@@ -267,7 +300,7 @@ func (s *storage) validateKey(generateIfNone bool, operatorKey string) error {
 
 // SavePrivateKey save operator private key
 func (s *storage) savePrivateKey(operatorKey string) error {
-	if err := s.db.Set(storagePrefix, []byte("private-key"), []byte(operatorKey)); err != nil {
+	if err := s.db.Set(storagePrefix, privateKeyKey, []byte(operatorKey)); err != nil {
 		return err
 	}
 	return nil
@@ -45,6 +45,47 @@ func TestSaveAndGetPrivateKey(t *testing.T) {
 	require.Equal(t, pkPem, operatorPublicKey)
 }
 
+func TestSetAndDeletePrivateKey(t *testing.T) {
+	logger := logging.TestLogger(t)
+	options := basedb.Options{
+		Type: "badger-memory",
+		Path: "",
+	}
+
+	db, err := ssvstorage.GetStorageFactory(logger, options)
+	require.NoError(t, err)
+	defer db.Close(logger)
+
+	operatorStorage := storage{
+		db: db,
+	}
+
+	KeyByte, err := base64.StdEncoding.DecodeString(skPem)
+	require.NoError(t, err)
+	require.NoError(t, operatorStorage.savePrivateKey(string(KeyByte)))
+
+	// a key set in memory takes precedence over the one in the db
+	KeyByte2, err := base64.StdEncoding.DecodeString(skPem2)
+	require.NoError(t, err)
+	sk2, err := rsaencryption.ConvertPemToPrivateKey(string(KeyByte2))
+	require.NoError(t, err)
+	operatorStorage.SetPrivateKey(sk2)
+	sk, found, err := operatorStorage.GetPrivateKey()
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, sk2, sk)
+
+	require.NoError(t, operatorStorage.DeletePrivateKey())
+	_, found, err = operatorStorage.getStoredPrivateKey()
+	require.NoError(t, err)
+	require.False(t, found)
+
+	operatorStorage.SetPrivateKey(nil)
+	_, found, err = operatorStorage.GetPrivateKey()
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
 func TestSetupPrivateKey(t *testing.T) {
 	tests := []struct {
 		name           string
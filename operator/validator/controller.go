@@ -1,15 +1,17 @@
 package validator
 
 import (
+	"bytes"
 	"context"
 	"crypto/rsa"
+	"crypto/sha256"
 	"encoding/hex"
 	"sync"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
-	specssv "github.com/bloxapp/ssv-spec/ssv"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jellydator/ttlcache/v3"
@@ -27,6 +29,8 @@ import (
 	nodestorage "github.com/bloxapp/ssv/operator/storage"
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/capture"
+	"github.com/bloxapp/ssv/protocol/v2/dutytrace"
 	"github.com/bloxapp/ssv/protocol/v2/message"
 	p2pprotocol "github.com/bloxapp/ssv/protocol/v2/p2p"
 	"github.com/bloxapp/ssv/protocol/v2/qbft"
@@ -34,6 +38,7 @@ import (
 	"github.com/bloxapp/ssv/protocol/v2/qbft/roundtimer"
 	utilsprotocol "github.com/bloxapp/ssv/protocol/v2/queue"
 	"github.com/bloxapp/ssv/protocol/v2/queue/worker"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/runner"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/validator"
 	"github.com/bloxapp/ssv/protocol/v2/sync/handlers"
@@ -73,13 +78,21 @@ type ControllerOptions struct {
 	FullNode                   bool `yaml:"FullNode" env:"FULLNODE" env-default:"false" env-description:"Save decided history rather than just highest messages"`
 	Exporter                   bool `yaml:"Exporter" env:"EXPORTER" env-default:"false" env-description:""`
 	BuilderProposals           bool `yaml:"BuilderProposals" env:"BUILDER_PROPOSALS" env-default:"false" env-description:"Use external builders to produce blocks"`
+	ShadowMode                 bool `yaml:"ShadowMode" env:"SHADOW_MODE" env-default:"false" env-description:"Process duties like the operator would, but never sign, broadcast or submit anything"`
 	KeyManager                 spectypes.KeyManager
 	OperatorData               *registrystorage.OperatorData
 	RegistryStorage            nodestorage.Storage
 	ForkVersion                forksprotocol.ForkVersion
 	NewDecidedHandler          qbftcontroller.NewDecidedHandler
+	DutyTracer                 *dutytrace.Recorder
+	Capture                    *capture.Recorder
+	DutyGuard                  validator.DutyGuard
 	DutyRoles                  []spectypes.BeaconRole
 
+	// queue flags
+	QueuePrioritizer string `yaml:"QueuePrioritizer" env:"QUEUE_PRIORITIZER" env-default:"standard" env-description:"Prioritizer of the validator message queues: standard or decided-first"`
+	QueueDropPolicy  string `yaml:"QueueDropPolicy" env:"QUEUE_DROP_POLICY" env-default:"lowest-priority" env-description:"Message dropped when a validator message queue is full: lowest-priority (stale messages first, never current height commits) or newest"`
+
 	// worker flags
 	WorkersCount    int `yaml:"MsgWorkersCount" env:"MSG_WORKERS_COUNT" env-default:"256" env-description:"Number of goroutines to use for message workers"`
 	QueueBufferSize int `yaml:"MsgWorkerBufferSize" env:"MSG_WORKER_BUFFER_SIZE" env-default:"1024" env-description:"Buffer size for message workers"`
@@ -103,7 +116,14 @@ type Controller interface {
 	//  - the amount of validators assigned to this operator
 	GetValidatorStats() (uint64, uint64, uint64, error)
 	GetOperatorData() *registrystorage.OperatorData
-	//OnFork(forkVersion forksprotocol.ForkVersion) error
+	// ExitValidator starts a voluntary exit duty of the given validator at the given slot,
+	// all the operators of the validator must start it at the same slot
+	ExitValidator(logger *zap.Logger, pubKey phase0.BLSPubKey, slot phase0.Slot) error
+	// ChangeWithdrawalCredentials starts a bls to execution change duty of the given validator at the given slot,
+	// all the operators of the validator must start it at the same slot with the same address
+	ChangeWithdrawalCredentials(logger *zap.Logger, pubKey phase0.BLSPubKey, slot phase0.Slot, address bellatrix.ExecutionAddress) error
+	// QBFTStores returns the stores of the decided instances of the validators, by role
+	QBFTStores() *storage.QBFTStores
 }
 
 // EventHandler represents the interface for compatible storage event handlers
@@ -124,13 +144,15 @@ type controller struct {
 	context context.Context
 
 	eventHandler      EventHandler
+	syncOffsetStorage eth1.SyncOffsetStorage
 	sharesStorage     registrystorage.Shares
 	operatorsStorage  registrystorage.Operators
 	recipientsStorage registrystorage.Recipients
 	ibftStorageMap    *storage.QBFTStores
 
-	beacon     beaconprotocol.BeaconNode
-	keyManager spectypes.KeyManager
+	beacon        beaconprotocol.BeaconNode
+	beaconNetwork beaconprotocol.Network
+	keyManager    spectypes.KeyManager
 
 	shareEncryptionKeyProvider ShareEncryptionKeyProvider
 	operatorData               *registrystorage.OperatorData
@@ -156,6 +178,14 @@ type controller struct {
 // NewController creates a new validator controller instance
 func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 	logger.Debug("CreatingController", zap.Bool("full_node", options.FullNode), fields.BuilderProposals(options.BuilderProposals))
+	if options.ShadowMode {
+		logger.Warn("👻 running in shadow mode, duties are never signed, broadcasted or submitted")
+	}
+	prioritizer, dropPolicy, err := queueOptions(options)
+	if err != nil {
+		logger.Fatal("invalid queue options", zap.Error(err))
+	}
+
 	storageMap := storage.NewStores()
 	storageMap.Add(spectypes.BNRoleAttester, storage.New(options.DB, spectypes.BNRoleAttester.String(), options.ForkVersion))
 	storageMap.Add(spectypes.BNRoleProposer, storage.New(options.DB, spectypes.BNRoleProposer.String(), options.ForkVersion))
@@ -178,16 +208,22 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 	validatorOptions := &validator.Options{ //TODO add vars
 		Network:       options.Network,
 		Beacon:        options.Beacon,
-		BeaconNetwork: options.BeaconNetwork.BeaconNetwork,
+		BeaconNetwork: options.BeaconNetwork,
 		Storage:       storageMap,
 		//Share:   nil,  // set per validator
 		Signer: options.KeyManager,
 		//Mode: validator.ModeRW // set per validator
 		DutyRunners:       nil, // set per validator
 		NewDecidedHandler: options.NewDecidedHandler,
+		DutyTracer:        options.DutyTracer,
+		Capture:           options.Capture,
+		DutyGuard:         options.DutyGuard,
 		FullNode:          options.FullNode,
 		Exporter:          options.Exporter,
 		BuilderProposals:  options.BuilderProposals,
+		ShadowMode:        options.ShadowMode,
+		QueuePrioritizer:  prioritizer,
+		QueueDropPolicy:   dropPolicy,
 		GasLimit:          options.GasLimit,
 	}
 
@@ -205,9 +241,11 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		operatorsStorage:           options.RegistryStorage,
 		recipientsStorage:          options.RegistryStorage,
 		eventHandler:               options.RegistryStorage,
+		syncOffsetStorage:          options.RegistryStorage,
 		ibftStorageMap:             storageMap,
 		context:                    options.Context,
 		beacon:                     options.Beacon,
+		beaconNetwork:              options.BeaconNetwork,
 		shareEncryptionKeyProvider: options.ShareEncryptionKeyProvider,
 		operatorData:               options.OperatorData,
 		keyManager:                 options.KeyManager,
@@ -222,7 +260,7 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 
 		operatorsIDs: operatorsIDs,
 
-		messageRouter:        newMessageRouter(msgID),
+		messageRouter:        newMessageRouter(msgID, options.Capture),
 		messageWorker:        worker.NewWorker(logger, workerCfg),
 		historySyncBatchSize: options.HistorySyncBatchSize,
 
@@ -272,6 +310,10 @@ func (c *controller) GetOperatorData() *registrystorage.OperatorData {
 	return c.operatorData
 }
 
+func (c *controller) QBFTStores() *storage.QBFTStores {
+	return c.ibftStorageMap
+}
+
 func (c *controller) GetValidatorStats() (uint64, uint64, uint64, error) {
 	allShares := c.sharesStorage.List()
 	operatorShares := uint64(0)
@@ -287,33 +329,48 @@ func (c *controller) GetValidatorStats() (uint64, uint64, uint64, error) {
 	return uint64(len(allShares)), active, operatorShares, nil
 }
 
+// queueOptions returns the prioritizer and drop policy of the validator message queues,
+// defaulting to the standard prioritizer and dropping the lowest priority messages
+func queueOptions(options ControllerOptions) (queue.PrioritizerFactory, queue.DropPolicy, error) {
+	prioritizer := queue.NewMessagePrioritizer
+	if options.QueuePrioritizer != "" {
+		var err error
+		if prioritizer, err = queue.PrioritizerFromString(options.QueuePrioritizer); err != nil {
+			return nil, 0, err
+		}
+	}
+	if options.QueueDropPolicy == "" {
+		return prioritizer, queue.DropLowestPriority, nil
+	}
+	dropPolicy, err := queue.DropPolicyFromString(options.QueueDropPolicy)
+	return prioritizer, dropPolicy, err
+}
+
 func (c *controller) handleRouterMessages(logger *zap.Logger) {
 	ctx, cancel := context.WithCancel(c.context)
 	defer cancel()
-	ch := c.messageRouter.GetMessageChan()
 
 	for {
-		select {
-		case <-ctx.Done():
+		msg, ok := c.messageRouter.Next(ctx)
+		if !ok {
 			logger.Debug("router message handler stopped")
 			return
-		case msg := <-ch:
-			// TODO temp solution to prevent getting event msgs from network. need to to add validation in p2p
-			if msg.MsgType == message.SSVEventMsgType {
-				continue
-			}
+		}
+		// TODO temp solution to prevent getting event msgs from network. need to to add validation in p2p
+		if msg.MsgType == message.SSVEventMsgType {
+			continue
+		}
 
-			pk := msg.GetID().GetPubKey()
-			hexPK := hex.EncodeToString(pk)
-			if v, ok := c.validatorsMap.GetValidator(hexPK); ok {
-				v.HandleMessage(logger, &msg)
-			} else {
-				if msg.MsgType != spectypes.SSVConsensusMsgType {
-					continue // not supporting other types
-				}
-				if !c.messageWorker.TryEnqueue(&msg) { // start to save non committee decided messages only post fork
-					logger.Warn("Failed to enqueue post consensus message: buffer is full")
-				}
+		pk := msg.GetID().GetPubKey()
+		hexPK := hex.EncodeToString(pk)
+		if v, ok := c.validatorsMap.GetValidator(hexPK); ok {
+			v.HandleMessage(logger, &msg)
+		} else {
+			if msg.MsgType != spectypes.SSVConsensusMsgType {
+				continue // not supporting other types
+			}
+			if !c.messageWorker.TryEnqueue(&msg) { // start to save non committee decided messages only post fork
+				logger.Warn("Failed to enqueue post consensus message: buffer is full")
 			}
 		}
 	}
@@ -355,7 +412,7 @@ func (c *controller) handleWorkerMessages(logger *zap.Logger, msg *spectypes.SSV
 			c.nonCommitteeValidators.Set(
 				msg.GetID(),
 				ncv,
-				time.Duration(ttlSlots)*c.beacon.GetBeaconNetwork().SlotDurationSec(),
+				time.Duration(ttlSlots)*c.beaconNetwork.SlotDurationSec(),
 			)
 		}
 
@@ -382,11 +439,25 @@ func (c *controller) ListenToEth1Events(logger *zap.Logger, feed *event.Feed) {
 
 	handler := c.Eth1EventHandler(logger, true)
 
+	// the sync offset stops advancing once an event fails, so it's handled again by the next history sync
+	advanceSyncOffset := true
+
 	for {
 		select {
 		case e := <-cn:
+			if confirmed, ok := e.Data.(eth1.ConfirmedBlockEvent); ok {
+				if advanceSyncOffset {
+					if err := c.syncOffsetStorage.SaveSyncOffset(new(eth1.SyncOffset).SetUint64(confirmed.Block)); err != nil {
+						logger.Warn("could not save sync offset", fields.BlockNumber(confirmed.Block), zap.Error(err))
+					}
+				}
+				continue
+			}
 			logFields, err := handler(*e)
-			_ = eth1.HandleEventResult(logger, *e, logFields, err, true)
+			if errs := eth1.HandleEventResult(logger, *e, logFields, err, true); len(errs) > 0 && advanceSyncOffset {
+				logger.Warn("not advancing sync offset past failed event", fields.BlockNumber(e.Log.BlockNumber))
+				advanceSyncOffset = false
+			}
 		case err := <-sub.Err():
 			logger.Warn("event feed subscription error", zap.Error(err))
 		}
@@ -559,6 +630,112 @@ func (c *controller) GetValidator(pubKey string) (*validator.Validator, bool) {
 	return c.validatorsMap.GetValidator(pubKey)
 }
 
+// ExitValidator starts a voluntary exit duty of the given validator at the given slot
+func (c *controller) ExitValidator(logger *zap.Logger, pubKey phase0.BLSPubKey, slot phase0.Slot) error {
+	v, ok := c.validatorsMap.GetValidator(hex.EncodeToString(pubKey[:]))
+	if !ok {
+		return errors.New("validator not found")
+	}
+	if !v.Share.HasBeaconMetadata() {
+		return errors.New("validator has no beacon metadata")
+	}
+	q, ok := v.Queues[message.BNRoleVoluntaryExit]
+	if !ok {
+		return errors.New("validator has no voluntary exit runner")
+	}
+
+	duty := &spectypes.Duty{
+		Type:           message.BNRoleVoluntaryExit,
+		PubKey:         pubKey,
+		Slot:           slot,
+		ValidatorIndex: v.Share.BeaconMetadata.Index,
+	}
+	ssvMsg, err := types.CreateDutyExecuteMsg(duty, pubKey, types.GetDefaultDomain())
+	if err != nil {
+		return err
+	}
+	dec, err := queue.DecodeSSVMessage(logger, ssvMsg)
+	if err != nil {
+		return err
+	}
+	if pushed := q.Q.TryPush(dec); !pushed {
+		return errors.New("voluntary exit queue is full")
+	}
+	logger.Info("starting voluntary exit", fields.PubKey(pubKey[:]), fields.Slot(slot))
+	return nil
+}
+
+// ChangeWithdrawalCredentials starts a bls to execution change duty of the given validator at the given slot.
+// The change is signed by the validator key, so its withdrawal credentials must be BLS credentials of the same key.
+func (c *controller) ChangeWithdrawalCredentials(logger *zap.Logger, pubKey phase0.BLSPubKey, slot phase0.Slot, address bellatrix.ExecutionAddress) error {
+	v, ok := c.validatorsMap.GetValidator(hex.EncodeToString(pubKey[:]))
+	if !ok {
+		return errors.New("validator not found")
+	}
+	if !v.Share.HasBeaconMetadata() {
+		return errors.New("validator has no beacon metadata")
+	}
+	q, ok := v.Queues[message.BNRoleBLSToExecutionChange]
+	if !ok {
+		return errors.New("validator has no bls to execution change runner")
+	}
+
+	data, err := c.beacon.GetValidatorData([]phase0.BLSPubKey{pubKey})
+	if err != nil {
+		return errors.Wrap(err, "could not get validator data")
+	}
+	validatorData, ok := data[v.Share.BeaconMetadata.Index]
+	if !ok || validatorData.Validator == nil {
+		return errors.New("validator not found on beacon node")
+	}
+	if err := validateBLSWithdrawalCredentials(pubKey, validatorData.Validator.WithdrawalCredentials); err != nil {
+		return err
+	}
+
+	duty := &spectypes.Duty{
+		Type:           message.BNRoleBLSToExecutionChange,
+		PubKey:         pubKey,
+		Slot:           slot,
+		ValidatorIndex: v.Share.BeaconMetadata.Index,
+	}
+	ssvMsg, err := types.CreateExecuteMsg(&types.ExecuteDutyData{Duty: duty, ExecutionAddress: &address}, pubKey, types.GetDefaultDomain())
+	if err != nil {
+		return err
+	}
+	dec, err := queue.DecodeSSVMessage(logger, ssvMsg)
+	if err != nil {
+		return err
+	}
+	if pushed := q.Q.TryPush(dec); !pushed {
+		return errors.New("bls to execution change queue is full")
+	}
+	logger.Info("starting bls to execution change", fields.PubKey(pubKey[:]), fields.Slot(slot), zap.String("execution_address", address.String()))
+	return nil
+}
+
+// validateBLSWithdrawalCredentials returns an error unless the withdrawal credentials are BLS credentials
+// of the validator key, which is the only key the cluster can sign a bls to execution change with
+func validateBLSWithdrawalCredentials(pubKey phase0.BLSPubKey, withdrawalCredentials []byte) error {
+	if len(withdrawalCredentials) != 32 || withdrawalCredentials[0] != 0x00 {
+		return errors.New("withdrawal credentials are not BLS credentials")
+	}
+	hash := sha256.Sum256(pubKey[:])
+	if !bytes.Equal(withdrawalCredentials[1:], hash[1:]) {
+		return errors.New("withdrawal credentials are of a withdrawal key other than the validator key")
+	}
+	return nil
+}
+
+// OnFork forks the qbft storage. The validators and their duty runners are the same across forks,
+// the messages they exchange are encoded and routed per fork by the p2p network.
+func (c *controller) OnFork(logger *zap.Logger, forkVersion forksprotocol.ForkVersion) error {
+	if err := c.ibftStorageMap.OnFork(logger, forkVersion); err != nil {
+		return errors.Wrap(err, "could not fork qbft storage")
+	}
+	c.forkVersion = forkVersion
+	return nil
+}
+
 // ActiveValidatorIndices returns a list of all the active validators indices
 // and fetch indices for missing once (could be first time attesting or non active once)
 func (c *controller) ActiveValidatorIndices(logger *zap.Logger) []phase0.ValidatorIndex {
@@ -732,6 +909,25 @@ func SetupRunners(ctx context.Context, logger *zap.Logger, options validator.Opt
 		spectypes.BNRoleSyncCommittee,
 		spectypes.BNRoleSyncCommitteeContribution,
 		spectypes.BNRoleValidatorRegistration,
+		message.BNRoleVoluntaryExit,
+		message.BNRoleBLSToExecutionChange,
+	}
+
+	if options.ShadowMode {
+		// runners of a shadow node do everything the operator would, except signing, broadcasting and submitting
+		bn, ok := options.Beacon.(beaconprotocol.BeaconNode)
+		if !ok {
+			logger.Error("shadow mode requires an ssv beacon node")
+			return runner.DutyRunners{}
+		}
+		options.Beacon = runner.NewShadowBeacon(logger, bn)
+		options.Network = runner.NewShadowNetwork(logger, options.Network)
+		options.Signer = runner.NewShadowSigner(options.Signer)
+	}
+	// exits and bls to execution changes are submitted through the ssv beacon node, which the spec's interface doesn't cover
+	exitBeacon, canExit := options.Beacon.(beaconprotocol.BeaconNode)
+	if options.Capture.Captures(options.SSVShare.ValidatorPubKey) {
+		options.Beacon = capture.NewBeacon(options.Capture, options.SSVShare.ValidatorPubKey, options.Beacon)
 	}
 
 	domainType := types.GetDefaultDomain()
@@ -746,9 +942,10 @@ func SetupRunners(ctx context.Context, logger *zap.Logger, options validator.Opt
 				//logger.Debug("leader", zap.Int("operator_id", int(leader)))
 				return leader
 			},
-			Storage: options.Storage.Get(role),
-			Network: options.Network,
-			Timer:   roundtimer.New(ctx, nil),
+			Storage:    options.Storage.Get(role),
+			Network:    options.Network,
+			Timer:      roundtimer.New(ctx, nil),
+			DutyTracer: options.DutyTracer,
 		}
 		config.ValueCheckF = valueCheckF
 
@@ -762,30 +959,42 @@ func SetupRunners(ctx context.Context, logger *zap.Logger, options validator.Opt
 	for _, role := range runnersType {
 		switch role {
 		case spectypes.BNRoleAttester:
-			valCheck := specssv.AttesterValueCheckF(options.Signer, options.BeaconNetwork, options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index, options.SSVShare.SharePubKey)
+			valCheck := runner.AttesterValueCheckF(options.Signer, options.BeaconNetwork, options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index, options.SSVShare.SharePubKey)
 			qbftCtrl := buildController(spectypes.BNRoleAttester, valCheck)
 			runners[role] = runner.NewAttesterRunnner(options.BeaconNetwork, &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, valCheck, 0)
 		case spectypes.BNRoleProposer:
-			proposedValueCheck := specssv.ProposerValueCheckF(options.Signer, options.BeaconNetwork, options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index, options.SSVShare.SharePubKey, options.BuilderProposals)
+			proposedValueCheck := runner.ProposerValueCheckF(options.Signer, options.BeaconNetwork, options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index, options.SSVShare.SharePubKey, options.BuilderProposals)
 			qbftCtrl := buildController(spectypes.BNRoleProposer, proposedValueCheck)
 			runners[role] = runner.NewProposerRunner(options.BeaconNetwork, &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, proposedValueCheck, 0)
 			runners[role].(*runner.ProposerRunner).ProducesBlindedBlocks = options.BuilderProposals // apply blinded block flag
 		case spectypes.BNRoleAggregator:
-			aggregatorValueCheckF := specssv.AggregatorValueCheckF(options.Signer, options.BeaconNetwork, options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index)
+			aggregatorValueCheckF := runner.AggregatorValueCheckF(options.BeaconNetwork, options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index)
 			qbftCtrl := buildController(spectypes.BNRoleAggregator, aggregatorValueCheckF)
 			runners[role] = runner.NewAggregatorRunner(options.BeaconNetwork, &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, aggregatorValueCheckF, 0)
 		case spectypes.BNRoleSyncCommittee:
-			syncCommitteeValueCheckF := specssv.SyncCommitteeValueCheckF(options.Signer, options.BeaconNetwork, options.SSVShare.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index)
+			syncCommitteeValueCheckF := runner.SyncCommitteeValueCheckF(options.BeaconNetwork, options.SSVShare.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index)
 			qbftCtrl := buildController(spectypes.BNRoleSyncCommittee, syncCommitteeValueCheckF)
 			runners[role] = runner.NewSyncCommitteeRunner(options.BeaconNetwork, &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, syncCommitteeValueCheckF, 0)
 		case spectypes.BNRoleSyncCommitteeContribution:
-			syncCommitteeContributionValueCheckF := specssv.SyncCommitteeContributionValueCheckF(options.Signer, options.BeaconNetwork, options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index)
+			syncCommitteeContributionValueCheckF := runner.SyncCommitteeContributionValueCheckF(options.BeaconNetwork, options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index)
 			qbftCtrl := buildController(spectypes.BNRoleSyncCommitteeContribution, syncCommitteeContributionValueCheckF)
 			runners[role] = runner.NewSyncCommitteeAggregatorRunner(options.BeaconNetwork, &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, syncCommitteeContributionValueCheckF, 0)
 		case spectypes.BNRoleValidatorRegistration:
 			qbftCtrl := buildController(spectypes.BNRoleValidatorRegistration, nil)
-			runners[role] = runner.NewValidatorRegistrationRunner(spectypes.PraterNetwork, &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer)
+			runners[role] = runner.NewValidatorRegistrationRunner(options.BeaconNetwork, &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer)
+		case message.BNRoleVoluntaryExit:
+			if canExit {
+				runners[role] = runner.NewVoluntaryExitRunner(options.BeaconNetwork, &options.SSVShare.Share, exitBeacon, options.Network, options.Signer)
+			}
+		case message.BNRoleBLSToExecutionChange:
+			if canExit {
+				runners[role] = runner.NewBLSToExecutionChangeRunner(options.BeaconNetwork, &options.SSVShare.Share, exitBeacon, options.Network, options.Signer)
+			}
 		}
 	}
+	for _, r := range runners {
+		r.GetBaseRunner().Shadow = options.ShadowMode
+		r.GetBaseRunner().DutyTracer = options.DutyTracer
+	}
 	return runners
 }
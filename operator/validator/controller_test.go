@@ -2,23 +2,32 @@ package validator
 
 import (
 	"context"
+	"crypto/sha256"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/bloxapp/ssv/logging"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	spectestingutils "github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/network/forks/genesis"
+	"github.com/bloxapp/ssv/networkconfig"
 	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/dutytrace"
 	"github.com/bloxapp/ssv/protocol/v2/message"
+	qbfttesting "github.com/bloxapp/ssv/protocol/v2/qbft/testing"
 	"github.com/bloxapp/ssv/protocol/v2/queue/worker"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/validator"
 	"github.com/bloxapp/ssv/protocol/v2/types"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
 )
 
 // TODO: increase test coverage, add more tests, e.g.:
@@ -149,7 +158,7 @@ func setupController(logger *zap.Logger, validators map[string]*validator.Valida
 		},
 		metadataUpdateQueue:    nil,
 		metadataUpdateInterval: 0,
-		messageRouter:          newMessageRouter(genesis.New().MsgID()),
+		messageRouter:          newMessageRouter(genesis.New().MsgID(), nil),
 		messageWorker: worker.NewWorker(logger, &worker.Config{
 			Ctx:          context.Background(),
 			WorkersCount: 1,
@@ -201,3 +210,106 @@ func generateDecidedMessage(t *testing.T, identifier spectypes.MessageID) []byte
 	require.NoError(t, err)
 	return res
 }
+
+func TestSetupRunnersShadowMode(t *testing.T) {
+	logger := logging.TestLogger(t)
+	keySet := spectestingutils.Testing4SharesSet()
+	ctrl := gomock.NewController(t)
+	bn := beacon.NewMockBeaconNode(ctrl)
+	bn.EXPECT().DomainData(gomock.Any(), gomock.Any()).DoAndReturn(spectestingutils.NewTestingBeaconNode().DomainData).AnyTimes()
+	net := spectestingutils.NewTestingNetwork()
+
+	runners := SetupRunners(context.Background(), logger, validator.Options{
+		Network:       net,
+		Beacon:        bn,
+		BeaconNetwork: beacon.NewNetwork(spectypes.BeaconTestNetwork),
+		Storage:       qbfttesting.TestingStores(logger),
+		SSVShare: &types.SSVShare{
+			Share:    *spectestingutils.TestingShare(keySet),
+			Metadata: types.Metadata{BeaconMetadata: &beacon.ValidatorMetadata{Index: spectestingutils.TestingValidatorIndex}},
+		},
+		Signer:     spectestingutils.NewTestingKeyManager(),
+		ShadowMode: true,
+	})
+	require.Len(t, runners, 8)
+	for _, r := range runners {
+		require.True(t, r.GetBaseRunner().Shadow)
+	}
+
+	// the duty runs, but nothing it signs reaches the network
+	r := runners[spectypes.BNRoleValidatorRegistration]
+	require.NoError(t, r.StartNewDuty(logger, &spectestingutils.TestingValidatorRegistrationDuty))
+	require.Empty(t, net.BroadcastedMsgs)
+	sig, err := r.GetSigner().SignRoot(&spectypes.PartialSignatureMessages{}, spectypes.PartialSignatureType, keySet.Shares[1].GetPublicKey().Serialize())
+	require.NoError(t, err)
+	require.Equal(t, make(spectypes.Signature, 96), sig)
+}
+
+func TestSetupRunnersDutyTracer(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.New(logger, basedb.Options{Type: "badger-memory", Path: ""})
+	require.NoError(t, err)
+	defer db.Close(logger)
+
+	network := networkconfig.TestNetwork.Beacon
+	recorder := dutytrace.New(dutytrace.Options{
+		Ctx:     context.Background(),
+		DB:      db,
+		Network: network,
+		Config:  dutytrace.Config{RetentionSlots: 100, MaxEvents: 10, FlushInterval: time.Minute},
+	})
+	keySet := spectestingutils.Testing4SharesSet()
+	runners := SetupRunners(context.Background(), logger, validator.Options{
+		Network:       spectestingutils.NewTestingNetwork(),
+		Beacon:        spectestingutils.NewTestingBeaconNode(),
+		BeaconNetwork: beacon.NewNetwork(spectypes.BeaconTestNetwork),
+		Storage:       qbfttesting.TestingStores(logger),
+		SSVShare: &types.SSVShare{
+			Share:    *spectestingutils.TestingShare(keySet),
+			Metadata: types.Metadata{BeaconMetadata: &beacon.ValidatorMetadata{Index: spectestingutils.TestingValidatorIndex}},
+		},
+		Signer:     spectestingutils.NewTestingKeyManager(),
+		DutyTracer: recorder,
+	})
+	for _, r := range runners {
+		require.Equal(t, recorder, r.GetBaseRunner().DutyTracer)
+		if r.GetBaseRunner().QBFTController != nil {
+			require.Equal(t, recorder, r.GetBaseRunner().QBFTController.GetConfig().GetDutyTracer())
+		}
+	}
+
+	duty := spectestingutils.TestingValidatorRegistrationDuty
+	duty.Slot = network.EstimatedCurrentSlot()
+	require.NoError(t, runners[spectypes.BNRoleValidatorRegistration].StartNewDuty(logger, &duty))
+
+	traces, err := recorder.Traces(logger, duty.Slot)
+	require.NoError(t, err)
+	require.Len(t, traces, 1)
+	require.Equal(t, spectypes.BNRoleValidatorRegistration, traces[0].Role)
+	require.Equal(t, keySet.ValidatorPK.Serialize(), traces[0].PubKey)
+	require.Len(t, traces[0].Events, 1)
+	require.Equal(t, dutytrace.StageStarted, traces[0].Events[0].Stage)
+}
+
+func TestValidateBLSWithdrawalCredentials(t *testing.T) {
+	var pubKey phase0.BLSPubKey
+	copy(pubKey[:], spectestingutils.TestingValidatorPubKey[:])
+	hash := sha256.Sum256(pubKey[:])
+
+	creds := make([]byte, 32)
+	copy(creds[1:], hash[1:])
+	require.NoError(t, validateBLSWithdrawalCredentials(pubKey, creds))
+
+	t.Run("execution credentials", func(t *testing.T) {
+		executionCreds := append([]byte{}, creds...)
+		executionCreds[0] = 0x01
+		require.Error(t, validateBLSWithdrawalCredentials(pubKey, executionCreds))
+	})
+
+	t.Run("other withdrawal key", func(t *testing.T) {
+		otherHash := sha256.Sum256([]byte("other"))
+		otherCreds := make([]byte, 32)
+		copy(otherCreds[1:], otherHash[1:])
+		require.Error(t, validateBLSWithdrawalCredentials(pubKey, otherCreds))
+	})
+}
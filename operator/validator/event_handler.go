@@ -71,6 +71,8 @@ func (c *controller) Eth1EventHandler(logger *zap.Logger, ongoingSync bool) eth1
 			logs, err = c.handleValidatorAddedEvent(logger, ev, ongoingSync)
 		case abiparser.ValidatorRemovedEvent:
 			logs, err = c.handleValidatorRemovedEvent(logger, ev, ongoingSync)
+		case abiparser.ValidatorExitedEvent:
+			logs, err = c.handleValidatorExitedEvent(logger, ev, ongoingSync)
 		case abiparser.ClusterLiquidatedEvent:
 			logs, err = c.handleClusterLiquidatedEvent(logger, ev, ongoingSync)
 		case abiparser.ClusterReactivatedEvent:
@@ -334,6 +336,53 @@ func (c *controller) handleValidatorRemovedEvent(
 	return logFields, nil
 }
 
+// handleValidatorExitedEvent handles registry contract event for validator exit requested by its owner,
+// the exit duty is started at the slot of the event's block so all the operators sign the same exit
+func (c *controller) handleValidatorExitedEvent(
+	logger *zap.Logger,
+	event abiparser.ValidatorExitedEvent,
+	ongoingSync bool,
+) ([]zap.Field, error) {
+	share := c.sharesStorage.Get(event.PublicKey)
+	if share == nil {
+		return nil, &abiparser.MalformedEventError{
+			Err: errors.New("could not find validator share"),
+		}
+	}
+	if event.Owner != share.OwnerAddress {
+		return nil, &abiparser.MalformedEventError{
+			Err: errors.Errorf(
+				"validator exit requested by a different owner address: expected %s, got %s",
+				share.OwnerAddress.String(),
+				event.Owner.String(),
+			),
+		}
+	}
+
+	logFields := []zap.Field{
+		zap.String("validatorPubKey", hex.EncodeToString(share.ValidatorPubKey)),
+		zap.String("ownerAddress", share.OwnerAddress.String()),
+	}
+
+	// exits of past events are not replayed, they were either submitted or are stale by now
+	if !ongoingSync || !share.BelongsToOperator(c.operatorData.ID) {
+		return logFields, nil
+	}
+
+	slot := c.beaconNetwork.EstimatedSlotAtTime(int64(event.BlockTimestamp))
+	if c.beaconNetwork.EstimatedCurrentSlot() > slot+phase0.Slot(c.beaconNetwork.SlotsPerEpoch()) {
+		logger.Warn("ignoring stale validator exit event", append(logFields, fields.Slot(slot))...)
+		return logFields, nil
+	}
+
+	var pubKey phase0.BLSPubKey
+	copy(pubKey[:], share.ValidatorPubKey)
+	if err := c.ExitValidator(logger, pubKey, slot); err != nil {
+		return nil, errors.Wrap(err, "could not start validator exit")
+	}
+	return append(logFields, fields.Slot(slot)), nil
+}
+
 // handleClusterLiquidatedEvent handles registry contract event for cluster liquidated
 func (c *controller) handleClusterLiquidatedEvent(
 	logger *zap.Logger,
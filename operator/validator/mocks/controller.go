@@ -7,8 +7,10 @@ package mocks
 import (
 	reflect "reflect"
 
+	bellatrix "github.com/attestantio/go-eth2-client/spec/bellatrix"
 	phase0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	eth1 "github.com/bloxapp/ssv/eth1"
+	storage0 "github.com/bloxapp/ssv/ibft/storage"
 	validator "github.com/bloxapp/ssv/protocol/v2/ssv/validator"
 	types "github.com/bloxapp/ssv/protocol/v2/types"
 	storage "github.com/bloxapp/ssv/registry/storage"
@@ -69,6 +71,34 @@ func (mr *MockControllerMockRecorder) Eth1EventHandler(logger, ongoingSync inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Eth1EventHandler", reflect.TypeOf((*MockController)(nil).Eth1EventHandler), logger, ongoingSync)
 }
 
+// ChangeWithdrawalCredentials mocks base method.
+func (m *MockController) ChangeWithdrawalCredentials(logger *zap.Logger, pubKey phase0.BLSPubKey, slot phase0.Slot, address bellatrix.ExecutionAddress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeWithdrawalCredentials", logger, pubKey, slot, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeWithdrawalCredentials indicates an expected call of ChangeWithdrawalCredentials.
+func (mr *MockControllerMockRecorder) ChangeWithdrawalCredentials(logger, pubKey, slot, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeWithdrawalCredentials", reflect.TypeOf((*MockController)(nil).ChangeWithdrawalCredentials), logger, pubKey, slot, address)
+}
+
+// ExitValidator mocks base method.
+func (m *MockController) ExitValidator(logger *zap.Logger, pubKey phase0.BLSPubKey, slot phase0.Slot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExitValidator", logger, pubKey, slot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExitValidator indicates an expected call of ExitValidator.
+func (mr *MockControllerMockRecorder) ExitValidator(logger, pubKey, slot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExitValidator", reflect.TypeOf((*MockController)(nil).ExitValidator), logger, pubKey, slot)
+}
+
 // GetOperatorData mocks base method.
 func (m *MockController) GetOperatorData() *storage.OperatorData {
 	m.ctrl.T.Helper()
@@ -141,6 +171,20 @@ func (mr *MockControllerMockRecorder) ListenToEth1Events(logger, feed interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenToEth1Events", reflect.TypeOf((*MockController)(nil).ListenToEth1Events), logger, feed)
 }
 
+// QBFTStores mocks base method.
+func (m *MockController) QBFTStores() *storage0.QBFTStores {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QBFTStores")
+	ret0, _ := ret[0].(*storage0.QBFTStores)
+	return ret0
+}
+
+// QBFTStores indicates an expected call of QBFTStores.
+func (mr *MockControllerMockRecorder) QBFTStores() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QBFTStores", reflect.TypeOf((*MockController)(nil).QBFTStores))
+}
+
 // StartNetworkHandlers mocks base method.
 func (m *MockController) StartNetworkHandlers(logger *zap.Logger) {
 	m.ctrl.T.Helper()
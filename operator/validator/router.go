@@ -1,33 +1,132 @@
 package validator
 
 import (
+	"context"
+	"sync"
+
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/bloxapp/ssv/network/forks"
+	"github.com/bloxapp/ssv/protocol/v2/capture"
 	"go.uber.org/zap"
 )
 
-const bufSize = 1024
+const (
+	bufSize = 1024
+	// validatorQuota is the number of messages a single validator may have buffered,
+	// so that a flooded validator can't take over the buffer of the others.
+	validatorQuota = bufSize / 8
+)
 
-func newMessageRouter(msgID forks.MsgIDFunc) *messageRouter {
+func newMessageRouter(msgID forks.MsgIDFunc, recorder *capture.Recorder) *messageRouter {
 	return &messageRouter{
-		ch:    make(chan spectypes.SSVMessage, bufSize),
-		msgID: msgID,
+		pending:  make(map[string][]spectypes.SSVMessage),
+		notify:   make(chan struct{}, 1),
+		msgID:    msgID,
+		recorder: recorder,
 	}
 }
 
+// messageRouter buffers the messages of each validator separately and dispatches them
+// round-robin across validators, so that a flooded validator doesn't delay or drop the messages of the others.
 type messageRouter struct {
-	ch    chan spectypes.SSVMessage
+	mu sync.Mutex
+	// pending holds the buffered messages of each validator by public key, in arrival order
+	pending map[string][]spectypes.SSVMessage
+	// order holds the validators with pending messages, in the order they're dispatched
+	order []string
+	len   int
+	// notify wakes up a waiting Next
+	notify chan struct{}
+
 	msgID forks.MsgIDFunc
+	// recorder captures the dropped messages, the others are captured by the validators handling them
+	recorder *capture.Recorder
 }
 
+// Route buffers the message, unless its validator exceeds its quota.
+// When the buffer is full, the oldest message of the validator with the most buffered messages
+// is dropped to make room, or the routed message if its validator has the most.
 func (r *messageRouter) Route(logger *zap.Logger, message spectypes.SSVMessage) {
+	key := string(message.GetID().GetPubKey())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.pending[key]) >= validatorQuota {
+		r.drop(logger, &message, "validator exceeded its message router quota. dropping message")
+		return
+	}
+	if r.len >= bufSize {
+		longest := r.longest()
+		if len(r.pending[longest]) <= len(r.pending[key])+1 {
+			r.drop(logger, &message, "message router buffer is full. dropping message")
+			return
+		}
+		evicted := r.pending[longest][0]
+		r.pending[longest] = r.pending[longest][1:]
+		r.len--
+		r.drop(logger, &evicted, "message router buffer is full. dropping message of the most buffered validator")
+	}
+
+	if len(r.pending[key]) == 0 {
+		r.order = append(r.order, key)
+	}
+	r.pending[key] = append(r.pending[key], message)
+	r.len++
 	select {
-	case r.ch <- message:
+	case r.notify <- struct{}{}:
 	default:
-		logger.Warn("message router buffer is full. dropping message")
 	}
 }
 
-func (r *messageRouter) GetMessageChan() <-chan spectypes.SSVMessage {
-	return r.ch
+// Next returns the next message, taking one message of each validator in turn.
+// It blocks until a message is available, and returns false when the context is canceled.
+func (r *messageRouter) Next(ctx context.Context) (spectypes.SSVMessage, bool) {
+	for {
+		if msg, ok := r.tryNext(); ok {
+			return msg, true
+		}
+		select {
+		case <-r.notify:
+		case <-ctx.Done():
+			return spectypes.SSVMessage{}, false
+		}
+	}
+}
+
+func (r *messageRouter) tryNext() (spectypes.SSVMessage, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.order) == 0 {
+		return spectypes.SSVMessage{}, false
+	}
+	key := r.order[0]
+	r.order = r.order[1:]
+	msgs := r.pending[key]
+	msg := msgs[0]
+	if len(msgs) == 1 {
+		delete(r.pending, key)
+	} else {
+		r.pending[key] = msgs[1:]
+		r.order = append(r.order, key)
+	}
+	r.len--
+	return msg, true
+}
+
+// longest returns the validator with the most buffered messages, the caller must hold the lock
+func (r *messageRouter) longest() string {
+	var longest string
+	for _, key := range r.order {
+		if len(r.pending[key]) > len(r.pending[longest]) {
+			longest = key
+		}
+	}
+	return longest
+}
+
+func (r *messageRouter) drop(logger *zap.Logger, message *spectypes.SSVMessage, reason string) {
+	logger.Warn(reason)
+	r.recorder.DroppedMessage(message)
 }
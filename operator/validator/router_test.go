@@ -19,7 +19,7 @@ func TestRouter(t *testing.T) {
 
 	logger := logging.TestLogger(t)
 
-	router := newMessageRouter(genesis.New().MsgID())
+	router := newMessageRouter(genesis.New().MsgID(), nil)
 
 	expectedCount := 1000
 	count := 0
@@ -29,20 +29,24 @@ func TestRouter(t *testing.T) {
 	go func() {
 		defer wg.Done()
 
-		cn := router.GetMessageChan()
-		for msg := range cn {
+		for {
+			msg, ok := router.Next(ctx)
+			if !ok {
+				return
+			}
 			require.NotNil(t, msg)
 			count++
-			if ctx.Err() != nil || count >= expectedCount {
+			if count >= expectedCount {
 				return
 			}
 		}
 	}()
 
 	for i := 0; i < expectedCount; i++ {
+		// spread across validators so that none exceeds its quota
 		msg := spectypes.SSVMessage{
 			MsgType: spectypes.MsgType(i % 3),
-			MsgID:   spectypes.NewMsgID(types.GetDefaultDomain(), []byte{1, 1, 1, 1, 1}, spectypes.BNRoleAttester),
+			MsgID:   spectypes.NewMsgID(types.GetDefaultDomain(), []byte{byte(i % 16), 1, 1, 1, 1}, spectypes.BNRoleAttester),
 			Data:    []byte(fmt.Sprintf("data-%d", i)),
 		}
 		router.Route(logger, msg)
@@ -55,3 +59,90 @@ func TestRouter(t *testing.T) {
 
 	require.Equal(t, count, expectedCount)
 }
+
+func routerTestMessage(pk byte, i int) spectypes.SSVMessage {
+	return spectypes.SSVMessage{
+		MsgType: spectypes.SSVConsensusMsgType,
+		MsgID:   spectypes.NewMsgID(types.GetDefaultDomain(), []byte{pk, 1, 1, 1, 1}, spectypes.BNRoleAttester),
+		Data:    []byte(fmt.Sprintf("data-%d", i)),
+	}
+}
+
+func TestRouterRoundRobin(t *testing.T) {
+	logger := logging.TestLogger(t)
+	router := newMessageRouter(genesis.New().MsgID(), nil)
+
+	router.Route(logger, routerTestMessage(1, 0))
+	router.Route(logger, routerTestMessage(1, 1))
+	router.Route(logger, routerTestMessage(1, 2))
+	router.Route(logger, routerTestMessage(2, 0))
+
+	var order []byte
+	for i := 0; i < 4; i++ {
+		msg, ok := router.Next(context.Background())
+		require.True(t, ok)
+		order = append(order, msg.GetID().GetPubKey()[0])
+	}
+	require.Equal(t, []byte{1, 2, 1, 1}, order)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, ok := router.Next(ctx)
+	require.False(t, ok)
+}
+
+func TestRouterFloodingValidator(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	t.Run("quota", func(t *testing.T) {
+		router := newMessageRouter(genesis.New().MsgID(), nil)
+
+		// a flooding validator is capped at its quota, without filling the buffer
+		for i := 0; i < bufSize*4; i++ {
+			router.Route(logger, routerTestMessage(1, i))
+		}
+		require.Equal(t, validatorQuota, router.len)
+
+		for i := 0; i < 10; i++ {
+			router.Route(logger, routerTestMessage(2, i))
+		}
+		counts := drainRouter(t, router)
+		require.Equal(t, validatorQuota, counts[1])
+		require.Equal(t, 10, counts[2])
+	})
+
+	t.Run("full buffer", func(t *testing.T) {
+		router := newMessageRouter(genesis.New().MsgID(), nil)
+
+		// many flooding validators fill the buffer
+		flooding := bufSize / validatorQuota * 2
+		for i := 0; i < validatorQuota; i++ {
+			for pk := 1; pk <= flooding; pk++ {
+				router.Route(logger, routerTestMessage(byte(pk), i))
+			}
+		}
+		require.Equal(t, bufSize, router.len)
+
+		// yet a quiet validator gets all its messages through
+		for i := 0; i < 10; i++ {
+			router.Route(logger, routerTestMessage(0xff, i))
+		}
+		require.Equal(t, bufSize, router.len)
+		counts := drainRouter(t, router)
+		require.Equal(t, 10, counts[0xff])
+		for pk := 1; pk <= flooding; pk++ {
+			require.LessOrEqual(t, counts[byte(pk)], validatorQuota)
+		}
+	})
+}
+
+// drainRouter returns the number of buffered messages of each validator by the first byte of its public key
+func drainRouter(t *testing.T, router *messageRouter) map[byte]int {
+	counts := make(map[byte]int)
+	for router.len > 0 {
+		msg, ok := router.Next(context.Background())
+		require.True(t, ok)
+		counts[msg.GetID().GetPubKey()[0]]++
+	}
+	return counts
+}
@@ -1,6 +1,8 @@
 package forksprotocol
 
 import (
+	"fmt"
+
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"go.uber.org/zap"
 )
@@ -17,15 +19,65 @@ const (
 	ForkVersionEmpty ForkVersion = ""
 	// GenesisForkVersion is the version for v0
 	GenesisForkVersion ForkVersion = "genesis"
+	// V3ForkVersion is the version which moves to the ssv.v3 topics and sync protocols
+	V3ForkVersion ForkVersion = "v3"
 )
 
+// KnownForkVersions lists the fork versions supported by this node, in activation order
+var KnownForkVersions = []ForkVersion{GenesisForkVersion, V3ForkVersion}
+
 // ForkHandler handles a fork event
 type ForkHandler interface {
 	// OnFork is called upon a ForkVersion change
 	OnFork(logger *zap.Logger, forkVersion ForkVersion) error
 }
 
-// GetCurrentForkVersion returns the current fork version
-func GetCurrentForkVersion(currentEpoch phase0.Epoch) ForkVersion {
-	return GenesisForkVersion
+// Fork is a fork version activated at an epoch
+type Fork struct {
+	Version ForkVersion  `json:"version" yaml:"Version"`
+	Epoch   phase0.Epoch `json:"epoch" yaml:"Epoch"`
+}
+
+// Schedule lists the forks of a network by activation epoch,
+// the genesis fork is active until the first scheduled fork
+type Schedule []Fork
+
+// VersionAt returns the fork version active at the given epoch
+func (s Schedule) VersionAt(epoch phase0.Epoch) ForkVersion {
+	version := GenesisForkVersion
+	for _, f := range s {
+		if f.Epoch > epoch {
+			break
+		}
+		version = f.Version
+	}
+	return version
+}
+
+// Validate checks that the scheduled versions are known and activated in order
+func (s Schedule) Validate() error {
+	for i, f := range s {
+		known := -1
+		for j, v := range KnownForkVersions {
+			if v == f.Version {
+				known = j
+			}
+		}
+		if known == -1 {
+			return fmt.Errorf("unknown fork version %q", f.Version)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := s[i-1]
+		if f.Epoch <= prev.Epoch {
+			return fmt.Errorf("fork %s must activate after fork %s", f.Version, prev.Version)
+		}
+		for j, v := range KnownForkVersions {
+			if v == prev.Version && j >= known {
+				return fmt.Errorf("fork %s can't follow fork %s", f.Version, prev.Version)
+			}
+		}
+	}
+	return nil
 }
@@ -0,0 +1,26 @@
+package forksprotocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_VersionAt(t *testing.T) {
+	require.Equal(t, GenesisForkVersion, Schedule(nil).VersionAt(100))
+
+	s := Schedule{{Version: V3ForkVersion, Epoch: 100}}
+	require.Equal(t, GenesisForkVersion, s.VersionAt(99))
+	require.Equal(t, V3ForkVersion, s.VersionAt(100))
+	require.Equal(t, V3ForkVersion, s.VersionAt(101))
+}
+
+func TestSchedule_Validate(t *testing.T) {
+	require.NoError(t, Schedule(nil).Validate())
+	require.NoError(t, Schedule{{Version: V3ForkVersion, Epoch: 100}}.Validate())
+	require.NoError(t, Schedule{{Version: GenesisForkVersion}, {Version: V3ForkVersion, Epoch: 100}}.Validate())
+
+	require.ErrorContains(t, Schedule{{Version: "v99", Epoch: 100}}.Validate(), "unknown fork version")
+	require.ErrorContains(t, Schedule{{Version: GenesisForkVersion, Epoch: 100}, {Version: V3ForkVersion, Epoch: 100}}.Validate(), "must activate after")
+	require.ErrorContains(t, Schedule{{Version: V3ForkVersion, Epoch: 100}, {Version: GenesisForkVersion, Epoch: 200}}.Validate(), "can't follow")
+}
@@ -6,6 +6,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	specssv "github.com/bloxapp/ssv-spec/ssv"
 	spectypes "github.com/bloxapp/ssv-spec/types"
@@ -35,6 +36,24 @@ type beaconSubscriber interface {
 type beaconValidator interface {
 	// GetValidatorData returns metadata (balance, index, status, more) for each pubkey from the node
 	GetValidatorData(validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error)
+	// ValidatorLiveness returns whether the given validators were seen live by the node in the given epoch
+	ValidatorLiveness(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*ValidatorLiveness, error)
+}
+
+// ValidatorLiveness is whether a validator was seen live in an epoch, e.g. attesting or proposing
+type ValidatorLiveness struct {
+	Index  phase0.ValidatorIndex
+	IsLive bool
+}
+
+type voluntaryExitSubmitter interface {
+	// SubmitVoluntaryExit submits a signed voluntary exit
+	SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error
+}
+
+type blsToExecutionChangeSubmitter interface {
+	// SubmitBLSToExecutionChange submits a signed change of BLS withdrawal credentials to an execution address
+	SubmitBLSToExecutionChange(change *capella.SignedBLSToExecutionChange) error
 }
 
 type proposer interface {
@@ -55,13 +74,18 @@ type BeaconNode interface {
 	beaconValidator
 	signer // TODO need to handle differently
 	proposer
+	voluntaryExitSubmitter
+	blsToExecutionChangeSubmitter
 }
 
 // Options for controller struct creation
 type Options struct {
-	Context        context.Context
-	Network        Network
+	Context context.Context
+	Network Network
+	// BeaconNodeAddr is the address of the beacon node, multiple addresses are separated by ';'
 	BeaconNodeAddr string `yaml:"BeaconNodeAddr" env:"BEACON_NODE_ADDR" env-required:"true"`
-	Graffiti       []byte
-	GasLimit       uint64
+	// CrossCheckDuties fetches duties from all healthy beacon nodes and uses the result most of them agree on
+	CrossCheckDuties bool `yaml:"CrossCheckDuties" env:"BEACON_CROSS_CHECK_DUTIES" env-description:"Cross check duties across multiple beacon nodes"`
+	Graffiti         []byte
+	GasLimit         uint64
 }
@@ -14,6 +14,7 @@ import (
 	spec "github.com/attestantio/go-eth2-client/spec"
 	altair "github.com/attestantio/go-eth2-client/spec/altair"
 	bellatrix "github.com/attestantio/go-eth2-client/spec/bellatrix"
+	capella "github.com/attestantio/go-eth2-client/spec/capella"
 	phase0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	types "github.com/bloxapp/ssv-spec/types"
 	ssz "github.com/ferranbt/fastssz"
@@ -177,6 +178,95 @@ func (mr *MockbeaconValidatorMockRecorder) GetValidatorData(validatorPubKeys int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorData", reflect.TypeOf((*MockbeaconValidator)(nil).GetValidatorData), validatorPubKeys)
 }
 
+// ValidatorLiveness mocks base method.
+func (m *MockbeaconValidator) ValidatorLiveness(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*ValidatorLiveness, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatorLiveness", epoch, validatorIndices)
+	ret0, _ := ret[0].([]*ValidatorLiveness)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidatorLiveness indicates an expected call of ValidatorLiveness.
+func (mr *MockbeaconValidatorMockRecorder) ValidatorLiveness(epoch, validatorIndices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatorLiveness", reflect.TypeOf((*MockbeaconValidator)(nil).ValidatorLiveness), epoch, validatorIndices)
+}
+
+// MockvoluntaryExitSubmitter is a mock of voluntaryExitSubmitter interface.
+type MockvoluntaryExitSubmitter struct {
+	ctrl     *gomock.Controller
+	recorder *MockvoluntaryExitSubmitterMockRecorder
+}
+
+// MockvoluntaryExitSubmitterMockRecorder is the mock recorder for MockvoluntaryExitSubmitter.
+type MockvoluntaryExitSubmitterMockRecorder struct {
+	mock *MockvoluntaryExitSubmitter
+}
+
+// NewMockvoluntaryExitSubmitter creates a new mock instance.
+func NewMockvoluntaryExitSubmitter(ctrl *gomock.Controller) *MockvoluntaryExitSubmitter {
+	mock := &MockvoluntaryExitSubmitter{ctrl: ctrl}
+	mock.recorder = &MockvoluntaryExitSubmitterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockvoluntaryExitSubmitter) EXPECT() *MockvoluntaryExitSubmitterMockRecorder {
+	return m.recorder
+}
+
+// SubmitVoluntaryExit mocks base method.
+func (m *MockvoluntaryExitSubmitter) SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitVoluntaryExit", voluntaryExit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitVoluntaryExit indicates an expected call of SubmitVoluntaryExit.
+func (mr *MockvoluntaryExitSubmitterMockRecorder) SubmitVoluntaryExit(voluntaryExit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitVoluntaryExit", reflect.TypeOf((*MockvoluntaryExitSubmitter)(nil).SubmitVoluntaryExit), voluntaryExit)
+}
+
+// MockblsToExecutionChangeSubmitter is a mock of blsToExecutionChangeSubmitter interface.
+type MockblsToExecutionChangeSubmitter struct {
+	ctrl     *gomock.Controller
+	recorder *MockblsToExecutionChangeSubmitterMockRecorder
+}
+
+// MockblsToExecutionChangeSubmitterMockRecorder is the mock recorder for MockblsToExecutionChangeSubmitter.
+type MockblsToExecutionChangeSubmitterMockRecorder struct {
+	mock *MockblsToExecutionChangeSubmitter
+}
+
+// NewMockblsToExecutionChangeSubmitter creates a new mock instance.
+func NewMockblsToExecutionChangeSubmitter(ctrl *gomock.Controller) *MockblsToExecutionChangeSubmitter {
+	mock := &MockblsToExecutionChangeSubmitter{ctrl: ctrl}
+	mock.recorder = &MockblsToExecutionChangeSubmitterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockblsToExecutionChangeSubmitter) EXPECT() *MockblsToExecutionChangeSubmitterMockRecorder {
+	return m.recorder
+}
+
+// SubmitBLSToExecutionChange mocks base method.
+func (m *MockblsToExecutionChangeSubmitter) SubmitBLSToExecutionChange(change *capella.SignedBLSToExecutionChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitBLSToExecutionChange", change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitBLSToExecutionChange indicates an expected call of SubmitBLSToExecutionChange.
+func (mr *MockblsToExecutionChangeSubmitterMockRecorder) SubmitBLSToExecutionChange(change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitBLSToExecutionChange", reflect.TypeOf((*MockblsToExecutionChangeSubmitter)(nil).SubmitBLSToExecutionChange), change)
+}
+
 // Mockproposer is a mock of proposer interface.
 type Mockproposer struct {
 	ctrl     *gomock.Controller
@@ -600,6 +690,34 @@ func (mr *MockBeaconNodeMockRecorder) SubmitValidatorRegistration(pubkey, feeRec
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitValidatorRegistration", reflect.TypeOf((*MockBeaconNode)(nil).SubmitValidatorRegistration), pubkey, feeRecipient, sig)
 }
 
+// SubmitBLSToExecutionChange mocks base method.
+func (m *MockBeaconNode) SubmitBLSToExecutionChange(change *capella.SignedBLSToExecutionChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitBLSToExecutionChange", change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitBLSToExecutionChange indicates an expected call of SubmitBLSToExecutionChange.
+func (mr *MockBeaconNodeMockRecorder) SubmitBLSToExecutionChange(change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitBLSToExecutionChange", reflect.TypeOf((*MockBeaconNode)(nil).SubmitBLSToExecutionChange), change)
+}
+
+// SubmitVoluntaryExit mocks base method.
+func (m *MockBeaconNode) SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitVoluntaryExit", voluntaryExit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitVoluntaryExit indicates an expected call of SubmitVoluntaryExit.
+func (mr *MockBeaconNodeMockRecorder) SubmitVoluntaryExit(voluntaryExit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitVoluntaryExit", reflect.TypeOf((*MockBeaconNode)(nil).SubmitVoluntaryExit), voluntaryExit)
+}
+
 // SubscribeToCommitteeSubnet mocks base method.
 func (m *MockBeaconNode) SubscribeToCommitteeSubnet(subscription []*v1.BeaconCommitteeSubscription) error {
 	m.ctrl.T.Helper()
@@ -643,3 +761,18 @@ func (mr *MockBeaconNodeMockRecorder) SyncCommitteeSubnetID(index interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncCommitteeSubnetID", reflect.TypeOf((*MockBeaconNode)(nil).SyncCommitteeSubnetID), index)
 }
+
+// ValidatorLiveness mocks base method.
+func (m *MockBeaconNode) ValidatorLiveness(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*ValidatorLiveness, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatorLiveness", epoch, validatorIndices)
+	ret0, _ := ret[0].([]*ValidatorLiveness)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidatorLiveness indicates an expected call of ValidatorLiveness.
+func (mr *MockBeaconNodeMockRecorder) ValidatorLiveness(epoch, validatorIndices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatorLiveness", reflect.TypeOf((*MockBeaconNode)(nil).ValidatorLiveness), epoch, validatorIndices)
+}
@@ -11,6 +11,19 @@ import (
 type Network struct {
 	spectypes.BeaconNetwork
 	LocalTestNet bool
+	// Custom overrides the parameters of BeaconNetwork, for networks which aren't known to the spec.
+	Custom *CustomParams
+	// now returns the current time, time.Now unless set by WithClock
+	now func() time.Time
+}
+
+// CustomParams are the parameters of a beacon chain network which isn't known to the spec.
+type CustomParams struct {
+	GenesisTime           uint64
+	GenesisForkVersion    [4]byte
+	GenesisValidatorsRoot phase0.Root
+	SlotDuration          time.Duration
+	SlotsPerEpoch         uint64
 }
 
 // NewNetwork creates a new beacon chain network.
@@ -29,14 +42,90 @@ func NewLocalTestNetwork(network spectypes.BeaconNetwork) Network {
 	}
 }
 
+// NewCustomNetwork creates a new beacon chain network with the given parameters,
+// the base network is still used where the spec doesn't allow overriding them.
+func NewCustomNetwork(network spectypes.BeaconNetwork, params CustomParams) Network {
+	return Network{
+		BeaconNetwork: network,
+		Custom:        &params,
+	}
+}
+
 // MinGenesisTime returns min genesis time value
 func (n Network) MinGenesisTime() uint64 {
+	if n.Custom != nil {
+		return n.Custom.GenesisTime
+	}
 	if n.LocalTestNet {
 		return 1689072978
 	}
 	return n.BeaconNetwork.MinGenesisTime()
 }
 
+// GenesisValidatorsRoot returns the genesis validators root of the network,
+// which is zero for local test networks as their genesis is not known in advance.
+func (n Network) GenesisValidatorsRoot() phase0.Root {
+	if n.Custom != nil {
+		return n.Custom.GenesisValidatorsRoot
+	}
+	if n.LocalTestNet {
+		return phase0.Root{}
+	}
+	switch n.BeaconNetwork {
+	case spectypes.MainNetwork:
+		return phase0.Root{
+			0x4b, 0x36, 0x3d, 0xb9, 0x4e, 0x28, 0x61, 0x20, 0xd7, 0x6e, 0xb9, 0x05, 0x34, 0x0f, 0xdd, 0x4e,
+			0x54, 0xbf, 0xe9, 0xf0, 0x6b, 0xf3, 0x3f, 0xf6, 0xcf, 0x5a, 0xd2, 0x7f, 0x51, 0x1b, 0xfe, 0x95,
+		}
+	case spectypes.PraterNetwork:
+		return phase0.Root{
+			0x04, 0x3d, 0xb0, 0xd9, 0xa8, 0x38, 0x13, 0x55, 0x1e, 0xe2, 0xf3, 0x34, 0x50, 0xd2, 0x37, 0x97,
+			0x75, 0x7d, 0x43, 0x09, 0x11, 0xa9, 0x32, 0x05, 0x30, 0xad, 0x8a, 0x0e, 0xab, 0xc4, 0x3e, 0xfb,
+		}
+	default:
+		return phase0.Root{}
+	}
+}
+
+// ForkVersion returns the genesis fork version of the network.
+func (n Network) ForkVersion() [4]byte {
+	if n.Custom != nil {
+		return n.Custom.GenesisForkVersion
+	}
+	return n.BeaconNetwork.ForkVersion()
+}
+
+// SlotDurationSec returns slot duration
+func (n Network) SlotDurationSec() time.Duration {
+	if n.Custom != nil {
+		return n.Custom.SlotDuration
+	}
+	return n.BeaconNetwork.SlotDurationSec()
+}
+
+// SlotsPerEpoch returns number of slots per one epoch
+func (n Network) SlotsPerEpoch() uint64 {
+	if n.Custom != nil {
+		return n.Custom.SlotsPerEpoch
+	}
+	return n.BeaconNetwork.SlotsPerEpoch()
+}
+
+// EstimatedTimeAtSlot returns the estimated start time of the given slot, in unix seconds
+func (n Network) EstimatedTimeAtSlot(slot phase0.Slot) int64 {
+	return n.GetSlotStartTime(slot).Unix()
+}
+
+// FirstSlotAtEpoch returns the first slot of the given epoch
+func (n Network) FirstSlotAtEpoch(epoch phase0.Epoch) phase0.Slot {
+	return n.GetEpochFirstSlot(epoch)
+}
+
+// EpochStartTime returns the start time of the given epoch
+func (n Network) EpochStartTime(epoch phase0.Epoch) time.Time {
+	return n.GetSlotStartTime(n.GetEpochFirstSlot(epoch))
+}
+
 // GetSlotStartTime returns the start time for the given slot
 func (n Network) GetSlotStartTime(slot phase0.Slot) time.Time {
 	timeSinceGenesisStart := uint64(slot) * uint64(n.SlotDurationSec().Seconds())
@@ -44,9 +133,20 @@ func (n Network) GetSlotStartTime(slot phase0.Slot) time.Time {
 	return start
 }
 
+// WithClock returns a copy of the network which estimates the current slot and epoch by the given clock,
+// e.g. to replay captured duties at the time they were captured.
+func (n Network) WithClock(now func() time.Time) Network {
+	n.now = now
+	return n
+}
+
 // EstimatedCurrentSlot returns the estimation of the current slot
 func (n Network) EstimatedCurrentSlot() phase0.Slot {
-	return n.EstimatedSlotAtTime(time.Now().Unix())
+	now := time.Now
+	if n.now != nil {
+		now = n.now
+	}
+	return n.EstimatedSlotAtTime(now().Unix())
 }
 
 // EstimatedSlotAtTime estimates slot at the given time
@@ -0,0 +1,414 @@
+package capture
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specssv "github.com/bloxapp/ssv-spec/ssv"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Beacon node methods whose responses are captured
+const (
+	MethodAttestationData           = "GetAttestationData"
+	MethodBeaconBlock               = "GetBeaconBlock"
+	MethodBlindedBeaconBlock        = "GetBlindedBeaconBlock"
+	MethodAggregateSelectionProof   = "SubmitAggregateSelectionProof"
+	MethodSyncMessageBlockRoot      = "GetSyncMessageBlockRoot"
+	MethodIsSyncCommitteeAggregator = "IsSyncCommitteeAggregator"
+	MethodSyncCommitteeSubnetID     = "SyncCommitteeSubnetID"
+	MethodSyncCommitteeContribution = "GetSyncCommitteeContribution"
+	MethodDomainData                = "DomainData"
+
+	MethodSubmitAttestation           = "SubmitAttestation"
+	MethodSubmitBeaconBlock           = "SubmitBeaconBlock"
+	MethodSubmitBlindedBeaconBlock    = "SubmitBlindedBeaconBlock"
+	MethodSubmitAggregateAndProof     = "SubmitSignedAggregateSelectionProof"
+	MethodSubmitSyncMessage           = "SubmitSyncMessage"
+	MethodSubmitContributionAndProof  = "SubmitSignedContributionAndProof"
+	MethodSubmitValidatorRegistration = "SubmitValidatorRegistration"
+)
+
+// capturingBeacon records the responses of the beacon node to the runners of a validator
+type capturingBeacon struct {
+	specssv.BeaconNode
+	recorder *Recorder
+	pubKey   []byte
+}
+
+// NewBeacon returns a beacon node which records its responses to the runners of the given validator
+func NewBeacon(recorder *Recorder, pubKey []byte, bn specssv.BeaconNode) specssv.BeaconNode {
+	return &capturingBeacon{BeaconNode: bn, recorder: recorder, pubKey: pubKey}
+}
+
+func (b *capturingBeacon) record(method, request string, data []byte, version spec.DataVersion, err error) {
+	response := &BeaconResponse{
+		PubKey:  b.pubKey,
+		Method:  method,
+		Request: request,
+		Data:    data,
+	}
+	if err != nil {
+		response.Error = err.Error()
+	} else {
+		response.Version = version
+	}
+	b.recorder.BeaconResponse(response)
+}
+
+func (b *capturingBeacon) recordObject(method, request string, obj ssz.Marshaler, version spec.DataVersion, err error) {
+	var data []byte
+	if err == nil && obj != nil {
+		var encodeErr error
+		if data, encodeErr = obj.MarshalSSZ(); encodeErr != nil {
+			err = errors.Wrap(encodeErr, "could not encode captured response")
+		}
+	}
+	b.record(method, request, data, version, err)
+}
+
+func (b *capturingBeacon) GetAttestationData(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (ssz.Marshaler, spec.DataVersion, error) {
+	obj, version, err := b.BeaconNode.GetAttestationData(slot, committeeIndex)
+	b.recordObject(MethodAttestationData, attestationRequest(slot, committeeIndex), obj, version, err)
+	return obj, version, err
+}
+
+func (b *capturingBeacon) GetBeaconBlock(slot phase0.Slot, graffiti, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	obj, version, err := b.BeaconNode.GetBeaconBlock(slot, graffiti, randao)
+	b.recordObject(MethodBeaconBlock, slotRequest(slot), obj, version, err)
+	return obj, version, err
+}
+
+func (b *capturingBeacon) GetBlindedBeaconBlock(slot phase0.Slot, graffiti, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	obj, version, err := b.BeaconNode.GetBlindedBeaconBlock(slot, graffiti, randao)
+	b.recordObject(MethodBlindedBeaconBlock, slotRequest(slot), obj, version, err)
+	return obj, version, err
+}
+
+func (b *capturingBeacon) SubmitAggregateSelectionProof(slot phase0.Slot, committeeIndex phase0.CommitteeIndex, committeeLength uint64, index phase0.ValidatorIndex, slotSig []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	obj, version, err := b.BeaconNode.SubmitAggregateSelectionProof(slot, committeeIndex, committeeLength, index, slotSig)
+	b.recordObject(MethodAggregateSelectionProof, attestationRequest(slot, committeeIndex), obj, version, err)
+	return obj, version, err
+}
+
+func (b *capturingBeacon) GetSyncMessageBlockRoot(slot phase0.Slot) (phase0.Root, spec.DataVersion, error) {
+	root, version, err := b.BeaconNode.GetSyncMessageBlockRoot(slot)
+	b.record(MethodSyncMessageBlockRoot, slotRequest(slot), root[:], version, err)
+	return root, version, err
+}
+
+func (b *capturingBeacon) IsSyncCommitteeAggregator(proof []byte) (bool, error) {
+	isAggregator, err := b.BeaconNode.IsSyncCommitteeAggregator(proof)
+	data := []byte{0}
+	if isAggregator {
+		data[0] = 1
+	}
+	b.record(MethodIsSyncCommitteeAggregator, hex.EncodeToString(proof), data, 0, err)
+	return isAggregator, err
+}
+
+func (b *capturingBeacon) SyncCommitteeSubnetID(index phase0.CommitteeIndex) (uint64, error) {
+	subnet, err := b.BeaconNode.SyncCommitteeSubnetID(index)
+	b.record(MethodSyncCommitteeSubnetID, fmt.Sprint(index), binary.AppendUvarint(nil, subnet), 0, err)
+	return subnet, err
+}
+
+func (b *capturingBeacon) GetSyncCommitteeContribution(slot phase0.Slot, selectionProofs []phase0.BLSSignature, subnetIDs []uint64) (ssz.Marshaler, spec.DataVersion, error) {
+	obj, version, err := b.BeaconNode.GetSyncCommitteeContribution(slot, selectionProofs, subnetIDs)
+	b.recordObject(MethodSyncCommitteeContribution, fmt.Sprintf("%d/%v", slot, subnetIDs), obj, version, err)
+	return obj, version, err
+}
+
+func (b *capturingBeacon) DomainData(epoch phase0.Epoch, domain phase0.DomainType) (phase0.Domain, error) {
+	data, err := b.BeaconNode.DomainData(epoch, domain)
+	b.record(MethodDomainData, domainRequest(epoch, domain), data[:], 0, err)
+	return data, err
+}
+
+func (b *capturingBeacon) SubmitAttestation(attestation *phase0.Attestation) error {
+	err := b.BeaconNode.SubmitAttestation(attestation)
+	b.record(MethodSubmitAttestation, slotRequest(attestation.Data.Slot), nil, 0, err)
+	return err
+}
+
+func (b *capturingBeacon) SubmitBeaconBlock(block *spec.VersionedBeaconBlock, sig phase0.BLSSignature) error {
+	err := b.BeaconNode.SubmitBeaconBlock(block, sig)
+	slot, _ := block.Slot()
+	b.record(MethodSubmitBeaconBlock, slotRequest(slot), nil, 0, err)
+	return err
+}
+
+func (b *capturingBeacon) SubmitBlindedBeaconBlock(block *api.VersionedBlindedBeaconBlock, sig phase0.BLSSignature) error {
+	err := b.BeaconNode.SubmitBlindedBeaconBlock(block, sig)
+	slot, _ := block.Slot()
+	b.record(MethodSubmitBlindedBeaconBlock, slotRequest(slot), nil, 0, err)
+	return err
+}
+
+func (b *capturingBeacon) SubmitSignedAggregateSelectionProof(msg *phase0.SignedAggregateAndProof) error {
+	err := b.BeaconNode.SubmitSignedAggregateSelectionProof(msg)
+	b.record(MethodSubmitAggregateAndProof, slotRequest(msg.Message.Aggregate.Data.Slot), nil, 0, err)
+	return err
+}
+
+func (b *capturingBeacon) SubmitSyncMessage(msg *altair.SyncCommitteeMessage) error {
+	err := b.BeaconNode.SubmitSyncMessage(msg)
+	b.record(MethodSubmitSyncMessage, slotRequest(msg.Slot), nil, 0, err)
+	return err
+}
+
+func (b *capturingBeacon) SubmitSignedContributionAndProof(contribution *altair.SignedContributionAndProof) error {
+	err := b.BeaconNode.SubmitSignedContributionAndProof(contribution)
+	b.record(MethodSubmitContributionAndProof, slotRequest(contribution.Message.Contribution.Slot), nil, 0, err)
+	return err
+}
+
+func (b *capturingBeacon) SubmitValidatorRegistration(pubkey []byte, feeRecipient bellatrix.ExecutionAddress, sig phase0.BLSSignature) error {
+	err := b.BeaconNode.SubmitValidatorRegistration(pubkey, feeRecipient, sig)
+	b.record(MethodSubmitValidatorRegistration, "", nil, 0, err)
+	return err
+}
+
+// ReplayBeacon answers the runners of a replayed validator with the captured responses of the beacon node.
+// Responses are matched to calls by method and request in the captured order, and the last response
+// to a request is repeated once they run out. Submissions are logged instead of being sent.
+type ReplayBeacon struct {
+	logger    *zap.Logger
+	network   spectypes.BeaconNetwork
+	responses map[string][]*BeaconResponse
+	// Submissions counts the objects the replayed validator submitted by method
+	Submissions map[string]int
+}
+
+// NewReplayBeacon creates a beacon node answering with the captured responses of the given records
+func NewReplayBeacon(logger *zap.Logger, network spectypes.BeaconNetwork, records []*Record) *ReplayBeacon {
+	b := &ReplayBeacon{
+		logger:      logger,
+		network:     network,
+		responses:   map[string][]*BeaconResponse{},
+		Submissions: map[string]int{},
+	}
+	for _, record := range records {
+		if record.Kind != KindBeacon {
+			continue
+		}
+		key := responseKey(record.Beacon.Method, record.Beacon.Request)
+		b.responses[key] = append(b.responses[key], record.Beacon)
+	}
+	return b
+}
+
+// next returns the captured response to the given call, along with its captured error
+func (b *ReplayBeacon) next(method, request string) (*BeaconResponse, error) {
+	key := responseKey(method, request)
+	responses := b.responses[key]
+	if len(responses) == 0 {
+		return nil, errors.Errorf("no captured response to %s(%s)", method, request)
+	}
+	response := responses[0]
+	if len(responses) > 1 {
+		b.responses[key] = responses[1:]
+	}
+	if response.Error != "" {
+		return response, errors.New(response.Error)
+	}
+	return response, nil
+}
+
+func (b *ReplayBeacon) nextObject(method, request string) (ssz.Marshaler, spec.DataVersion, error) {
+	response, err := b.next(method, request)
+	if err != nil {
+		return nil, 0, err
+	}
+	obj, err := decodeObject(method, response.Version, response.Data)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "could not decode captured response to %s(%s)", method, request)
+	}
+	return obj, response.Version, nil
+}
+
+// submit logs the submission and returns the captured error of it, if any
+func (b *ReplayBeacon) submit(method, request string, obj ssz.HashRoot) error {
+	b.Submissions[method]++
+	logFields := []zap.Field{zap.String("method", method), zap.String("request", request)}
+	if obj != nil {
+		if root, err := obj.HashTreeRoot(); err == nil {
+			logFields = append(logFields, zap.String("root", hex.EncodeToString(root[:])))
+		}
+	}
+
+	if len(b.responses[responseKey(method, request)]) == 0 {
+		// the original run didn't get to submit this
+		b.logger.Info("🔁 replay: submitted, no captured response", logFields...)
+		return nil
+	}
+	_, err := b.next(method, request)
+	b.logger.Info("🔁 replay: submitted", append(logFields, zap.NamedError("captured_error", err))...)
+	return err
+}
+
+func (b *ReplayBeacon) GetBeaconNetwork() spectypes.BeaconNetwork {
+	return b.network
+}
+
+func (b *ReplayBeacon) GetAttestationData(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (ssz.Marshaler, spec.DataVersion, error) {
+	return b.nextObject(MethodAttestationData, attestationRequest(slot, committeeIndex))
+}
+
+func (b *ReplayBeacon) GetBeaconBlock(slot phase0.Slot, graffiti, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	return b.nextObject(MethodBeaconBlock, slotRequest(slot))
+}
+
+func (b *ReplayBeacon) GetBlindedBeaconBlock(slot phase0.Slot, graffiti, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	return b.nextObject(MethodBlindedBeaconBlock, slotRequest(slot))
+}
+
+func (b *ReplayBeacon) SubmitAggregateSelectionProof(slot phase0.Slot, committeeIndex phase0.CommitteeIndex, committeeLength uint64, index phase0.ValidatorIndex, slotSig []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	return b.nextObject(MethodAggregateSelectionProof, attestationRequest(slot, committeeIndex))
+}
+
+func (b *ReplayBeacon) GetSyncMessageBlockRoot(slot phase0.Slot) (phase0.Root, spec.DataVersion, error) {
+	response, err := b.next(MethodSyncMessageBlockRoot, slotRequest(slot))
+	if err != nil {
+		return phase0.Root{}, 0, err
+	}
+	var root phase0.Root
+	copy(root[:], response.Data)
+	return root, response.Version, nil
+}
+
+func (b *ReplayBeacon) IsSyncCommitteeAggregator(proof []byte) (bool, error) {
+	response, err := b.next(MethodIsSyncCommitteeAggregator, hex.EncodeToString(proof))
+	if err != nil {
+		return false, err
+	}
+	return len(response.Data) == 1 && response.Data[0] == 1, nil
+}
+
+func (b *ReplayBeacon) SyncCommitteeSubnetID(index phase0.CommitteeIndex) (uint64, error) {
+	response, err := b.next(MethodSyncCommitteeSubnetID, fmt.Sprint(index))
+	if err != nil {
+		return 0, err
+	}
+	subnet, n := binary.Uvarint(response.Data)
+	if n <= 0 {
+		return 0, errors.New("invalid captured subnet id")
+	}
+	return subnet, nil
+}
+
+func (b *ReplayBeacon) GetSyncCommitteeContribution(slot phase0.Slot, selectionProofs []phase0.BLSSignature, subnetIDs []uint64) (ssz.Marshaler, spec.DataVersion, error) {
+	return b.nextObject(MethodSyncCommitteeContribution, fmt.Sprintf("%d/%v", slot, subnetIDs))
+}
+
+func (b *ReplayBeacon) DomainData(epoch phase0.Epoch, domain phase0.DomainType) (phase0.Domain, error) {
+	response, err := b.next(MethodDomainData, domainRequest(epoch, domain))
+	if err != nil {
+		return phase0.Domain{}, err
+	}
+	var data phase0.Domain
+	copy(data[:], response.Data)
+	return data, nil
+}
+
+func (b *ReplayBeacon) SubmitAttestation(attestation *phase0.Attestation) error {
+	return b.submit(MethodSubmitAttestation, slotRequest(attestation.Data.Slot), attestation)
+}
+
+func (b *ReplayBeacon) SubmitBeaconBlock(block *spec.VersionedBeaconBlock, sig phase0.BLSSignature) error {
+	slot, _ := block.Slot()
+	return b.submit(MethodSubmitBeaconBlock, slotRequest(slot), nil)
+}
+
+func (b *ReplayBeacon) SubmitBlindedBeaconBlock(block *api.VersionedBlindedBeaconBlock, sig phase0.BLSSignature) error {
+	slot, _ := block.Slot()
+	return b.submit(MethodSubmitBlindedBeaconBlock, slotRequest(slot), nil)
+}
+
+func (b *ReplayBeacon) SubmitSignedAggregateSelectionProof(msg *phase0.SignedAggregateAndProof) error {
+	return b.submit(MethodSubmitAggregateAndProof, slotRequest(msg.Message.Aggregate.Data.Slot), msg.Message)
+}
+
+func (b *ReplayBeacon) SubmitSyncMessage(msg *altair.SyncCommitteeMessage) error {
+	return b.submit(MethodSubmitSyncMessage, slotRequest(msg.Slot), msg)
+}
+
+func (b *ReplayBeacon) SubmitSignedContributionAndProof(contribution *altair.SignedContributionAndProof) error {
+	return b.submit(MethodSubmitContributionAndProof, slotRequest(contribution.Message.Contribution.Slot), contribution.Message)
+}
+
+func (b *ReplayBeacon) SubmitValidatorRegistration(pubkey []byte, feeRecipient bellatrix.ExecutionAddress, sig phase0.BLSSignature) error {
+	return b.submit(MethodSubmitValidatorRegistration, "", nil)
+}
+
+// sszObject is a response object of the beacon node
+type sszObject interface {
+	ssz.Marshaler
+	ssz.Unmarshaler
+}
+
+// decodeObject decodes a captured response object by the method and version it was returned with
+func decodeObject(method string, version spec.DataVersion, data []byte) (ssz.Marshaler, error) {
+	var obj sszObject
+	switch method {
+	case MethodAttestationData:
+		obj = &phase0.AttestationData{}
+	case MethodAggregateSelectionProof:
+		obj = &phase0.AggregateAndProof{}
+	case MethodSyncCommitteeContribution:
+		obj = &spectypes.Contributions{}
+	case MethodBeaconBlock:
+		switch version {
+		case spec.DataVersionPhase0:
+			obj = &phase0.BeaconBlock{}
+		case spec.DataVersionAltair:
+			obj = &altair.BeaconBlock{}
+		case spec.DataVersionBellatrix:
+			obj = &bellatrix.BeaconBlock{}
+		case spec.DataVersionCapella:
+			obj = &capella.BeaconBlock{}
+		}
+	case MethodBlindedBeaconBlock:
+		switch version {
+		case spec.DataVersionBellatrix:
+			obj = &apiv1bellatrix.BlindedBeaconBlock{}
+		case spec.DataVersionCapella:
+			obj = &apiv1capella.BlindedBeaconBlock{}
+		}
+	}
+	if obj == nil {
+		return nil, errors.Errorf("unsupported response of %s of version %s", method, version)
+	}
+	if err := obj.UnmarshalSSZ(data); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func responseKey(method, request string) string {
+	return method + "/" + request
+}
+
+func slotRequest(slot phase0.Slot) string {
+	return fmt.Sprint(slot)
+}
+
+func attestationRequest(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) string {
+	return fmt.Sprintf("%d/%d", slot, committeeIndex)
+}
+
+func domainRequest(epoch phase0.Epoch, domain phase0.DomainType) string {
+	return fmt.Sprintf("%d/%x", epoch, domain)
+}
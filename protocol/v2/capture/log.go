@@ -0,0 +1,210 @@
+package capture
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+// Kind is the type of a captured record
+type Kind byte
+
+const (
+	// KindShare is the share of a validator, captured when the validator is created
+	KindShare Kind = iota + 1
+	// KindMessage is a message handled by a validator, either from the network or an internal event such as a timeout
+	KindMessage
+	// KindDroppedMessage is a message from the network which was dropped before reaching the validator
+	KindDroppedMessage
+	// KindDuty is a duty started by a validator
+	KindDuty
+	// KindBeacon is a response of the beacon node to a validator's runner
+	KindBeacon
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindShare:
+		return "share"
+	case KindMessage:
+		return "message"
+	case KindDroppedMessage:
+		return "dropped_message"
+	case KindDuty:
+		return "duty"
+	case KindBeacon:
+		return "beacon"
+	default:
+		return "unknown"
+	}
+}
+
+// BeaconResponse is the result of a call of a runner to the beacon node
+type BeaconResponse struct {
+	PubKey []byte `json:"pubkey"`
+	Method string `json:"method"`
+	// Request identifies the arguments of the call, so that responses are matched to calls on replay
+	Request string           `json:"request,omitempty"`
+	Version spec.DataVersion `json:"version,omitempty"`
+	// Data is the SSZ encoded object, or the raw value of calls which don't return an object
+	Data  []byte `json:"data,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Record is a single entry of a capture log, only the field of its kind is set
+type Record struct {
+	Time    time.Time
+	Kind    Kind
+	Share   *types.SSVShare
+	Message *spectypes.SSVMessage
+	Duty    *spectypes.Duty
+	Beacon  *BeaconResponse
+}
+
+// PubKey returns the public key of the validator the record belongs to
+func (r *Record) PubKey() []byte {
+	switch r.Kind {
+	case KindShare:
+		return r.Share.ValidatorPubKey
+	case KindMessage, KindDroppedMessage:
+		return r.Message.MsgID.GetPubKey()
+	case KindDuty:
+		return r.Duty.PubKey[:]
+	case KindBeacon:
+		return r.Beacon.PubKey
+	default:
+		return nil
+	}
+}
+
+// encode writes the record as its kind, its unix time in nanoseconds, the length of its payload and the payload.
+// Messages are SSZ encoded, the other kinds use their own encoding.
+func (r *Record) encode(w io.Writer) error {
+	var payload []byte
+	var err error
+	switch r.Kind {
+	case KindShare:
+		payload, err = r.Share.Encode()
+	case KindMessage, KindDroppedMessage:
+		payload, err = r.Message.MarshalSSZ()
+	case KindDuty:
+		payload, err = json.Marshal(r.Duty)
+	case KindBeacon:
+		payload, err = json.Marshal(r.Beacon)
+	default:
+		return errors.Errorf("unknown record kind %d", r.Kind)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "could not encode %s record", r.Kind)
+	}
+
+	header := make([]byte, 1+2*binary.MaxVarintLen64)
+	header[0] = byte(r.Kind)
+	n := 1 + binary.PutVarint(header[1:], r.Time.UnixNano())
+	n += binary.PutUvarint(header[n:], uint64(len(payload)))
+	if _, err := w.Write(header[:n]); err != nil {
+		return err
+	}
+	_, err = w.Write(payload)
+	return err
+}
+
+// Reader reads the records of a capture log
+type Reader struct {
+	r *bufio.Reader
+}
+
+// NewReader creates a reader of the given gzip compressed capture log
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open capture log")
+	}
+	return &Reader{r: bufio.NewReader(gz)}, nil
+}
+
+// Next returns the next record, or io.EOF at the end of the log.
+// A log cut short by a crash of the node ends with io.ErrUnexpectedEOF.
+func (r *Reader) Next() (*Record, error) {
+	kind, err := r.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	t, err := binary.ReadVarint(r.r)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	size, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r.r, payload); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	record := &Record{Time: time.Unix(0, t), Kind: Kind(kind)}
+	switch record.Kind {
+	case KindShare:
+		record.Share = &types.SSVShare{}
+		err = record.Share.Decode(payload)
+	case KindMessage, KindDroppedMessage:
+		record.Message = &spectypes.SSVMessage{}
+		err = record.Message.UnmarshalSSZ(payload)
+	case KindDuty:
+		record.Duty = &spectypes.Duty{}
+		err = json.Unmarshal(payload, record.Duty)
+	case KindBeacon:
+		record.Beacon = &BeaconResponse{}
+		err = json.Unmarshal(payload, record.Beacon)
+	default:
+		return nil, errors.Errorf("unknown record kind %d", kind)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode %s record", record.Kind)
+	}
+	return record, nil
+}
+
+// ReadFile reads all the records of the capture log at the given path.
+// The records of a log cut short by a crash are returned along with io.ErrUnexpectedEOF.
+func ReadFile(path string) ([]*Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader, err := NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	var records []*Record
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,196 @@
+package capture
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/hex"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+// Config holds the configuration of the capture recorder
+type Config struct {
+	Path          string        `yaml:"Path" env:"CAPTURE_PATH" env-description:"File to capture the messages, duties and beacon responses of validators to, which can be replayed with the replay command. Disabled when empty"`
+	Validators    []string      `yaml:"Validators" env:"CAPTURE_VALIDATORS" env-description:"Public keys of the validators to capture, all the validators of the operator when empty"`
+	FlushInterval time.Duration `yaml:"FlushInterval" env:"CAPTURE_FLUSH_INTERVAL" env-default:"1s" env-description:"Interval for flushing the capture to disk"`
+}
+
+// Options holds the needed dependencies of the recorder
+type Options struct {
+	Ctx    context.Context
+	Config Config
+}
+
+// Recorder writes the messages handled by validators, the duties they start and the responses of the beacon node
+// to a gzip compressed log, so that incidents can be replayed deterministically.
+// A nil recorder is valid and records nothing.
+type Recorder struct {
+	ctx    context.Context
+	config Config
+	// validators holds the captured validators, all the validators are captured when it's empty
+	validators map[phase0.BLSPubKey]struct{}
+
+	mu   sync.Mutex
+	file *os.File
+	gz   *gzip.Writer
+	// err is the first write error, which is reported on the next flush
+	err error
+}
+
+// New creates a recorder, appending to the log at the configured path
+func New(opts Options) (*Recorder, error) {
+	validators := map[phase0.BLSPubKey]struct{}{}
+	for _, v := range opts.Config.Validators {
+		raw, err := hex.DecodeString(strings.TrimPrefix(v, "0x"))
+		if err != nil || len(raw) != phase0.PublicKeyLength {
+			return nil, errors.Errorf("invalid validator public key %q", v)
+		}
+		var pk phase0.BLSPubKey
+		copy(pk[:], raw)
+		validators[pk] = struct{}{}
+	}
+
+	// every run appends a gzip member of its own, which are read as a single stream
+	file, err := os.OpenFile(opts.Config.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open capture file")
+	}
+	return &Recorder{
+		ctx:        opts.Ctx,
+		config:     opts.Config,
+		validators: validators,
+		file:       file,
+		gz:         gzip.NewWriter(file),
+	}, nil
+}
+
+// Start periodically flushes the log to disk, blocks until the context is done and closes the log
+func (r *Recorder) Start(logger *zap.Logger) {
+	logger = logger.Named("CaptureRecorder")
+	logger.Info("capturing validators", zap.String("path", r.config.Path), zap.Strings("validators", r.config.Validators))
+
+	ticker := time.NewTicker(r.config.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.ctx.Done():
+			if err := r.close(); err != nil {
+				logger.Warn("could not close capture", zap.Error(err))
+			}
+			return
+		case <-ticker.C:
+			if err := r.flush(); err != nil {
+				logger.Warn("could not flush capture", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Captures returns true if the validator of the given public key is captured
+func (r *Recorder) Captures(pubKey []byte) bool {
+	if r == nil {
+		return false
+	}
+	if len(r.validators) == 0 {
+		return true
+	}
+	var pk phase0.BLSPubKey
+	copy(pk[:], pubKey)
+	_, ok := r.validators[pk]
+	return ok
+}
+
+// Share records the share of a validator, which replays build the validator from
+func (r *Recorder) Share(share *types.SSVShare) {
+	if !r.Captures(share.ValidatorPubKey) {
+		return
+	}
+	r.write(&Record{Kind: KindShare, Share: share})
+}
+
+// Message records a message handled by a validator
+func (r *Recorder) Message(msg *spectypes.SSVMessage) {
+	if !r.Captures(msg.MsgID.GetPubKey()) {
+		return
+	}
+	r.write(&Record{Kind: KindMessage, Message: msg})
+}
+
+// DroppedMessage records a message from the network which was dropped before reaching its validator
+func (r *Recorder) DroppedMessage(msg *spectypes.SSVMessage) {
+	if !r.Captures(msg.MsgID.GetPubKey()) {
+		return
+	}
+	r.write(&Record{Kind: KindDroppedMessage, Message: msg})
+}
+
+// Duty records a duty started by a validator
+func (r *Recorder) Duty(duty *spectypes.Duty) {
+	if !r.Captures(duty.PubKey[:]) {
+		return
+	}
+	r.write(&Record{Kind: KindDuty, Duty: duty})
+}
+
+// BeaconResponse records a response of the beacon node to a validator's runner
+func (r *Recorder) BeaconResponse(response *BeaconResponse) {
+	if !r.Captures(response.PubKey) {
+		return
+	}
+	r.write(&Record{Kind: KindBeacon, Beacon: response})
+}
+
+func (r *Recorder) write(record *Record) {
+	record.Time = time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.gz == nil || r.err != nil {
+		return
+	}
+	r.err = record.encode(r.gz)
+}
+
+// flush writes the buffered records to disk and reports the first write error since the last flush
+func (r *Recorder) flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.gz == nil {
+		return nil
+	}
+	if err := r.err; err != nil {
+		// a record may have been partially written, so the log can't be continued
+		r.gz = nil
+		return errors.Wrap(err, "could not write record, capture is stopped")
+	}
+	return r.gz.Flush()
+}
+
+func (r *Recorder) close() error {
+	flushErr := r.flush()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.gz != nil {
+		if err := r.gz.Close(); err != nil {
+			return err
+		}
+		r.gz = nil
+	}
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	return flushErr
+}
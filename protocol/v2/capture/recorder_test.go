@@ -0,0 +1,133 @@
+package capture
+
+import (
+	"context"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specssv "github.com/bloxapp/ssv-spec/ssv"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+// testBeacon returns attestation data with an incrementing root and rejects attestations
+type testBeacon struct {
+	specssv.BeaconNode
+	calls int
+}
+
+func (b *testBeacon) GetAttestationData(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (ssz.Marshaler, spec.DataVersion, error) {
+	b.calls++
+	return &phase0.AttestationData{
+		Slot:            slot,
+		Index:           committeeIndex,
+		BeaconBlockRoot: phase0.Root{byte(b.calls)},
+		Source:          &phase0.Checkpoint{},
+		Target:          &phase0.Checkpoint{},
+	}, spec.DataVersionPhase0, nil
+}
+
+func (b *testBeacon) SubmitAttestation(attestation *phase0.Attestation) error {
+	return errors.New("rejected")
+}
+
+func TestRecorder(t *testing.T) {
+	logger := logging.TestLogger(t)
+	path := filepath.Join(t.TempDir(), "capture.gz")
+
+	captured := make([]byte, phase0.PublicKeyLength)
+	captured[0] = 1
+	ignored := make([]byte, phase0.PublicKeyLength)
+	ignored[0] = 2
+
+	recorder, err := New(Options{
+		Ctx:    context.Background(),
+		Config: Config{Path: path, Validators: []string{hex.EncodeToString(captured)}, FlushInterval: time.Minute},
+	})
+	require.NoError(t, err)
+	require.True(t, recorder.Captures(captured))
+	require.False(t, recorder.Captures(ignored))
+
+	newShare := func(pk []byte) *types.SSVShare {
+		share := &types.SSVShare{}
+		share.ValidatorPubKey = pk
+		share.OperatorID = 1
+		return share
+	}
+	newMessage := func(pk []byte) *spectypes.SSVMessage {
+		return &spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   spectypes.NewMsgID(types.GetDefaultDomain(), pk, spectypes.BNRoleAttester),
+			Data:    []byte{1, 2, 3},
+		}
+	}
+	duty := &spectypes.Duty{Type: spectypes.BNRoleAttester, PubKey: phase0.BLSPubKey{1}, Slot: 5, CommitteeIndex: 1}
+
+	recorder.Share(newShare(ignored))
+	recorder.Share(newShare(captured))
+	recorder.Message(newMessage(ignored))
+	recorder.Message(newMessage(captured))
+	recorder.DroppedMessage(newMessage(captured))
+	recorder.Duty(duty)
+
+	bn := NewBeacon(recorder, captured, &testBeacon{})
+	for i := 0; i < 2; i++ {
+		_, _, err := bn.GetAttestationData(5, 1)
+		require.NoError(t, err)
+	}
+	attestation := &phase0.Attestation{
+		AggregationBits: []byte{1},
+		Data:            &phase0.AttestationData{Slot: 5, Source: &phase0.Checkpoint{}, Target: &phase0.Checkpoint{}},
+	}
+	require.EqualError(t, bn.SubmitAttestation(attestation), "rejected")
+	NewBeacon(recorder, ignored, &testBeacon{}).GetAttestationData(5, 1)
+
+	require.NoError(t, recorder.close())
+	// a nil recorder records nothing
+	var nilRecorder *Recorder
+	require.False(t, nilRecorder.Captures(captured))
+	nilRecorder.Message(newMessage(captured))
+
+	records, err := ReadFile(path)
+	require.NoError(t, err)
+	var kinds []Kind
+	for _, record := range records {
+		require.Equal(t, captured, record.PubKey())
+		kinds = append(kinds, record.Kind)
+	}
+	require.Equal(t, []Kind{KindShare, KindMessage, KindDroppedMessage, KindDuty, KindBeacon, KindBeacon, KindBeacon}, kinds)
+	require.Equal(t, uint64(1), uint64(records[0].Share.OperatorID))
+	require.Equal(t, newMessage(captured), records[1].Message)
+	require.Equal(t, duty, records[3].Duty)
+	require.Equal(t, MethodSubmitAttestation, records[6].Beacon.Method)
+	require.Equal(t, "rejected", records[6].Beacon.Error)
+
+	t.Run("replay beacon", func(t *testing.T) {
+		bn := NewReplayBeacon(logger, spectypes.PraterNetwork, records)
+
+		// responses are replayed in order, and the last one repeats
+		for _, root := range []byte{1, 2, 2} {
+			obj, version, err := bn.GetAttestationData(5, 1)
+			require.NoError(t, err)
+			require.Equal(t, spec.DataVersionPhase0, version)
+			require.Equal(t, phase0.Root{root}, obj.(*phase0.AttestationData).BeaconBlockRoot)
+		}
+		_, _, err := bn.GetAttestationData(6, 1)
+		require.Error(t, err)
+
+		require.EqualError(t, bn.SubmitAttestation(attestation), "rejected")
+		// submissions which weren't captured succeed
+		require.NoError(t, bn.SubmitSyncMessage(&altair.SyncCommitteeMessage{Slot: 5}))
+		require.Equal(t, map[string]int{MethodSubmitAttestation: 1, MethodSubmitSyncMessage: 1}, bn.Submissions)
+	})
+}
@@ -0,0 +1,261 @@
+package dutytrace
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"go.uber.org/zap"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// Config holds the configuration of the duty trace recorder
+type Config struct {
+	Enabled        bool          `yaml:"Enabled" env:"DUTY_TRACE_ENABLED" env-default:"false" env-description:"Record the lifecycle of duties and serve it through the API"`
+	RetentionSlots uint64        `yaml:"RetentionSlots" env:"DUTY_TRACE_RETENTION_SLOTS" env-default:"1800" env-description:"Amount of slots to keep duty traces for"`
+	MaxEvents      int           `yaml:"MaxEvents" env:"DUTY_TRACE_MAX_EVENTS" env-default:"256" env-description:"Maximum amount of events recorded per duty"`
+	FlushInterval  time.Duration `yaml:"FlushInterval" env:"DUTY_TRACE_FLUSH_INTERVAL" env-default:"12s" env-description:"Interval for persisting duty traces"`
+}
+
+// Options holds the needed dependencies of the recorder
+type Options struct {
+	Ctx     context.Context
+	DB      basedb.IDb
+	Network beaconprotocol.Network
+	Config  Config
+}
+
+// Stage is a step in the lifecycle of a duty
+type Stage string
+
+const (
+	// StageReceived is a message of the duty received from the network
+	StageReceived Stage = "received"
+	// StageDropped is a message of the duty dropped because the queue was full
+	StageDropped Stage = "dropped"
+	// StageStarted is the start of the duty by this node
+	StageStarted Stage = "started"
+	// StagePreConsensusQuorum is a quorum of pre-consensus partial signatures
+	StagePreConsensusQuorum Stage = "pre_consensus_quorum"
+	// StageConsensusStarted is the start of the QBFT instance
+	StageConsensusStarted Stage = "consensus_started"
+	// StageProposalAccepted is a valid proposal for the current round
+	StageProposalAccepted Stage = "proposal_accepted"
+	// StagePrepareQuorum is a quorum of prepare messages for the current round
+	StagePrepareQuorum Stage = "prepare_quorum"
+	// StageCommitQuorum is a quorum of commit messages for the current round
+	StageCommitQuorum Stage = "commit_quorum"
+	// StageRoundChange is a move of the QBFT instance to a new round
+	StageRoundChange Stage = "round_change"
+	// StageDecided is a decided value accepted by the runner
+	StageDecided Stage = "decided"
+	// StagePostConsensusQuorum is a quorum of post-consensus partial signatures
+	StagePostConsensusQuorum Stage = "post_consensus_quorum"
+	// StageSubmitted is a successful submission to the beacon node
+	StageSubmitted Stage = "submitted"
+	// StageSubmissionFailed is a failed submission to the beacon node
+	StageSubmissionFailed Stage = "submission_failed"
+)
+
+// Event is a single step in the lifecycle of a duty
+type Event struct {
+	Time  time.Time `json:"time"`
+	Stage Stage     `json:"stage"`
+	// Message is the type of the received message, e.g. prepare or post_consensus
+	Message string                 `json:"message,omitempty"`
+	Round   specqbft.Round         `json:"round,omitempty"`
+	Signers []spectypes.OperatorID `json:"signers,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+}
+
+// Trace holds the events of a validator's duty in a role and slot
+type Trace struct {
+	PubKey []byte               `json:"pubkey"`
+	Role   spectypes.BeaconRole `json:"role"`
+	Slot   phase0.Slot          `json:"slot"`
+	Events []Event              `json:"events"`
+	// Truncated is set when events were discarded because the trace reached the maximum size
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// traceKey identifies the trace of a duty
+type traceKey struct {
+	PubKey phase0.BLSPubKey
+	Role   spectypes.BeaconRole
+	Slot   phase0.Slot
+}
+
+func newTraceKey(msgID spectypes.MessageID, slot phase0.Slot) traceKey {
+	key := traceKey{Role: msgID.GetRoleType(), Slot: slot}
+	copy(key.PubKey[:], msgID.GetPubKey())
+	return key
+}
+
+// Recorder records the lifecycle of duties, persisting it periodically.
+// A nil recorder is valid and records nothing.
+type Recorder struct {
+	ctx     context.Context
+	storage *storage
+	network beaconprotocol.Network
+	config  Config
+
+	mu sync.Mutex
+	// traces holds the traces of recent slots, which are persisted on flush
+	traces map[traceKey]*Trace
+	dirty  map[traceKey]bool
+	// prunedUntil is the slot until which traces were removed from the DB, it's only accessed by flush
+	prunedUntil phase0.Slot
+}
+
+// New creates a new recorder
+func New(opts Options) *Recorder {
+	return &Recorder{
+		ctx:     opts.Ctx,
+		storage: &storage{db: opts.DB},
+		network: opts.Network,
+		config:  opts.Config,
+		traces:  map[traceKey]*Trace{},
+		dirty:   map[traceKey]bool{},
+	}
+}
+
+// Start periodically persists the traces and removes expired ones, blocks until the context is done
+func (r *Recorder) Start(logger *zap.Logger) {
+	logger = logger.Named("DutyTraceRecorder")
+
+	ticker := time.NewTicker(r.config.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.ctx.Done():
+			if err := r.flush(); err != nil {
+				logger.Warn("could not flush duty traces", zap.Error(err))
+			}
+			return
+		case <-ticker.C:
+			if err := r.flush(); err != nil {
+				logger.Warn("could not flush duty traces", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Record appends the event to the trace of the duty of the given message id and slot.
+// Events of slots too far from the current one are ignored, so that the recorder stays bounded.
+func (r *Recorder) Record(msgID spectypes.MessageID, slot phase0.Slot, event Event) {
+	if r == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	if !r.isRecent(slot) {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := newTraceKey(msgID, slot)
+	trace, ok := r.traces[key]
+	if !ok {
+		trace = &Trace{PubKey: key.PubKey[:], Role: key.Role, Slot: slot}
+		r.traces[key] = trace
+	}
+	if len(trace.Events) >= r.config.MaxEvents {
+		trace.Truncated = true
+		return
+	}
+	trace.Events = append(trace.Events, event)
+	r.dirty[key] = true
+}
+
+// isRecent returns true if the slot is within the window of slots for which messages are accepted
+func (r *Recorder) isRecent(slot phase0.Slot) bool {
+	window := phase0.Slot(r.network.SlotsPerEpoch())
+	currentSlot := r.network.EstimatedCurrentSlot()
+	return slot+2*window >= currentSlot && slot <= currentSlot+window
+}
+
+// Traces returns the traces of the given slot
+func (r *Recorder) Traces(logger *zap.Logger, slot phase0.Slot) ([]*Trace, error) {
+	traces, err := r.storage.loadSlot(logger, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// traces in memory are newer than the persisted ones
+	for key, trace := range r.traces {
+		if key.Slot != slot {
+			continue
+		}
+		cp := *trace
+		cp.Events = append([]Event(nil), trace.Events...)
+		traces[key] = &cp
+	}
+
+	res := make([]*Trace, 0, len(traces))
+	for _, trace := range traces {
+		res = append(res, trace)
+	}
+	return res, nil
+}
+
+// flush persists the traces that were changed since the last flush,
+// evicts old traces from memory and removes expired traces from the DB.
+func (r *Recorder) flush() error {
+	// the changed traces are copied under the lock and written without it,
+	// so that recording events isn't blocked by the DB
+	r.mu.Lock()
+	dirty := make(map[traceKey]*Trace, len(r.dirty))
+	for key := range r.dirty {
+		trace := r.traces[key]
+		cp := *trace
+		cp.Events = append([]Event(nil), trace.Events...)
+		dirty[key] = &cp
+	}
+	r.dirty = map[traceKey]bool{}
+	for key := range r.traces {
+		if !r.isRecent(key.Slot) {
+			delete(r.traces, key)
+		}
+	}
+	r.mu.Unlock()
+
+	if err := r.storage.saveTraces(dirty); err != nil {
+		// retry on the next flush, unless the traces were evicted meanwhile
+		r.mu.Lock()
+		for key := range dirty {
+			if _, ok := r.traces[key]; ok {
+				r.dirty[key] = true
+			}
+		}
+		r.mu.Unlock()
+		return err
+	}
+
+	currentSlot := r.network.EstimatedCurrentSlot()
+	if uint64(currentSlot) <= r.config.RetentionSlots {
+		return nil
+	}
+	cutoff := currentSlot - phase0.Slot(r.config.RetentionSlots)
+	if r.prunedUntil == 0 && uint64(cutoff) > r.config.RetentionSlots {
+		// after a restart, look back one retention period for traces that expired while the node was down
+		r.prunedUntil = cutoff - phase0.Slot(r.config.RetentionSlots)
+	}
+	for slot := r.prunedUntil; slot < cutoff; slot++ {
+		if err := r.storage.deleteSlot(slot); err != nil {
+			return err
+		}
+	}
+	r.prunedUntil = cutoff
+	return nil
+}
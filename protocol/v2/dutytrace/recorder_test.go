@@ -0,0 +1,142 @@
+package dutytrace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestRecorder(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.New(logger, basedb.Options{Type: "badger-memory", Path: ""})
+	require.NoError(t, err)
+	defer db.Close(logger)
+
+	network := networkconfig.TestNetwork.Beacon
+	currentSlot := network.EstimatedCurrentSlot()
+	newRecorder := func() *Recorder {
+		return New(Options{
+			Ctx:     context.Background(),
+			DB:      db,
+			Network: network,
+			Config:  Config{RetentionSlots: 100, MaxEvents: 3, FlushInterval: time.Minute},
+		})
+	}
+	recorder := newRecorder()
+
+	pk := make([]byte, 48)
+	attester := spectypes.NewMsgID(types.GetDefaultDomain(), pk, spectypes.BNRoleAttester)
+	proposer := spectypes.NewMsgID(types.GetDefaultDomain(), pk, spectypes.BNRoleProposer)
+	recorder.Record(attester, currentSlot, Event{Stage: StageStarted})
+	recorder.Record(attester, currentSlot, Event{Stage: StageReceived, Message: "proposal", Round: 1, Signers: []spectypes.OperatorID{2}})
+	recorder.Record(attester, currentSlot, Event{Stage: StageDecided, Round: 1, Signers: []spectypes.OperatorID{1, 2, 3}})
+	// exceeds the maximum amount of events
+	recorder.Record(attester, currentSlot, Event{Stage: StageSubmitted})
+	recorder.Record(proposer, currentSlot, Event{Stage: StageSubmissionFailed, Error: "failed"})
+	// too old to be recorded
+	recorder.Record(attester, currentSlot-1000, Event{Stage: StageStarted})
+
+	requireTraces := func(recorder *Recorder) {
+		traces, err := recorder.Traces(logger, currentSlot)
+		require.NoError(t, err)
+		require.Len(t, traces, 2)
+		byRole := map[spectypes.BeaconRole]*Trace{}
+		for _, trace := range traces {
+			require.Equal(t, pk, trace.PubKey)
+			require.Equal(t, currentSlot, trace.Slot)
+			byRole[trace.Role] = trace
+		}
+
+		attesterTrace := byRole[spectypes.BNRoleAttester]
+		require.True(t, attesterTrace.Truncated)
+		require.Len(t, attesterTrace.Events, 3)
+		require.Equal(t, StageStarted, attesterTrace.Events[0].Stage)
+		require.Equal(t, []spectypes.OperatorID{2}, attesterTrace.Events[1].Signers)
+		require.Equal(t, StageDecided, attesterTrace.Events[2].Stage)
+		require.False(t, attesterTrace.Events[2].Time.IsZero())
+
+		proposerTrace := byRole[spectypes.BNRoleProposer]
+		require.Len(t, proposerTrace.Events, 1)
+		require.Equal(t, "failed", proposerTrace.Events[0].Error)
+	}
+	requireTraces(recorder)
+
+	traces, err := recorder.Traces(logger, currentSlot-1000)
+	require.NoError(t, err)
+	require.Empty(t, traces)
+
+	// traces survive a restart once flushed
+	require.NoError(t, recorder.flush())
+	requireTraces(newRecorder())
+
+	// traces that expired while the node was down are pruned after a restart
+	require.NoError(t, recorder.storage.saveTraces(map[traceKey]*Trace{{Slot: currentSlot - 150}: {Slot: currentSlot - 150}}))
+	recorder = newRecorder()
+	require.NoError(t, recorder.flush())
+	traces, err = recorder.Traces(logger, currentSlot-150)
+	require.NoError(t, err)
+	require.Empty(t, traces)
+	traces, err = recorder.Traces(logger, currentSlot)
+	require.NoError(t, err)
+	require.Len(t, traces, 2)
+}
+
+func TestRecorder_FlushWhileRecording(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.New(logger, basedb.Options{Type: "badger-memory", Path: ""})
+	require.NoError(t, err)
+	defer db.Close(logger)
+
+	network := networkconfig.TestNetwork.Beacon
+	currentSlot := network.EstimatedCurrentSlot()
+	recorder := New(Options{
+		Ctx:     context.Background(),
+		DB:      db,
+		Network: network,
+		Config:  Config{RetentionSlots: 100, MaxEvents: 1000, FlushInterval: time.Minute},
+	})
+	msgID := spectypes.NewMsgID(types.GetDefaultDomain(), make([]byte, 48), spectypes.BNRoleAttester)
+
+	const events = 500
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < events; i++ {
+			recorder.Record(msgID, currentSlot, Event{Stage: StageReceived, Round: specqbft.Round(i)})
+		}
+	}()
+	for flushing := true; flushing; {
+		select {
+		case <-done:
+			flushing = false
+		default:
+		}
+		require.NoError(t, recorder.flush())
+	}
+
+	// every event is persisted once recording is done, even when it was recorded during a flush
+	traces, err := recorder.storage.loadSlot(logger, currentSlot)
+	require.NoError(t, err)
+	require.Len(t, traces, 1)
+	for _, trace := range traces {
+		require.Len(t, trace.Events, events)
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	var recorder *Recorder
+	require.NotPanics(t, func() {
+		recorder.Record(spectypes.MessageID{}, phase0.Slot(1), Event{Stage: StageStarted})
+	})
+}
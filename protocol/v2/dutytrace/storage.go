@@ -0,0 +1,85 @@
+package dutytrace
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+var storagePrefix = []byte("dutytrace/")
+
+// storage persists traces, keyed by slot and then by validator and role
+type storage struct {
+	db basedb.IDb
+}
+
+func slotPrefix(slot phase0.Slot) []byte {
+	b := make([]byte, len(storagePrefix)+8)
+	copy(b, storagePrefix)
+	binary.BigEndian.PutUint64(b[len(storagePrefix):], uint64(slot))
+	return b
+}
+
+func (k traceKey) bytes() []byte {
+	b := make([]byte, len(k.PubKey)+4)
+	copy(b, k.PubKey[:])
+	binary.BigEndian.PutUint32(b[len(k.PubKey):], uint32(k.Role))
+	return b
+}
+
+func traceKeyFromBytes(slot phase0.Slot, b []byte) (traceKey, error) {
+	key := traceKey{Slot: slot}
+	if len(b) != len(key.PubKey)+4 {
+		return traceKey{}, errors.Errorf("invalid key length %d", len(b))
+	}
+	copy(key.PubKey[:], b)
+	key.Role = spectypes.BeaconRole(binary.BigEndian.Uint32(b[len(key.PubKey):]))
+	return key, nil
+}
+
+// loadSlot returns the traces of the given slot
+func (s *storage) loadSlot(logger *zap.Logger, slot phase0.Slot) (map[traceKey]*Trace, error) {
+	res := map[traceKey]*Trace{}
+	err := s.db.GetAll(logger, slotPrefix(slot), func(i int, obj basedb.Obj) error {
+		key, err := traceKeyFromBytes(slot, obj.Key)
+		if err != nil {
+			return err
+		}
+		trace := &Trace{}
+		if err := json.Unmarshal(obj.Value, trace); err != nil {
+			return errors.Wrap(err, "could not unmarshal trace")
+		}
+		res[key] = trace
+		return nil
+	})
+	return res, err
+}
+
+// saveTraces saves the given traces in a single batch
+func (s *storage) saveTraces(traces map[traceKey]*Trace) error {
+	objs := make([]basedb.Obj, 0, len(traces))
+	for key, trace := range traces {
+		raw, err := json.Marshal(trace)
+		if err != nil {
+			return errors.Wrap(err, "could not marshal trace")
+		}
+		// traces of different slots are saved together, so their keys are relative to storagePrefix
+		slotKey := slotPrefix(key.Slot)[len(storagePrefix):]
+		objs = append(objs, basedb.Obj{Key: append(slotKey, key.bytes()...), Value: raw})
+	}
+	return s.db.SetMany(storagePrefix, len(objs), func(i int) (basedb.Obj, error) {
+		return objs[i], nil
+	})
+}
+
+// deleteSlot removes the traces of the given slot
+func (s *storage) deleteSlot(slot phase0.Slot) error {
+	_, err := s.db.DeleteByPrefix(slotPrefix(slot))
+	return err
+}
@@ -3,6 +3,7 @@ package message
 import (
 	"fmt"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 )
@@ -14,6 +15,33 @@ const (
 	SSVEventMsgType spectypes.MsgType = 200
 )
 
+const (
+	// BNRoleVoluntaryExit extends spec beacon roles
+	BNRoleVoluntaryExit spectypes.BeaconRole = spectypes.BNRoleValidatorRegistration + 1
+	// VoluntaryExitPartialSig extends spec partial signature types, a partial signature over a VoluntaryExit
+	VoluntaryExitPartialSig spectypes.PartialSigMsgType = spectypes.ValidatorRegistrationPartialSig + 1
+
+	// BNRoleBLSToExecutionChange extends spec beacon roles
+	BNRoleBLSToExecutionChange spectypes.BeaconRole = BNRoleVoluntaryExit + 1
+	// BLSToExecutionChangePartialSig extends spec partial signature types, a partial signature over a BLSToExecutionChange
+	BLSToExecutionChangePartialSig spectypes.PartialSigMsgType = VoluntaryExitPartialSig + 1
+)
+
+// DomainBLSToExecutionChange extends spec domain types, it's computed with the genesis fork version
+var DomainBLSToExecutionChange = phase0.DomainType{0x0a, 0x00, 0x00, 0x00}
+
+// RoleToString extension for spec beacon role. convert beacon role to string
+func RoleToString(r spectypes.BeaconRole) string {
+	switch r {
+	case BNRoleVoluntaryExit:
+		return "VOLUNTARY_EXIT"
+	case BNRoleBLSToExecutionChange:
+		return "BLS_TO_EXECUTION_CHANGE"
+	default:
+		return r.String()
+	}
+}
+
 // MsgTypeToString extension for spec msg type. convert spec msg type to string
 func MsgTypeToString(mt spectypes.MsgType) string {
 	switch mt {
@@ -47,6 +75,26 @@ func QBFTMsgTypeToString(mt specqbft.MessageType) string {
 	}
 }
 
+// PartialSigMsgTypeToString extension for spec partial signature msg type. convert partial signature msg type to string
+func PartialSigMsgTypeToString(mt spectypes.PartialSigMsgType) string {
+	switch mt {
+	case spectypes.PostConsensusPartialSig:
+		return "post_consensus"
+	case spectypes.RandaoPartialSig:
+		return "randao"
+	case spectypes.SelectionProofPartialSig:
+		return "selection_proof"
+	case spectypes.ContributionProofs:
+		return "contribution_proofs"
+	case spectypes.ValidatorRegistrationPartialSig:
+		return "validator_registration"
+	case VoluntaryExitPartialSig:
+		return "voluntary_exit"
+	default:
+		return fmt.Sprintf("unknown(%d)", mt)
+	}
+}
+
 // BeaconRoleFromString returns BeaconRole from string
 func BeaconRoleFromString(s string) (spectypes.BeaconRole, error) {
 	switch s {
@@ -62,6 +110,10 @@ func BeaconRoleFromString(s string) (spectypes.BeaconRole, error) {
 		return spectypes.BNRoleSyncCommitteeContribution, nil
 	case "VALIDATOR_REGISTRATION":
 		return spectypes.BNRoleValidatorRegistration, nil
+	case "VOLUNTARY_EXIT":
+		return BNRoleVoluntaryExit, nil
+	case "BLS_TO_EXECUTION_CHANGE":
+		return BNRoleBLSToExecutionChange, nil
 	default:
 		return 0, fmt.Errorf("unknown role: %s", s)
 	}
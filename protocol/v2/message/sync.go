@@ -1,8 +1,6 @@
 package message
 
 import (
-	"encoding/json"
-
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 )
@@ -80,16 +78,9 @@ type SyncMessage struct {
 	Data []*specqbft.SignedMessage
 	// Status is the status code of the operation
 	Status StatusCode
-}
-
-// Encode encodes the message
-func (sm *SyncMessage) Encode() ([]byte, error) {
-	return json.Marshal(sm)
-}
 
-// Decode decodes the message
-func (sm *SyncMessage) Decode(data []byte) error {
-	return json.Unmarshal(data, sm)
+	// encoding is the wire encoding of the message, see sync_encoding.go
+	encoding SyncEncoding
 }
 
 // UpdateResults updates the given sync message with results or potential error
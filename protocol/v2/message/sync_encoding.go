@@ -0,0 +1,226 @@
+package message
+
+import (
+	"encoding/json"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	ssz "github.com/ferranbt/fastssz"
+)
+
+// SyncEncoding is the wire encoding of sync messages
+type SyncEncoding byte
+
+const (
+	// SyncEncodingJSON encodes sync messages with JSON
+	SyncEncodingJSON SyncEncoding = iota
+	// SyncEncodingSSZ encodes sync messages with SSZ
+	SyncEncodingSSZ
+)
+
+const (
+	// MaxSyncResults is the maximum amount of decided messages in a sync message
+	MaxSyncResults = 1024
+	// maxSyncHeights is the maximum amount of heights in sync params, a single height or a range
+	maxSyncHeights = 2
+
+	// syncMessageFixedSize is the size of Protocol, Params offset, Data offset and Status
+	syncMessageFixedSize = 16
+	// syncParamsFixedSize is the size of Height offset and Identifier
+	syncParamsFixedSize = 60
+)
+
+// Encode encodes the message with the encoding it was decoded with or set to, JSON by default
+func (sm *SyncMessage) Encode() ([]byte, error) {
+	if sm.encoding == SyncEncodingSSZ {
+		return sm.MarshalSSZ()
+	}
+	return json.Marshal(sm)
+}
+
+// Decode decodes the message from either JSON or SSZ, and keeps the encoding for Encode.
+// SSZ is told apart by its first byte, which is part of the protocol number and never '{'.
+func (sm *SyncMessage) Decode(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		sm.encoding = SyncEncodingJSON
+		return json.Unmarshal(data, sm)
+	}
+	sm.encoding = SyncEncodingSSZ
+	return sm.UnmarshalSSZ(data)
+}
+
+// SetEncoding sets the encoding used by Encode
+func (sm *SyncMessage) SetEncoding(encoding SyncEncoding) {
+	sm.encoding = encoding
+}
+
+// MarshalSSZ ssz marshals the SyncMessage object
+func (sm *SyncMessage) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(sm)
+}
+
+// MarshalSSZTo ssz marshals the SyncMessage object to a target array, nil params are encoded as empty params
+func (sm *SyncMessage) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	params := sm.Params
+	if params == nil {
+		params = &SyncParams{}
+	}
+	if size := len(sm.Data); size > MaxSyncResults {
+		return nil, ssz.ErrListTooBigFn("SyncMessage.Data", size, MaxSyncResults)
+	}
+	for _, msg := range sm.Data {
+		if msg == nil {
+			return nil, ssz.ErrBytesLengthFn("SyncMessage.Data", 0, 1)
+		}
+	}
+
+	// Field (0) 'Protocol'
+	dst = ssz.MarshalUint32(dst, uint32(sm.Protocol))
+
+	// Offset (1) 'Params'
+	offset := syncMessageFixedSize
+	dst = ssz.WriteOffset(dst, offset)
+	offset += params.SizeSSZ()
+
+	// Offset (2) 'Data'
+	dst = ssz.WriteOffset(dst, offset)
+
+	// Field (3) 'Status'
+	dst = ssz.MarshalUint32(dst, uint32(sm.Status))
+
+	// Field (1) 'Params'
+	if dst, err = params.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (2) 'Data'
+	offset = 4 * len(sm.Data)
+	for _, msg := range sm.Data {
+		dst = ssz.WriteOffset(dst, offset)
+		offset += msg.SizeSSZ()
+	}
+	for _, msg := range sm.Data {
+		if dst, err = msg.MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the SyncMessage object, the amount of results is checked before they're decoded
+func (sm *SyncMessage) UnmarshalSSZ(buf []byte) error {
+	size := uint64(len(buf))
+	if size < syncMessageFixedSize {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'Protocol'
+	sm.Protocol = SyncMsgType(ssz.UnmarshallUint32(buf[0:4]))
+
+	// Offset (1) 'Params'
+	o1 := ssz.ReadOffset(buf[4:8])
+	if o1 != syncMessageFixedSize {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (2) 'Data'
+	o2 := ssz.ReadOffset(buf[8:12])
+	if o2 < o1 || o2 > size {
+		return ssz.ErrOffset
+	}
+
+	// Field (3) 'Status'
+	sm.Status = StatusCode(ssz.UnmarshallUint32(buf[12:16]))
+
+	// Field (1) 'Params'
+	sm.Params = &SyncParams{}
+	if err := sm.Params.UnmarshalSSZ(buf[o1:o2]); err != nil {
+		return err
+	}
+
+	// Field (2) 'Data'
+	data := buf[o2:]
+	num, err := ssz.DecodeDynamicLength(data, MaxSyncResults)
+	if err != nil {
+		return err
+	}
+	sm.Data = make([]*specqbft.SignedMessage, num)
+	return ssz.UnmarshalDynamic(data, num, func(i int, b []byte) error {
+		sm.Data[i] = &specqbft.SignedMessage{}
+		return sm.Data[i].UnmarshalSSZ(b)
+	})
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the SyncMessage object
+func (sm *SyncMessage) SizeSSZ() int {
+	size := syncMessageFixedSize
+	if sm.Params != nil {
+		size += sm.Params.SizeSSZ()
+	} else {
+		size += syncParamsFixedSize
+	}
+	for _, msg := range sm.Data {
+		size += 4
+		if msg != nil {
+			size += msg.SizeSSZ()
+		}
+	}
+	return size
+}
+
+// MarshalSSZ ssz marshals the SyncParams object
+func (sp *SyncParams) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(sp)
+}
+
+// MarshalSSZTo ssz marshals the SyncParams object to a target array
+func (sp *SyncParams) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	if size := len(sp.Height); size > maxSyncHeights {
+		return nil, ssz.ErrListTooBigFn("SyncParams.Height", size, maxSyncHeights)
+	}
+
+	// Offset (0) 'Height'
+	dst = ssz.WriteOffset(dst, syncParamsFixedSize)
+
+	// Field (1) 'Identifier'
+	dst = append(dst, sp.Identifier[:]...)
+
+	// Field (0) 'Height'
+	for _, height := range sp.Height {
+		dst = ssz.MarshalUint64(dst, uint64(height))
+	}
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the SyncParams object
+func (sp *SyncParams) UnmarshalSSZ(buf []byte) error {
+	if len(buf) < syncParamsFixedSize {
+		return ssz.ErrSize
+	}
+
+	// Offset (0) 'Height'
+	if o0 := ssz.ReadOffset(buf[0:4]); o0 != syncParamsFixedSize {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (1) 'Identifier'
+	copy(sp.Identifier[:], buf[4:syncParamsFixedSize])
+
+	// Field (0) 'Height'
+	heights, err := ssz.DivideInt2(len(buf)-syncParamsFixedSize, 8, maxSyncHeights)
+	if err != nil {
+		return ssz.ErrListTooBig
+	}
+	sp.Height = make([]specqbft.Height, heights)
+	for i := range sp.Height {
+		start := syncParamsFixedSize + 8*i
+		sp.Height[i] = specqbft.Height(ssz.UnmarshallUint64(buf[start : start+8]))
+	}
+	return nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the SyncParams object
+func (sp *SyncParams) SizeSSZ() int {
+	return syncParamsFixedSize + 8*len(sp.Height)
+}
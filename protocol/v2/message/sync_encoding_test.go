@@ -0,0 +1,116 @@
+package message_test
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/protocol/v2/message"
+	protocoltesting "github.com/bloxapp/ssv/protocol/v2/testing"
+)
+
+func TestSyncMessageEncoding(t *testing.T) {
+	operators := []spectypes.OperatorID{1, 2, 3, 4}
+	secretKeys, _ := protocoltesting.GenerateBLSKeys(operators...)
+	mid := spectypes.NewMsgID(spectypes.GenesisMainnet, []byte("pk"), spectypes.BNRoleAttester)
+
+	var decided []*specqbft.SignedMessage
+	for height := specqbft.Height(10); height < 13; height++ {
+		decided = append(decided, protocoltesting.AggregateSign(t, secretKeys, operators[:3], &specqbft.Message{
+			MsgType:    specqbft.CommitMsgType,
+			Height:     height,
+			Round:      1,
+			Identifier: mid[:],
+			Root:       [32]byte{1, 2, 3},
+		}))
+	}
+	sm := &message.SyncMessage{
+		Protocol: message.DecidedHistoryType,
+		Params: &message.SyncParams{
+			Height:     []specqbft.Height{10, 12},
+			Identifier: mid,
+		},
+		Data:   decided,
+		Status: message.StatusSuccess,
+	}
+
+	t.Run("ssz", func(t *testing.T) {
+		sm.SetEncoding(message.SyncEncodingSSZ)
+		data, err := sm.Encode()
+		require.NoError(t, err)
+		require.Len(t, data, sm.SizeSSZ())
+
+		decoded := &message.SyncMessage{}
+		require.NoError(t, decoded.Decode(data))
+		requireSyncMessage(t, sm, decoded)
+
+		// responses are encoded like the request
+		reencoded, err := decoded.Encode()
+		require.NoError(t, err)
+		require.Equal(t, data, reencoded)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		sm.SetEncoding(message.SyncEncodingJSON)
+		data, err := sm.Encode()
+		require.NoError(t, err)
+		require.True(t, json.Valid(data))
+
+		decoded := &message.SyncMessage{}
+		require.NoError(t, decoded.Decode(data))
+		requireSyncMessage(t, sm, decoded)
+
+		reencoded, err := decoded.Encode()
+		require.NoError(t, err)
+		require.True(t, json.Valid(reencoded))
+	})
+
+	t.Run("request without results", func(t *testing.T) {
+		req := &message.SyncMessage{Protocol: message.LastDecidedType, Params: &message.SyncParams{Identifier: mid}}
+		req.SetEncoding(message.SyncEncodingSSZ)
+		data, err := req.Encode()
+		require.NoError(t, err)
+
+		decoded := &message.SyncMessage{}
+		require.NoError(t, decoded.Decode(data))
+		require.Equal(t, mid, decoded.Params.Identifier)
+		require.Empty(t, decoded.Data)
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		tooManyHeights := &message.SyncMessage{Params: &message.SyncParams{Height: []specqbft.Height{1, 2, 3}}}
+		_, err := tooManyHeights.MarshalSSZ()
+		require.Error(t, err)
+
+		sm.SetEncoding(message.SyncEncodingSSZ)
+		data, err := sm.Encode()
+		require.NoError(t, err)
+
+		// a list of results longer than the maximum is rejected by its first offset
+		dataOffset := binary.LittleEndian.Uint32(data[8:12])
+		binary.LittleEndian.PutUint32(data[dataOffset:], 4*(message.MaxSyncResults+1))
+		require.Error(t, (&message.SyncMessage{}).Decode(data))
+
+		require.Error(t, (&message.SyncMessage{}).Decode([]byte{1, 2, 3}))
+	})
+}
+
+func requireSyncMessage(t *testing.T, expected, actual *message.SyncMessage) {
+	require.Equal(t, expected.Protocol, actual.Protocol)
+	require.Equal(t, expected.Status, actual.Status)
+	require.Equal(t, expected.Params.Height, actual.Params.Height)
+	require.Equal(t, expected.Params.Identifier, actual.Params.Identifier)
+	require.Len(t, actual.Data, len(expected.Data))
+	for i := range expected.Data {
+		expectedRoot, err := expected.Data[i].GetRoot()
+		require.NoError(t, err)
+		actualRoot, err := actual.Data[i].GetRoot()
+		require.NoError(t, err)
+		require.Equal(t, expectedRoot, actualRoot)
+		require.Equal(t, expected.Data[i].Signers, actual.Data[i].Signers)
+	}
+}
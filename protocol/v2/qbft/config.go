@@ -3,6 +3,8 @@ package qbft
 import (
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+
+	"github.com/bloxapp/ssv/protocol/v2/dutytrace"
 	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
 )
 
@@ -25,6 +27,8 @@ type IConfig interface {
 	GetStorage() qbftstorage.QBFTStore
 	// GetTimer returns round timer
 	GetTimer() specqbft.Timer
+	// GetDutyTracer returns the duty trace recorder, nil when tracing is disabled
+	GetDutyTracer() *dutytrace.Recorder
 }
 
 type Config struct {
@@ -36,6 +40,7 @@ type Config struct {
 	Storage     qbftstorage.QBFTStore
 	Network     specqbft.Network
 	Timer       specqbft.Timer
+	DutyTracer  *dutytrace.Recorder
 }
 
 // GetSigner returns a Signer instance
@@ -77,3 +82,8 @@ func (c *Config) GetStorage() qbftstorage.QBFTStore {
 func (c *Config) GetTimer() specqbft.Timer {
 	return c.Timer
 }
+
+// GetDutyTracer returns the duty trace recorder, nil when tracing is disabled
+func (c *Config) GetDutyTracer() *dutytrace.Recorder {
+	return c.DutyTracer
+}
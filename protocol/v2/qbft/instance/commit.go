@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/protocol/v2/dutytrace"
 	"github.com/bloxapp/ssv/protocol/v2/qbft"
 	"github.com/bloxapp/ssv/protocol/v2/types"
 )
@@ -50,6 +51,7 @@ func (i *Instance) UponCommit(logger *zap.Logger, signedCommit *specqbft.SignedM
 			fields.Root(signedCommit.Message.Root))
 
 		i.metrics.EndStageCommit()
+		i.trace(dutytrace.StageCommitQuorum, agg.Signers)
 
 		return true, fullData, agg, nil
 	}
@@ -6,11 +6,13 @@ import (
 
 	"github.com/bloxapp/ssv/logging/fields"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/protocol/v2/dutytrace"
 	"github.com/bloxapp/ssv/protocol/v2/qbft"
 )
 
@@ -65,6 +67,7 @@ func (i *Instance) Start(logger *zap.Logger, value []byte, height specqbft.Heigh
 		i.bumpToRound(specqbft.FirstRound)
 		i.State.Height = height
 		i.metrics.StartStage()
+		i.trace(dutytrace.StageConsensusStarted, nil)
 
 		i.config.GetTimer().TimeoutForRound(specqbft.FirstRound)
 
@@ -252,8 +255,21 @@ func (i *Instance) Decode(data []byte) error {
 
 // bumpToRound sets round and sends current round metrics.
 func (i *Instance) bumpToRound(round specqbft.Round) {
+	changed := round != i.State.Round
 	i.State.Round = round
 	i.metrics.SetRound(round)
+	if changed {
+		i.trace(dutytrace.StageRoundChange, nil)
+	}
+}
+
+// trace records a stage of the instance in the trace of its duty
+func (i *Instance) trace(stage dutytrace.Stage, signers []spectypes.OperatorID) {
+	i.config.GetDutyTracer().Record(spectypes.MessageIDFromBytes(i.State.ID), phase0.Slot(i.State.Height), dutytrace.Event{
+		Stage:   stage,
+		Round:   i.State.Round,
+		Signers: signers,
+	})
 }
 
 // CanProcessMessages will return true if instance can process messages
@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/protocol/v2/dutytrace"
 	"github.com/bloxapp/ssv/protocol/v2/qbft"
 	"github.com/bloxapp/ssv/protocol/v2/types"
 )
@@ -48,6 +49,7 @@ func (i *Instance) uponPrepare(
 	i.State.LastPreparedRound = i.State.Round
 
 	i.metrics.EndStagePrepare()
+	i.trace(dutytrace.StagePrepareQuorum, allSigners(prepareMsgContainer.MessagesForRound(i.State.Round)))
 
 	logger.Debug("🎯 got prepare quorum",
 		fields.Round(i.State.Round),
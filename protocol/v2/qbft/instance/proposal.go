@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/protocol/v2/dutytrace"
 	"github.com/bloxapp/ssv/protocol/v2/qbft"
 	"github.com/bloxapp/ssv/protocol/v2/types"
 )
@@ -38,6 +39,7 @@ func (i *Instance) uponProposal(logger *zap.Logger, signedProposal *specqbft.Sig
 	i.bumpToRound(newRound)
 
 	i.metrics.EndStageProposal()
+	i.trace(dutytrace.StageProposalAccepted, signedProposal.Signers)
 
 	// value root
 	r, err := specqbft.HashDataRoot(signedProposal.FullData)
@@ -1,8 +1,11 @@
 package queue
 
 import (
+	"fmt"
+
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
 )
 
 // State represents a portion of the the current state
@@ -19,6 +22,25 @@ type State struct {
 type MessagePrioritizer interface {
 	// Prior returns true if message A should be prioritized over B.
 	Prior(a, b *DecodedSSVMessage) bool
+	// Stale returns true if the message is no longer useful, such messages are evicted first from a full queue.
+	Stale(m *DecodedSSVMessage) bool
+	// Protected returns true if the message must never be evicted from a full queue.
+	Protected(m *DecodedSSVMessage) bool
+}
+
+// PrioritizerFactory creates a MessagePrioritizer which prioritizes messages according to the given State.
+type PrioritizerFactory func(state *State) MessagePrioritizer
+
+// PrioritizerFromString returns the PrioritizerFactory of the given name.
+func PrioritizerFromString(s string) (PrioritizerFactory, error) {
+	switch s {
+	case "standard":
+		return NewMessagePrioritizer, nil
+	case "decided-first":
+		return NewDecidedFirstPrioritizer, nil
+	default:
+		return nil, fmt.Errorf("unknown prioritizer: %s", s)
+	}
 }
 
 type standardPrioritizer struct {
@@ -60,6 +82,50 @@ func (p *standardPrioritizer) Prior(a, b *DecodedSSVMessage) bool {
 	return true
 }
 
+// Stale returns true for messages of lower heights or slots, except for commit and decided messages.
+func (p *standardPrioritizer) Stale(m *DecodedSSVMessage) bool {
+	switch m.Body.(type) {
+	case *qbft.SignedMessage, *spectypes.SignedPartialSignatureMessage:
+		relativeHeight := compareHeightOrSlot(p.state, m)
+		return relativeHeight == -1 && scoreMessageSubtype(p.state, m, relativeHeight) == 0
+	default:
+		return false
+	}
+}
+
+// Protected returns true for commit and decided messages of the current height,
+// which the running instance needs to decide.
+func (p *standardPrioritizer) Protected(m *DecodedSSVMessage) bool {
+	sm, ok := m.Body.(*qbft.SignedMessage)
+	return ok && sm.Message.MsgType == qbft.CommitMsgType && compareHeightOrSlot(p.state, m) == 0
+}
+
+type decidedFirstPrioritizer struct {
+	standardPrioritizer
+}
+
+// NewDecidedFirstPrioritizer returns a MessagePrioritizer which prioritizes decided messages of any height
+// over all but event messages, so that a node falling behind catches up to its cluster first.
+// Other messages are prioritized like the standard implementation.
+func NewDecidedFirstPrioritizer(state *State) MessagePrioritizer {
+	return &decidedFirstPrioritizer{standardPrioritizer{state: state}}
+}
+
+func (p *decidedFirstPrioritizer) Prior(a, b *DecodedSSVMessage) bool {
+	if scoreMessageType(a) == scoreMessageType(b) {
+		decidedA, decidedB := p.isDecided(a), p.isDecided(b)
+		if decidedA != decidedB {
+			return decidedA
+		}
+	}
+	return p.standardPrioritizer.Prior(a, b)
+}
+
+func (p *decidedFirstPrioritizer) isDecided(m *DecodedSSVMessage) bool {
+	sm, _ := m.Body.(*qbft.SignedMessage)
+	return isDecidedMesssage(p.state, sm)
+}
+
 func scoreHeight(relativeHeight int) int {
 	switch relativeHeight {
 	case 0:
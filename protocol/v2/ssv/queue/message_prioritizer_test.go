@@ -153,6 +153,80 @@ func TestMessagePrioritizer(t *testing.T) {
 	}
 }
 
+func TestDecidedFirstPrioritizer(t *testing.T) {
+	decode := func(m mockMessage) *DecodedSSVMessage {
+		msg, err := DecodeSSVMessage(zap.L(), m.ssvMessage(mockState))
+		require.NoError(t, err)
+		return msg
+	}
+	proposal := decode(mockConsensusMessage{Height: 100, Type: qbft.ProposalMsgType})
+	decided := decode(mockConsensusMessage{Height: 101, Decided: true})
+	event := decode(mockExecuteDutyMessage{Slot: 62, Role: spectypes.BNRoleProposer})
+
+	standard := NewMessagePrioritizer(mockState)
+	require.True(t, standard.Prior(proposal, decided))
+
+	decidedFirst := NewDecidedFirstPrioritizer(mockState)
+	require.True(t, decidedFirst.Prior(decided, proposal))
+	require.False(t, decidedFirst.Prior(proposal, decided))
+	require.True(t, decidedFirst.Prior(event, decided))
+}
+
+func TestStaleMessages(t *testing.T) {
+	prioritizer := NewMessagePrioritizer(mockState)
+	for _, test := range []struct {
+		message mockMessage
+		stale   bool
+	}{
+		{mockConsensusMessage{Height: 99, Type: qbft.PrepareMsgType}, true},
+		{mockConsensusMessage{Height: 99, Type: qbft.CommitMsgType}, false},
+		{mockConsensusMessage{Height: 99, Decided: true}, false},
+		{mockConsensusMessage{Height: 100, Type: qbft.PrepareMsgType}, false},
+		{mockNonConsensusMessage{Slot: 63, Type: spectypes.PostConsensusPartialSig}, true},
+		{mockNonConsensusMessage{Slot: 64, Type: spectypes.PostConsensusPartialSig}, false},
+		{mockExecuteDutyMessage{Slot: 62, Role: spectypes.BNRoleProposer}, false},
+	} {
+		msg, err := DecodeSSVMessage(zap.L(), test.message.ssvMessage(mockState))
+		require.NoError(t, err)
+		require.Equal(t, test.stale, prioritizer.Stale(msg), "%#v", test.message)
+	}
+}
+
+func TestProtectedMessages(t *testing.T) {
+	prioritizer := NewMessagePrioritizer(mockState)
+	for _, test := range []struct {
+		message   mockMessage
+		protected bool
+	}{
+		{mockConsensusMessage{Height: 100, Type: qbft.CommitMsgType}, true},
+		{mockConsensusMessage{Height: 100, Decided: true}, true},
+		{mockConsensusMessage{Height: 100, Type: qbft.ProposalMsgType}, false},
+		{mockConsensusMessage{Height: 99, Type: qbft.CommitMsgType}, false},
+		{mockConsensusMessage{Height: 101, Decided: true}, false},
+		{mockNonConsensusMessage{Slot: 64, Type: spectypes.PostConsensusPartialSig}, false},
+	} {
+		msg, err := DecodeSSVMessage(zap.L(), test.message.ssvMessage(mockState))
+		require.NoError(t, err)
+		require.Equal(t, test.protected, prioritizer.Protected(msg), "%#v", test.message)
+	}
+}
+
+func TestPrioritizerFromString(t *testing.T) {
+	for _, name := range []string{"standard", "decided-first"} {
+		factory, err := PrioritizerFromString(name)
+		require.NoError(t, err)
+		require.NotNil(t, factory(mockState))
+	}
+	_, err := PrioritizerFromString("unknown")
+	require.Error(t, err)
+
+	policy, err := DropPolicyFromString("lowest-priority")
+	require.NoError(t, err)
+	require.Equal(t, DropLowestPriority, policy)
+	_, err = DropPolicyFromString("unknown")
+	require.Error(t, err)
+}
+
 type mockMessage interface {
 	ssvMessage(*State) *spectypes.SSVMessage
 }
@@ -2,12 +2,8 @@ package queue
 
 import (
 	"context"
-	"time"
-)
-
-const (
-	// inboxReadFrequency is the minimum time between reads from the inbox.
-	inboxReadFrequency = 1 * time.Millisecond
+	"fmt"
+	"sync"
 )
 
 // Filter is a function that returns true if the message should be popped.
@@ -22,7 +18,7 @@ type Queue interface {
 	Push(*DecodedSSVMessage)
 
 	// TryPush returns immediately with true if the message was pushed to the queue,
-	// or false if the queue is full.
+	// or false if the queue is full and the message was dropped.
 	TryPush(*DecodedSSVMessage) bool
 
 	// Pop returns and removes the next message in the queue, or blocks until a message is available.
@@ -39,17 +35,65 @@ type Queue interface {
 	Len() int
 }
 
+// DropPolicy decides which message is dropped when pushing to a full queue.
+type DropPolicy int
+
+const (
+	// DropNewest drops the pushed message.
+	DropNewest DropPolicy = iota
+	// DropLowestPriority evicts stale messages, or else the lowest priority message, which may be the pushed one.
+	// Protected messages, such as commits and decideds of the current height, are never evicted.
+	// Priorities are decided by the prioritizer of the latest pop, the pushed message is dropped before any pop.
+	DropLowestPriority
+)
+
+// DropPolicyFromString returns the DropPolicy of the given name.
+func DropPolicyFromString(s string) (DropPolicy, error) {
+	switch s {
+	case "newest":
+		return DropNewest, nil
+	case "lowest-priority":
+		return DropLowestPriority, nil
+	default:
+		return 0, fmt.Errorf("unknown drop policy: %s", s)
+	}
+}
+
+// EvictHandler is called with messages evicted from the queue to make room for a pushed message.
+type EvictHandler func(*DecodedSSVMessage)
+
 type priorityQueue struct {
+	mu       sync.Mutex
 	head     *item
-	inbox    chan *DecodedSSVMessage
-	lastRead time.Time
+	len      int
+	capacity int
+	policy   DropPolicy
+	onEvict  EvictHandler
+	// prioritizer is the prioritizer of the latest pop, used to choose which message to evict.
+	prioritizer MessagePrioritizer
+
+	// pushed and popped wake up a waiting Pop and Push respectively.
+	pushed chan struct{}
+	popped chan struct{}
 }
 
-// New returns an implementation of Queue optimized for concurrent push and sequential pop.
+// New returns an implementation of Queue optimized for concurrent push and sequential pop,
+// which drops pushed messages when full.
 // Pops aren't thread-safe, so don't call Pop from multiple goroutines.
 func New(capacity int) Queue {
+	return NewWithPolicy(capacity, DropNewest, nil)
+}
+
+// NewWithPolicy returns an implementation of Queue optimized for concurrent push and sequential pop,
+// which drops messages according to the given policy when full. onEvict is optional.
+// Pops aren't thread-safe, so don't call Pop from multiple goroutines.
+func NewWithPolicy(capacity int, policy DropPolicy, onEvict EvictHandler) Queue {
 	return &priorityQueue{
-		inbox: make(chan *DecodedSSVMessage, capacity),
+		capacity: capacity,
+		policy:   policy,
+		onEvict:  onEvict,
+		pushed:   make(chan struct{}, 1),
+		popped:   make(chan struct{}, 1),
 	}
 }
 
@@ -60,137 +104,170 @@ func NewDefault() Queue {
 }
 
 func (q *priorityQueue) Push(msg *DecodedSSVMessage) {
-	q.inbox <- msg
+	for {
+		q.mu.Lock()
+		if q.len < q.capacity {
+			q.push(msg)
+			if q.len < q.capacity {
+				// pass the wake up on to other waiting pushers
+				notify(q.popped)
+			}
+			q.mu.Unlock()
+			return
+		}
+		q.mu.Unlock()
+		<-q.popped
+	}
 }
 
 func (q *priorityQueue) TryPush(msg *DecodedSSVMessage) bool {
-	select {
-	case q.inbox <- msg:
-		return true
-	default:
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.len >= q.capacity && !q.evictFor(msg) {
 		return false
 	}
+	q.push(msg)
+	return true
 }
 
-func (q *priorityQueue) TryPop(prioritizer MessagePrioritizer, filter Filter) *DecodedSSVMessage {
-	// Read any pending messages from the inbox.
-	q.readInbox()
-
-	// Pop the highest priority message.
-	if q.head != nil {
-		return q.pop(prioritizer, filter)
-	}
-
-	return nil
+// push adds the message to the queue, the caller must hold the lock
+func (q *priorityQueue) push(msg *DecodedSSVMessage) {
+	q.head = &item{message: msg, next: q.head}
+	q.len++
+	notify(q.pushed)
 }
 
-func (q *priorityQueue) Pop(ctx context.Context, prioritizer MessagePrioritizer, filter Filter) *DecodedSSVMessage {
-	// Read any pending messages from the inbox, if enough time has passed.
-	// inboxReadFrequency is a tradeoff between responsiveness and computational cost,
-	// since reading the inbox is more expensive than just reading the head.
-	if time.Since(q.lastRead) > inboxReadFrequency {
-		q.readInbox()
+// evictFor makes room for the given message according to the drop policy,
+// returns false if the message should be dropped instead. The caller must hold the lock.
+func (q *priorityQueue) evictFor(msg *DecodedSSVMessage) bool {
+	if q.policy != DropLowestPriority || q.prioritizer == nil || q.head == nil {
+		return false
 	}
 
-	// Try to pop immediately.
-	if q.head != nil {
-		if m := q.pop(prioritizer, filter); m != nil {
-			return m
+	// evict all stale messages
+	var prior *item
+	for current := q.head; current != nil; current = current.next {
+		if q.prioritizer.Stale(current.message) {
+			q.remove(prior, current)
+			q.evicted(current.message)
+			continue
 		}
+		prior = current
+	}
+	if q.len < q.capacity {
+		return true
 	}
 
-	// Wait for a message to be pushed.
-Wait:
-	for {
-		select {
-		case msg := <-q.inbox:
-			if q.head == nil {
-				q.head = &item{message: msg}
-			} else {
-				q.head = &item{message: msg, next: q.head}
-			}
-			if filter(msg) {
-				break Wait
-			}
-		case <-ctx.Done():
-			break Wait
+	// evict the lowest priority message which isn't protected, unless it's the pushed one
+	var (
+		lowestPrior *item
+		lowest      *item
+	)
+	for prior, current := (*item)(nil), q.head; current != nil; prior, current = current, current.next {
+		if q.prioritizer.Protected(current.message) {
+			continue
+		}
+		if lowest == nil || q.prioritizer.Prior(lowest.message, current.message) {
+			lowest = current
+			lowestPrior = prior
 		}
 	}
+	if lowest == nil || !q.prioritizer.Prior(msg, lowest.message) && !q.prioritizer.Protected(msg) {
+		return false
+	}
+	q.remove(lowestPrior, lowest)
+	q.evicted(lowest.message)
+	return true
+}
 
-	// Read any messages that were pushed while waiting.
-	q.readInbox()
-
-	// Pop the highest priority message.
-	if q.head != nil {
-		return q.pop(prioritizer, filter)
+func (q *priorityQueue) evicted(msg *DecodedSSVMessage) {
+	if q.onEvict != nil {
+		q.onEvict(msg)
 	}
+}
 
-	return nil
+// remove unlinks the given item, which follows prior (nil for the head). The caller must hold the lock.
+func (q *priorityQueue) remove(prior, current *item) {
+	if prior == nil {
+		q.head = current.next
+	} else {
+		prior.next = current.next
+	}
+	q.len--
 }
 
-func (q *priorityQueue) readInbox() {
-	q.lastRead = time.Now()
+func (q *priorityQueue) TryPop(prioritizer MessagePrioritizer, filter Filter) *DecodedSSVMessage {
+	q.mu.Lock()
+	defer q.mu.Unlock()
 
-	for {
-		select {
-		case msg := <-q.inbox:
-			if q.head == nil {
-				q.head = &item{message: msg}
-			} else {
-				q.head = &item{message: msg, next: q.head}
-			}
-		default:
-			return
-		}
+	q.prioritizer = prioritizer
+	if q.head == nil {
+		return nil
+	}
+	m := q.pop(prioritizer, filter)
+	if m != nil {
+		notify(q.popped)
 	}
+	return m
 }
 
-func (q *priorityQueue) pop(prioritizer MessagePrioritizer, filter Filter) *DecodedSSVMessage {
-	if q.head.next == nil {
-		if m := q.head.message; filter(m) {
-			q.head = nil
+func (q *priorityQueue) Pop(ctx context.Context, prioritizer MessagePrioritizer, filter Filter) *DecodedSSVMessage {
+	for {
+		if m := q.TryPop(prioritizer, filter); m != nil {
 			return m
 		}
-		return nil
+
+		// Wait for a message to be pushed.
+		select {
+		case <-q.pushed:
+		case <-ctx.Done():
+			return q.TryPop(prioritizer, filter)
+		}
 	}
+}
 
-	// Remove the highest priority message and return it.
+// pop removes and returns the highest priority message which passes the filter,
+// the caller must hold the lock
+func (q *priorityQueue) pop(prioritizer MessagePrioritizer, filter Filter) *DecodedSSVMessage {
 	var (
-		prior   *item
-		highest = q.head
-		current = q.head
+		highestPrior *item
+		highest      *item
 	)
-	for {
-		if prioritizer.Prior(current.next.message, highest.message) && filter(current.next.message) {
-			highest = current.next
-			prior = current
+	for prior, current := (*item)(nil), q.head; current != nil; prior, current = current, current.next {
+		if !filter(current.message) {
+			continue
 		}
-		current = current.next
-		if current.next == nil {
-			break
+		if highest == nil || prioritizer.Prior(current.message, highest.message) {
+			highest = current
+			highestPrior = prior
 		}
 	}
-	if prior == nil {
-		q.head = highest.next
-	} else {
-		prior.next = highest.next
-	}
-	if filter(highest.message) {
-		return highest.message
+	if highest == nil {
+		return nil
 	}
-	return nil
+	q.remove(highestPrior, highest)
+	return highest.message
 }
 
 func (q *priorityQueue) Empty() bool {
-	return q.head == nil && len(q.inbox) == 0
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.len == 0
 }
 
 func (q *priorityQueue) Len() int {
-	n := len(q.inbox)
-	for i := q.head; i != nil; i = i.next {
-		n++
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.len
+}
+
+// notify signals the given channel without blocking, wake ups which weren't consumed yet are merged
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
 	}
-	return n
 }
 
 // item is a node in a linked list of DecodedSSVMessage.
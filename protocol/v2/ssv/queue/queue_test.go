@@ -210,6 +210,58 @@ func TestWithMetrics(t *testing.T) {
 	require.Equal(t, 1, metrics.dropped)
 }
 
+func TestPriorityQueue_DropLowestPriority(t *testing.T) {
+	var evicted []*DecodedSSVMessage
+	queue := NewWithPolicy(2, DropLowestPriority, func(msg *DecodedSSVMessage) {
+		evicted = append(evicted, msg)
+	})
+
+	// Before any pop, the pushed message is dropped.
+	stale := decodeAndPush(t, queue, mockConsensusMessage{Height: 99, Type: qbft.PrepareMsgType}, mockState)
+	prepare := decodeAndPush(t, queue, mockConsensusMessage{Height: 100, Type: qbft.PrepareMsgType}, mockState)
+	commit, err := DecodeSSVMessage(zap.L(), mockConsensusMessage{Height: 100, Type: qbft.CommitMsgType}.ssvMessage(mockState))
+	require.NoError(t, err)
+	require.False(t, queue.TryPush(commit))
+	require.Empty(t, evicted)
+
+	// Pop with a non-matching filter to learn the prioritizer, without losing messages.
+	require.Nil(t, queue.TryPop(NewMessagePrioritizer(mockState), func(*DecodedSSVMessage) bool { return false }))
+	require.Equal(t, 2, queue.Len())
+
+	// Stale messages are evicted first.
+	require.True(t, queue.TryPush(commit))
+	require.Equal(t, []*DecodedSSVMessage{stale}, evicted)
+
+	// Then the lowest priority message, if it's lower than the pushed one,
+	// while commits of the current height are never evicted.
+	proposal, err := DecodeSSVMessage(zap.L(), mockConsensusMessage{Height: 100, Type: qbft.ProposalMsgType}.ssvMessage(mockState))
+	require.NoError(t, err)
+	require.True(t, queue.TryPush(proposal))
+	require.Equal(t, []*DecodedSSVMessage{stale, prepare}, evicted)
+
+	roundChange, err := DecodeSSVMessage(zap.L(), mockConsensusMessage{Height: 100, Type: qbft.RoundChangeMsgType}.ssvMessage(mockState))
+	require.NoError(t, err)
+	require.False(t, queue.TryPush(roundChange))
+	require.Len(t, evicted, 2)
+
+	// A decided of the current height makes room for itself regardless of priorities.
+	decided, err := DecodeSSVMessage(zap.L(), mockConsensusMessage{Height: 100, Decided: true}.ssvMessage(mockState))
+	require.NoError(t, err)
+	require.True(t, queue.TryPush(decided))
+	require.Equal(t, []*DecodedSSVMessage{stale, prepare, proposal}, evicted)
+
+	// Once the queue is full of protected messages, pushed messages are dropped.
+	require.False(t, queue.TryPush(proposal))
+	require.Len(t, evicted, 3)
+
+	popped := []*DecodedSSVMessage{
+		queue.TryPop(NewMessagePrioritizer(mockState), FilterAny),
+		queue.TryPop(NewMessagePrioritizer(mockState), FilterAny),
+	}
+	require.ElementsMatch(t, []*DecodedSSVMessage{commit, decided}, popped)
+	require.True(t, queue.Empty())
+}
+
 func BenchmarkPriorityQueue_Parallel(b *testing.B) {
 	benchmarkPriorityQueueParallel(b, func() Queue {
 		return New(32)
@@ -0,0 +1,228 @@
+package runner
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv-spec/qbft"
+	specssv "github.com/bloxapp/ssv-spec/ssv"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/message"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/runner/metrics"
+)
+
+// BLSToExecutionChangeRunner collects partial signatures over a BLSToExecutionChange and submits the change,
+// it has no consensus phase since the change is fully determined by the duty and the execution address.
+// The change is signed with the validator key, so it only applies to validators whose BLS withdrawal
+// credentials commit to the validator key itself.
+type BLSToExecutionChangeRunner struct {
+	BaseRunner *BaseRunner
+	// ExecutionAddress is the address the withdrawal credentials are changed to
+	ExecutionAddress bellatrix.ExecutionAddress
+
+	beacon   beacon.BeaconNode
+	network  specssv.Network
+	signer   spectypes.KeyManager
+	valCheck qbft.ProposedValueCheckF
+
+	metrics metrics.ConsensusMetrics
+}
+
+func NewBLSToExecutionChangeRunner(
+	beaconNetwork beacon.Network,
+	share *spectypes.Share,
+	beacon beacon.BeaconNode,
+	network specssv.Network,
+	signer spectypes.KeyManager,
+) Runner {
+	return &BLSToExecutionChangeRunner{
+		BaseRunner: &BaseRunner{
+			BeaconRoleType: message.BNRoleBLSToExecutionChange,
+			BeaconNetwork:  beaconNetwork,
+			Share:          share,
+		},
+
+		beacon:  beacon,
+		network: network,
+		signer:  signer,
+		metrics: metrics.NewConsensusMetrics(message.BNRoleBLSToExecutionChange),
+	}
+}
+
+// SetExecutionAddress sets the address the withdrawal credentials are changed to by the next duty
+func (r *BLSToExecutionChangeRunner) SetExecutionAddress(address bellatrix.ExecutionAddress) {
+	r.ExecutionAddress = address
+}
+
+func (r *BLSToExecutionChangeRunner) StartNewDuty(logger *zap.Logger, duty *spectypes.Duty) error {
+	return r.BaseRunner.baseStartNewDuty(logger, r, duty)
+}
+
+// HasRunningDuty returns true if a duty is already running (StartNewDuty called and returned nil)
+func (r *BLSToExecutionChangeRunner) HasRunningDuty() bool {
+	return r.BaseRunner.hasRunningDuty()
+}
+
+func (r *BLSToExecutionChangeRunner) ProcessPreConsensus(logger *zap.Logger, signedMsg *spectypes.SignedPartialSignatureMessage) error {
+	quorum, roots, err := r.BaseRunner.basePreConsensusMsgProcessing(r, signedMsg)
+	if err != nil {
+		return errors.Wrap(err, "failed processing bls to execution change message")
+	}
+
+	// quorum returns true only once (first time quorum achieved)
+	if !quorum {
+		return nil
+	}
+
+	// only 1 root, verified in basePreConsensusMsgProcessing
+	root := roots[0]
+	fullSig, err := r.GetState().ReconstructBeaconSig(r.GetState().PreConsensusContainer, root, r.GetShare().ValidatorPubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not reconstruct bls to execution change sig")
+	}
+	signedChange := &capella.SignedBLSToExecutionChange{
+		Message: r.calculateBLSToExecutionChange(),
+	}
+	copy(signedChange.Signature[:], fullSig)
+
+	if err := r.beacon.SubmitBLSToExecutionChange(signedChange); err != nil {
+		r.BaseRunner.traceSubmission(err)
+		return errors.Wrap(err, "could not submit bls to execution change")
+	}
+	r.BaseRunner.traceSubmission(nil)
+
+	logger.Info("✅ bls to execution change submitted successfully",
+		zap.String("execution_address", signedChange.Message.ToExecutionAddress.String()),
+		zap.Uint64("validator_index", uint64(signedChange.Message.ValidatorIndex)),
+	)
+
+	r.GetState().Finished = true
+	return nil
+}
+
+func (r *BLSToExecutionChangeRunner) ProcessConsensus(logger *zap.Logger, signedMsg *qbft.SignedMessage) error {
+	return errors.New("no consensus phase for bls to execution change")
+}
+
+func (r *BLSToExecutionChangeRunner) ProcessPostConsensus(logger *zap.Logger, signedMsg *spectypes.SignedPartialSignatureMessage) error {
+	return errors.New("no post consensus phase for bls to execution change")
+}
+
+func (r *BLSToExecutionChangeRunner) expectedPreConsensusRootsAndDomain() ([]ssz.HashRoot, phase0.DomainType, error) {
+	return []ssz.HashRoot{r.calculateBLSToExecutionChange()}, message.DomainBLSToExecutionChange, nil
+}
+
+// expectedPostConsensusRootsAndDomain an INTERNAL function, returns the expected post-consensus roots to sign
+func (r *BLSToExecutionChangeRunner) expectedPostConsensusRootsAndDomain() ([]ssz.HashRoot, phase0.DomainType, error) {
+	return nil, [4]byte{}, errors.New("no post consensus roots for bls to execution change")
+}
+
+func (r *BLSToExecutionChangeRunner) executeDuty(logger *zap.Logger, duty *spectypes.Duty) error {
+	if r.ExecutionAddress == (bellatrix.ExecutionAddress{}) {
+		return errors.New("no execution address to change the withdrawal credentials to")
+	}
+	change := r.calculateBLSToExecutionChange()
+
+	// sign partial bls to execution change
+	msg, err := r.BaseRunner.signBeaconObject(r, change, duty.Slot, message.DomainBLSToExecutionChange)
+	if err != nil {
+		return errors.Wrap(err, "could not sign bls to execution change")
+	}
+	msgs := spectypes.PartialSignatureMessages{
+		Type:     message.BLSToExecutionChangePartialSig,
+		Slot:     duty.Slot,
+		Messages: []*spectypes.PartialSignatureMessage{msg},
+	}
+
+	// sign msg
+	signature, err := r.GetSigner().SignRoot(msgs, spectypes.PartialSignatureType, r.GetShare().SharePubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not sign bls to execution change msg")
+	}
+	signedPartialMsg := &spectypes.SignedPartialSignatureMessage{
+		Message:   msgs,
+		Signature: signature,
+		Signer:    r.GetShare().OperatorID,
+	}
+
+	// broadcast
+	data, err := signedPartialMsg.Encode()
+	if err != nil {
+		return errors.Wrap(err, "failed to encode bls to execution change pre-consensus signature msg")
+	}
+	msgToBroadcast := &spectypes.SSVMessage{
+		MsgType: spectypes.SSVPartialSignatureMsgType,
+		MsgID:   spectypes.NewMsgID(r.GetShare().DomainType, r.GetShare().ValidatorPubKey, r.BaseRunner.BeaconRoleType),
+		Data:    data,
+	}
+	if err := r.GetNetwork().Broadcast(msgToBroadcast); err != nil {
+		return errors.Wrap(err, "can't broadcast partial bls to execution change sig")
+	}
+	return nil
+}
+
+// calculateBLSToExecutionChange returns the change of the duty's validator from its own key to the execution address
+func (r *BLSToExecutionChangeRunner) calculateBLSToExecutionChange() *capella.BLSToExecutionChange {
+	change := &capella.BLSToExecutionChange{
+		ValidatorIndex:     r.BaseRunner.State.StartingDuty.ValidatorIndex,
+		ToExecutionAddress: r.ExecutionAddress,
+	}
+	copy(change.FromBLSPubkey[:], r.GetShare().ValidatorPubKey)
+	return change
+}
+
+func (r *BLSToExecutionChangeRunner) GetBaseRunner() *BaseRunner {
+	return r.BaseRunner
+}
+
+func (r *BLSToExecutionChangeRunner) GetNetwork() specssv.Network {
+	return r.network
+}
+
+func (r *BLSToExecutionChangeRunner) GetBeaconNode() specssv.BeaconNode {
+	return r.beacon
+}
+
+func (r *BLSToExecutionChangeRunner) GetShare() *spectypes.Share {
+	return r.BaseRunner.Share
+}
+
+func (r *BLSToExecutionChangeRunner) GetState() *State {
+	return r.BaseRunner.State
+}
+
+func (r *BLSToExecutionChangeRunner) GetValCheckF() qbft.ProposedValueCheckF {
+	return r.valCheck
+}
+
+func (r *BLSToExecutionChangeRunner) GetSigner() spectypes.KeyManager {
+	return r.signer
+}
+
+// Encode returns the encoded struct in bytes or error
+func (r *BLSToExecutionChangeRunner) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// Decode returns error if decoding failed
+func (r *BLSToExecutionChangeRunner) Decode(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
+
+// GetRoot returns the root used for signing and verification
+func (r *BLSToExecutionChangeRunner) GetRoot() ([32]byte, error) {
+	marshaledRoot, err := r.Encode()
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not encode DutyRunnerState")
+	}
+	ret := sha256.Sum256(marshaledRoot)
+	return ret, nil
+}
@@ -0,0 +1,66 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	spectestingutils "github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/protocol/v2/message"
+)
+
+func TestBLSToExecutionChangeRunner(t *testing.T) {
+	logger := logging.TestLogger(t)
+	ks := spectestingutils.Testing4SharesSet()
+	address := bellatrix.ExecutionAddress{1, 2, 3, 4}
+	duty := &spectypes.Duty{
+		Type:           message.BNRoleBLSToExecutionChange,
+		PubKey:         spectestingutils.TestingValidatorPubKey,
+		Slot:           spectestingutils.TestingDutySlot,
+		ValidatorIndex: spectestingutils.TestingValidatorIndex,
+	}
+	newRunner := func(share *spectypes.Share, submitter *testSubmitter, network *spectestingutils.TestingNetwork) Runner {
+		r := NewBLSToExecutionChangeRunner(testNetwork, share, submitter, network, spectestingutils.NewTestingKeyManager())
+		r.(*BLSToExecutionChangeRunner).SetExecutionAddress(address)
+		return r
+	}
+
+	msgs := collectPreConsensus(t, ks, duty, func(share *spectypes.Share, network *spectestingutils.TestingNetwork) Runner {
+		return newRunner(share, &testSubmitter{}, network)
+	})
+
+	submitter := &testSubmitter{}
+	r := newRunner(operatorShare(ks, 1), submitter, spectestingutils.NewTestingNetwork())
+	require.NoError(t, r.StartNewDuty(logger, duty))
+
+	// no quorum until 3 of the 4 operators signed
+	require.NoError(t, r.ProcessPreConsensus(logger, msgs[0]))
+	require.NoError(t, r.ProcessPreConsensus(logger, msgs[1]))
+	require.Empty(t, submitter.changes)
+
+	require.NoError(t, r.ProcessPreConsensus(logger, msgs[2]))
+	require.Len(t, submitter.changes, 1)
+	require.True(t, r.GetBaseRunner().State.Finished)
+
+	change := submitter.changes[0]
+	require.Equal(t, duty.ValidatorIndex, change.Message.ValidatorIndex)
+	require.Equal(t, address, change.Message.ToExecutionAddress)
+	require.Equal(t, ks.ValidatorPK.Serialize(), change.Message.FromBLSPubkey[:])
+	verifyBeaconSig(t, ks, change.Signature, change.Message, message.DomainBLSToExecutionChange)
+
+	t.Run("different address", func(t *testing.T) {
+		// partial signatures over a change to another address don't match the expected root
+		other := newRunner(operatorShare(ks, 1), &testSubmitter{}, spectestingutils.NewTestingNetwork())
+		other.(*BLSToExecutionChangeRunner).SetExecutionAddress(bellatrix.ExecutionAddress{5})
+		require.NoError(t, other.StartNewDuty(logger, duty))
+		require.Error(t, other.ProcessPreConsensus(logger, msgs[1]))
+	})
+
+	t.Run("no execution address", func(t *testing.T) {
+		r := NewBLSToExecutionChangeRunner(testNetwork, operatorShare(ks, 1), &testSubmitter{}, spectestingutils.NewTestingNetwork(), spectestingutils.NewTestingKeyManager())
+		require.Error(t, r.StartNewDuty(logger, duty))
+	})
+}
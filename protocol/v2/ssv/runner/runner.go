@@ -1,6 +1,9 @@
 package runner
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
 	"sync"
 
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
@@ -11,6 +14,9 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/dutytrace"
 	"github.com/bloxapp/ssv/protocol/v2/qbft/controller"
 )
 
@@ -50,11 +56,15 @@ type BaseRunner struct {
 	State          *State
 	Share          *spectypes.Share
 	QBFTController *controller.Controller
-	BeaconNetwork  spectypes.BeaconNetwork
+	BeaconNetwork  beacon.Network
 	BeaconRoleType spectypes.BeaconRole
 
 	// implementation vars
 	TimeoutF TimeoutF `json:"-"`
+	// Shadow marks runners of a node in shadow mode, which compare the values they would have proposed with the decided ones
+	Shadow bool `json:"-"`
+	// DutyTracer records the lifecycle of the runner's duties, nil when tracing is disabled
+	DutyTracer *dutytrace.Recorder `json:"-"`
 
 	// highestDecidedSlot holds the highest decided duty slot and gets updated after each decided is reached
 	highestDecidedSlot spec.Slot
@@ -80,7 +90,7 @@ func NewBaseRunner(
 	state *State,
 	share *spectypes.Share,
 	controller *controller.Controller,
-	beaconNetwork spectypes.BeaconNetwork,
+	beaconNetwork beacon.Network,
 	beaconRoleType spectypes.BeaconRole,
 	highestDecidedSlot spec.Slot,
 ) *BaseRunner {
@@ -97,6 +107,7 @@ func NewBaseRunner(
 // baseStartNewDuty is a base func that all runner implementation can call to start a duty
 func (b *BaseRunner) baseStartNewDuty(logger *zap.Logger, runner Runner, duty *spectypes.Duty) error {
 	b.baseSetupForNewDuty(duty)
+	b.trace(dutytrace.Event{Stage: dutytrace.StageStarted})
 	return runner.executeDuty(logger, duty)
 }
 
@@ -107,6 +118,9 @@ func (b *BaseRunner) basePreConsensusMsgProcessing(runner Runner, signedMsg *spe
 	}
 
 	hasQuorum, roots, err := b.basePartialSigMsgProcessing(signedMsg, b.State.PreConsensusContainer)
+	if hasQuorum {
+		b.traceQuorum(dutytrace.StagePreConsensusQuorum, b.State.PreConsensusContainer, roots)
+	}
 	return hasQuorum, roots, errors.Wrap(err, "could not process pre-consensus partial signature msg")
 }
 
@@ -167,10 +181,73 @@ func (b *BaseRunner) baseConsensusMsgProcessing(logger *zap.Logger, runner Runne
 	}
 
 	runner.GetBaseRunner().State.DecidedValue = decidedValue
+	b.trace(dutytrace.Event{Stage: dutytrace.StageDecided, Round: decidedMsg.Message.Round, Signers: decidedMsg.Signers})
+
+	if b.Shadow {
+		b.compareShadowValue(logger, decidedMsg)
+	}
 
 	return true, decidedValue, nil
 }
 
+// compareShadowValue logs whether the value decided by the cluster is the one this node would have proposed.
+// The decided value is expected to match only when this node led the decided round in the first round,
+// later leaders may re-propose a value prepared in an earlier round, so the other cases are logged at debug level.
+func (b *BaseRunner) compareShadowValue(logger *zap.Logger, decidedMsg *specqbft.SignedMessage) {
+	instance := b.State.RunningInstance
+	decidedRoot := sha256.Sum256(decidedMsg.FullData)
+	shadowRoot := sha256.Sum256(instance.StartValue)
+	leader := instance.GetConfig().GetProposerF()(instance.State, decidedMsg.Message.Round)
+	logger = logger.With(
+		fields.Height(decidedMsg.Message.Height),
+		fields.Round(decidedMsg.Message.Round),
+		zap.Uint64("leader", leader),
+		zap.Any("signers", decidedMsg.Signers),
+	)
+	if decidedRoot == shadowRoot {
+		logger.Info("👻 shadow: decided value matches")
+		return
+	}
+	log := logger.Warn
+	if leader != b.Share.OperatorID || decidedMsg.Message.Round != specqbft.FirstRound {
+		log = logger.Debug
+	}
+	log("👻 shadow: decided value differs",
+		zap.String("decided_root", hex.EncodeToString(decidedRoot[:])),
+		zap.String("shadow_root", hex.EncodeToString(shadowRoot[:])))
+}
+
+// trace records an event in the trace of the running duty
+func (b *BaseRunner) trace(event dutytrace.Event) {
+	if b.DutyTracer == nil || b.State == nil || b.State.StartingDuty == nil {
+		return
+	}
+	msgID := spectypes.NewMsgID(b.Share.DomainType, b.Share.ValidatorPubKey, b.BeaconRoleType)
+	b.DutyTracer.Record(msgID, b.State.StartingDuty.Slot, event)
+}
+
+// traceQuorum records a quorum of partial signatures along with its signers
+func (b *BaseRunner) traceQuorum(stage dutytrace.Stage, container *specssv.PartialSigContainer, roots [][32]byte) {
+	if b.DutyTracer == nil {
+		return
+	}
+	signers := make([]spectypes.OperatorID, 0, b.Share.Quorum)
+	for signer := range container.Signatures[hex.EncodeToString(roots[0][:])] {
+		signers = append(signers, signer)
+	}
+	sort.Slice(signers, func(i, j int) bool { return signers[i] < signers[j] })
+	b.trace(dutytrace.Event{Stage: stage, Signers: signers})
+}
+
+// traceSubmission records the outcome of submitting the duty to the beacon node
+func (b *BaseRunner) traceSubmission(err error) {
+	if err != nil {
+		b.trace(dutytrace.Event{Stage: dutytrace.StageSubmissionFailed, Error: err.Error()})
+		return
+	}
+	b.trace(dutytrace.Event{Stage: dutytrace.StageSubmitted})
+}
+
 // basePostConsensusMsgProcessing is a base func that all runner implementation can call for processing a post-consensus msg
 func (b *BaseRunner) basePostConsensusMsgProcessing(logger *zap.Logger, runner Runner, signedMsg *spectypes.SignedPartialSignatureMessage) (bool, [][32]byte, error) {
 	if err := b.ValidatePostConsensusMsg(runner, signedMsg); err != nil {
@@ -178,6 +255,9 @@ func (b *BaseRunner) basePostConsensusMsgProcessing(logger *zap.Logger, runner R
 	}
 
 	hasQuorum, roots, err := b.basePartialSigMsgProcessing(signedMsg, b.State.PostConsensusContainer)
+	if hasQuorum {
+		b.traceQuorum(dutytrace.StagePostConsensusQuorum, b.State.PostConsensusContainer, roots)
+	}
 	return hasQuorum, roots, errors.Wrap(err, "could not process post-consensus partial signature msg")
 }
 
@@ -0,0 +1,123 @@
+package runner
+
+import (
+	"encoding/hex"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ssz "github.com/ferranbt/fastssz"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+// shadowNetwork drops every broadcast, while syncing through the underlying network
+type shadowNetwork struct {
+	specqbft.Network
+	logger *zap.Logger
+}
+
+// NewShadowNetwork returns a network which never broadcasts, for nodes running in shadow mode
+func NewShadowNetwork(logger *zap.Logger, network specqbft.Network) specqbft.Network {
+	return &shadowNetwork{Network: network, logger: logger}
+}
+
+func (n *shadowNetwork) Broadcast(msg *spectypes.SSVMessage) error {
+	n.logger.Debug("👻 shadow: dropped broadcast", fields.MessageID(msg.MsgID), fields.MessageType(msg.MsgType))
+	return nil
+}
+
+// shadowSigner never signs, it returns empty signatures along with the roots that would have been signed
+type shadowSigner struct {
+	spectypes.KeyManager
+}
+
+// NewShadowSigner returns a key manager which never signs, for nodes running in shadow mode.
+// Slashing checks are delegated to the given key manager.
+func NewShadowSigner(signer spectypes.KeyManager) spectypes.KeyManager {
+	return &shadowSigner{KeyManager: signer}
+}
+
+func (s *shadowSigner) SignBeaconObject(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType) (spectypes.Signature, [32]byte, error) {
+	root, err := spectypes.ComputeETHSigningRoot(obj, domain)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	return make(spectypes.Signature, phase0.SignatureLength), root, nil
+}
+
+func (s *shadowSigner) SignRoot(data spectypes.Root, sigType spectypes.SignatureType, pk []byte) (spectypes.Signature, error) {
+	return make(spectypes.Signature, phase0.SignatureLength), nil
+}
+
+// shadowBeacon logs what would have been submitted instead of submitting it
+type shadowBeacon struct {
+	beacon.BeaconNode
+	logger *zap.Logger
+}
+
+// NewShadowBeacon returns a beacon node which never submits, for nodes running in shadow mode
+func NewShadowBeacon(logger *zap.Logger, bn beacon.BeaconNode) beacon.BeaconNode {
+	return &shadowBeacon{BeaconNode: bn, logger: logger}
+}
+
+func (b *shadowBeacon) wouldSubmit(what string, obj ssz.HashRoot, logFields ...zap.Field) {
+	if root, err := obj.HashTreeRoot(); err == nil {
+		logFields = append(logFields, zap.String("root", hex.EncodeToString(root[:])))
+	}
+	b.logger.Info("👻 shadow: would have submitted "+what, logFields...)
+}
+
+func (b *shadowBeacon) SubmitAttestation(attestation *phase0.Attestation) error {
+	b.wouldSubmit("attestation", attestation, fields.Slot(attestation.Data.Slot))
+	return nil
+}
+
+func (b *shadowBeacon) SubmitBeaconBlock(block *spec.VersionedBeaconBlock, sig phase0.BLSSignature) error {
+	slot, _ := block.Slot()
+	b.logger.Info("👻 shadow: would have submitted block", fields.Slot(slot))
+	return nil
+}
+
+func (b *shadowBeacon) SubmitBlindedBeaconBlock(block *api.VersionedBlindedBeaconBlock, sig phase0.BLSSignature) error {
+	slot, _ := block.Slot()
+	b.logger.Info("👻 shadow: would have submitted blinded block", fields.Slot(slot))
+	return nil
+}
+
+func (b *shadowBeacon) SubmitSignedAggregateSelectionProof(msg *phase0.SignedAggregateAndProof) error {
+	b.wouldSubmit("aggregate and proof", msg.Message, fields.Slot(msg.Message.Aggregate.Data.Slot))
+	return nil
+}
+
+func (b *shadowBeacon) SubmitSyncMessage(msg *altair.SyncCommitteeMessage) error {
+	b.wouldSubmit("sync committee message", msg, fields.Slot(msg.Slot))
+	return nil
+}
+
+func (b *shadowBeacon) SubmitSignedContributionAndProof(contribution *altair.SignedContributionAndProof) error {
+	b.wouldSubmit("contribution and proof", contribution.Message, fields.Slot(contribution.Message.Contribution.Slot))
+	return nil
+}
+
+func (b *shadowBeacon) SubmitValidatorRegistration(pubkey []byte, feeRecipient bellatrix.ExecutionAddress, sig phase0.BLSSignature) error {
+	b.logger.Info("👻 shadow: would have submitted validator registration", fields.PubKey(pubkey))
+	return nil
+}
+
+func (b *shadowBeacon) SubmitBLSToExecutionChange(change *capella.SignedBLSToExecutionChange) error {
+	b.wouldSubmit("bls to execution change", change.Message, zap.Uint64("validator_index", uint64(change.Message.ValidatorIndex)))
+	return nil
+}
+
+func (b *shadowBeacon) SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error {
+	b.wouldSubmit("voluntary exit", voluntaryExit.Message, zap.Uint64("validator_index", uint64(voluntaryExit.Message.ValidatorIndex)))
+	return nil
+}
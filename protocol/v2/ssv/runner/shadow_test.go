@@ -0,0 +1,57 @@
+package runner
+
+import (
+	"testing"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	spectestingutils "github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/bloxapp/ssv/protocol/v2/qbft"
+	"github.com/bloxapp/ssv/protocol/v2/qbft/instance"
+)
+
+func TestCompareShadowValue(t *testing.T) {
+	share := spectestingutils.TestingShare(spectestingutils.Testing4SharesSet())
+	var leader spectypes.OperatorID
+	config := &qbft.Config{
+		ProposerF: func(state *specqbft.State, round specqbft.Round) spectypes.OperatorID {
+			return leader
+		},
+	}
+	b := &BaseRunner{Share: share, Shadow: true, State: NewRunnerState(share.Quorum, nil)}
+	b.State.RunningInstance = instance.NewInstance(config, share, []byte{1}, 1)
+	b.State.RunningInstance.StartValue = []byte{1, 2, 3}
+
+	decided := func(round specqbft.Round, value []byte) *specqbft.SignedMessage {
+		return &specqbft.SignedMessage{
+			Message:  specqbft.Message{Height: 1, Round: round},
+			FullData: value,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		leader spectypes.OperatorID
+		msg    *specqbft.SignedMessage
+		level  zapcore.Level
+	}{
+		{"match", 1, decided(specqbft.FirstRound, []byte{1, 2, 3}), zap.InfoLevel},
+		{"differs when leading", 1, decided(specqbft.FirstRound, []byte{4}), zap.WarnLevel},
+		{"differs when another operator leads", 2, decided(specqbft.FirstRound, []byte{4}), zap.DebugLevel},
+		{"differs in a later round", 1, decided(specqbft.FirstRound+1, []byte{4}), zap.DebugLevel},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			core, logs := observer.New(zap.DebugLevel)
+			leader = test.leader
+			b.compareShadowValue(zap.New(core), test.msg)
+			require.Equal(t, 1, logs.Len())
+			require.Equal(t, test.level, logs.All()[0].Level)
+		})
+	}
+}
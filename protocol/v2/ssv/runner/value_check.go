@@ -0,0 +1,168 @@
+package runner
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+// The value checks below follow the ones of the spec (ssv/value_check.go),
+// but estimate epochs with the node's beacon network so that custom slot timings are honored.
+
+func dutyValueCheck(
+	duty *spectypes.Duty,
+	network beacon.Network,
+	expectedType spectypes.BeaconRole,
+	validatorPK spectypes.ValidatorPK,
+	validatorIndex phase0.ValidatorIndex,
+) error {
+	if network.EstimatedEpochAtSlot(duty.Slot) > network.EstimatedCurrentEpoch()+1 {
+		return errors.New("duty epoch is into far future")
+	}
+
+	if expectedType != duty.Type {
+		return errors.New("wrong beacon role type")
+	}
+
+	if !bytes.Equal(validatorPK, duty.PubKey[:]) {
+		return errors.New("wrong validator pk")
+	}
+
+	if validatorIndex != duty.ValidatorIndex {
+		return errors.New("wrong validator index")
+	}
+
+	return nil
+}
+
+// decodeConsensusData decodes and validates a proposed value and its duty
+func decodeConsensusData(
+	data []byte,
+	network beacon.Network,
+	expectedType spectypes.BeaconRole,
+	validatorPK spectypes.ValidatorPK,
+	validatorIndex phase0.ValidatorIndex,
+) (*spectypes.ConsensusData, error) {
+	cd := &spectypes.ConsensusData{}
+	if err := cd.Decode(data); err != nil {
+		return nil, errors.Wrap(err, "failed decoding consensus data")
+	}
+	if err := cd.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid value")
+	}
+
+	if err := dutyValueCheck(&cd.Duty, network, expectedType, validatorPK, validatorIndex); err != nil {
+		return nil, errors.Wrap(err, "duty invalid")
+	}
+	return cd, nil
+}
+
+func AttesterValueCheckF(
+	signer spectypes.BeaconSigner,
+	network beacon.Network,
+	validatorPK spectypes.ValidatorPK,
+	validatorIndex phase0.ValidatorIndex,
+	sharePublicKey []byte,
+) specqbft.ProposedValueCheckF {
+	return func(data []byte) error {
+		cd, err := decodeConsensusData(data, network, spectypes.BNRoleAttester, validatorPK, validatorIndex)
+		if err != nil {
+			return err
+		}
+
+		attestationData, _ := cd.GetAttestationData() // error checked in cd.validate()
+
+		if cd.Duty.Slot != attestationData.Slot {
+			return errors.New("attestation data slot != duty slot")
+		}
+
+		if cd.Duty.CommitteeIndex != attestationData.Index {
+			return errors.New("attestation data CommitteeIndex != duty CommitteeIndex")
+		}
+
+		if attestationData.Target.Epoch > network.EstimatedCurrentEpoch()+1 {
+			return errors.New("attestation data target epoch is into far future")
+		}
+
+		if attestationData.Source.Epoch >= attestationData.Target.Epoch {
+			return errors.New("attestation data source > target")
+		}
+
+		return signer.IsAttestationSlashable(sharePublicKey, attestationData)
+	}
+}
+
+func ProposerValueCheckF(
+	signer spectypes.BeaconSigner,
+	network beacon.Network,
+	validatorPK spectypes.ValidatorPK,
+	validatorIndex phase0.ValidatorIndex,
+	sharePublicKey []byte,
+	supportsBlinded bool,
+) specqbft.ProposedValueCheckF {
+	return func(data []byte) error {
+		cd, err := decodeConsensusData(data, network, spectypes.BNRoleProposer, validatorPK, validatorIndex)
+		if err != nil {
+			return err
+		}
+
+		if blockData, _, err := cd.GetBlindedBlockData(); err == nil {
+			if !supportsBlinded {
+				return fmt.Errorf("blinded blocks are not supported")
+			}
+			slot, err := blockData.Slot()
+			if err != nil {
+				return errors.Wrap(err, "failed to get slot from blinded block data")
+			}
+			return signer.IsBeaconBlockSlashable(sharePublicKey, slot)
+		}
+		if blockData, _, err := cd.GetBlockData(); err == nil {
+			slot, err := blockData.Slot()
+			if err != nil {
+				return errors.Wrap(err, "failed to get slot from block data")
+			}
+			return signer.IsBeaconBlockSlashable(sharePublicKey, slot)
+		}
+
+		return errors.New("no block data")
+	}
+}
+
+func AggregatorValueCheckF(
+	network beacon.Network,
+	validatorPK spectypes.ValidatorPK,
+	validatorIndex phase0.ValidatorIndex,
+) specqbft.ProposedValueCheckF {
+	return func(data []byte) error {
+		_, err := decodeConsensusData(data, network, spectypes.BNRoleAggregator, validatorPK, validatorIndex)
+		return err
+	}
+}
+
+func SyncCommitteeValueCheckF(
+	network beacon.Network,
+	validatorPK spectypes.ValidatorPK,
+	validatorIndex phase0.ValidatorIndex,
+) specqbft.ProposedValueCheckF {
+	return func(data []byte) error {
+		_, err := decodeConsensusData(data, network, spectypes.BNRoleSyncCommittee, validatorPK, validatorIndex)
+		return err
+	}
+}
+
+func SyncCommitteeContributionValueCheckF(
+	network beacon.Network,
+	validatorPK spectypes.ValidatorPK,
+	validatorIndex phase0.ValidatorIndex,
+) specqbft.ProposedValueCheckF {
+	return func(data []byte) error {
+		_, err := decodeConsensusData(data, network, spectypes.BNRoleSyncCommitteeContribution, validatorPK, validatorIndex)
+		return err
+	}
+}
@@ -0,0 +1,39 @@
+package runner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	spectestingutils "github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+func TestDutyValueCheckCustomNetwork(t *testing.T) {
+	network := beacon.NewCustomNetwork(spectypes.PraterNetwork, beacon.CustomParams{
+		GenesisTime:   uint64(time.Now().Add(-time.Hour).Unix()),
+		SlotDuration:  4 * time.Second,
+		SlotsPerEpoch: 8,
+	})
+	nextEpoch := network.EstimatedCurrentEpoch() + 1
+
+	duty := func(slot phase0.Slot) *spectypes.Duty {
+		return &spectypes.Duty{
+			Type:           spectypes.BNRoleAggregator,
+			PubKey:         spectestingutils.TestingValidatorPubKey,
+			Slot:           slot,
+			ValidatorIndex: spectestingutils.TestingValidatorIndex,
+		}
+	}
+	check := func(slot phase0.Slot) error {
+		return dutyValueCheck(duty(slot), network, spectypes.BNRoleAggregator, spectestingutils.TestingValidatorPubKey[:], spectestingutils.TestingValidatorIndex)
+	}
+
+	// epochs are estimated with the custom timings, rather than the 32 slots of 12 seconds of the spec
+	require.NoError(t, check(network.GetEpochFirstSlot(nextEpoch)))
+	require.NoError(t, check(network.GetEpochFirstSlot(nextEpoch+1)-1))
+	require.EqualError(t, check(network.GetEpochFirstSlot(nextEpoch+1)), "duty epoch is into far future")
+}
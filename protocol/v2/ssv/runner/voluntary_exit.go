@@ -0,0 +1,212 @@
+package runner
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv-spec/qbft"
+	specssv "github.com/bloxapp/ssv-spec/ssv"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/message"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/runner/metrics"
+)
+
+// VoluntaryExitRunner collects partial signatures over a VoluntaryExit and submits the exit,
+// it has no consensus phase since the exit is fully determined by the duty
+type VoluntaryExitRunner struct {
+	BaseRunner *BaseRunner
+
+	beacon   beacon.BeaconNode
+	network  specssv.Network
+	signer   spectypes.KeyManager
+	valCheck qbft.ProposedValueCheckF
+
+	metrics metrics.ConsensusMetrics
+}
+
+func NewVoluntaryExitRunner(
+	beaconNetwork beacon.Network,
+	share *spectypes.Share,
+	beacon beacon.BeaconNode,
+	network specssv.Network,
+	signer spectypes.KeyManager,
+) Runner {
+	return &VoluntaryExitRunner{
+		BaseRunner: &BaseRunner{
+			BeaconRoleType: message.BNRoleVoluntaryExit,
+			BeaconNetwork:  beaconNetwork,
+			Share:          share,
+		},
+
+		beacon:  beacon,
+		network: network,
+		signer:  signer,
+		metrics: metrics.NewConsensusMetrics(message.BNRoleVoluntaryExit),
+	}
+}
+
+func (r *VoluntaryExitRunner) StartNewDuty(logger *zap.Logger, duty *spectypes.Duty) error {
+	return r.BaseRunner.baseStartNewDuty(logger, r, duty)
+}
+
+// HasRunningDuty returns true if a duty is already running (StartNewDuty called and returned nil)
+func (r *VoluntaryExitRunner) HasRunningDuty() bool {
+	return r.BaseRunner.hasRunningDuty()
+}
+
+func (r *VoluntaryExitRunner) ProcessPreConsensus(logger *zap.Logger, signedMsg *spectypes.SignedPartialSignatureMessage) error {
+	quorum, roots, err := r.BaseRunner.basePreConsensusMsgProcessing(r, signedMsg)
+	if err != nil {
+		return errors.Wrap(err, "failed processing voluntary exit message")
+	}
+
+	// quorum returns true only once (first time quorum achieved)
+	if !quorum {
+		return nil
+	}
+
+	// only 1 root, verified in basePreConsensusMsgProcessing
+	root := roots[0]
+	fullSig, err := r.GetState().ReconstructBeaconSig(r.GetState().PreConsensusContainer, root, r.GetShare().ValidatorPubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not reconstruct voluntary exit sig")
+	}
+	signedExit := &phase0.SignedVoluntaryExit{
+		Message: r.calculateVoluntaryExit(),
+	}
+	copy(signedExit.Signature[:], fullSig)
+
+	if err := r.beacon.SubmitVoluntaryExit(signedExit); err != nil {
+		r.BaseRunner.traceSubmission(err)
+		return errors.Wrap(err, "could not submit voluntary exit")
+	}
+	r.BaseRunner.traceSubmission(nil)
+
+	logger.Info("✅ voluntary exit submitted successfully",
+		zap.Uint64("epoch", uint64(signedExit.Message.Epoch)),
+		zap.Uint64("validator_index", uint64(signedExit.Message.ValidatorIndex)),
+	)
+
+	r.GetState().Finished = true
+	return nil
+}
+
+func (r *VoluntaryExitRunner) ProcessConsensus(logger *zap.Logger, signedMsg *qbft.SignedMessage) error {
+	return errors.New("no consensus phase for voluntary exit")
+}
+
+func (r *VoluntaryExitRunner) ProcessPostConsensus(logger *zap.Logger, signedMsg *spectypes.SignedPartialSignatureMessage) error {
+	return errors.New("no post consensus phase for voluntary exit")
+}
+
+func (r *VoluntaryExitRunner) expectedPreConsensusRootsAndDomain() ([]ssz.HashRoot, phase0.DomainType, error) {
+	return []ssz.HashRoot{r.calculateVoluntaryExit()}, spectypes.DomainVoluntaryExit, nil
+}
+
+// expectedPostConsensusRootsAndDomain an INTERNAL function, returns the expected post-consensus roots to sign
+func (r *VoluntaryExitRunner) expectedPostConsensusRootsAndDomain() ([]ssz.HashRoot, phase0.DomainType, error) {
+	return nil, [4]byte{}, errors.New("no post consensus roots for voluntary exit")
+}
+
+func (r *VoluntaryExitRunner) executeDuty(logger *zap.Logger, duty *spectypes.Duty) error {
+	ve := r.calculateVoluntaryExit()
+
+	// sign partial voluntary exit
+	msg, err := r.BaseRunner.signBeaconObject(r, ve, duty.Slot, spectypes.DomainVoluntaryExit)
+	if err != nil {
+		return errors.Wrap(err, "could not sign voluntary exit")
+	}
+	msgs := spectypes.PartialSignatureMessages{
+		Type:     message.VoluntaryExitPartialSig,
+		Slot:     duty.Slot,
+		Messages: []*spectypes.PartialSignatureMessage{msg},
+	}
+
+	// sign msg
+	signature, err := r.GetSigner().SignRoot(msgs, spectypes.PartialSignatureType, r.GetShare().SharePubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not sign voluntary exit msg")
+	}
+	signedPartialMsg := &spectypes.SignedPartialSignatureMessage{
+		Message:   msgs,
+		Signature: signature,
+		Signer:    r.GetShare().OperatorID,
+	}
+
+	// broadcast
+	data, err := signedPartialMsg.Encode()
+	if err != nil {
+		return errors.Wrap(err, "failed to encode voluntary exit pre-consensus signature msg")
+	}
+	msgToBroadcast := &spectypes.SSVMessage{
+		MsgType: spectypes.SSVPartialSignatureMsgType,
+		MsgID:   spectypes.NewMsgID(r.GetShare().DomainType, r.GetShare().ValidatorPubKey, r.BaseRunner.BeaconRoleType),
+		Data:    data,
+	}
+	if err := r.GetNetwork().Broadcast(msgToBroadcast); err != nil {
+		return errors.Wrap(err, "can't broadcast partial voluntary exit sig")
+	}
+	return nil
+}
+
+// calculateVoluntaryExit returns the exit of the duty's validator, effective at the epoch of the duty's slot
+func (r *VoluntaryExitRunner) calculateVoluntaryExit() *phase0.VoluntaryExit {
+	return &phase0.VoluntaryExit{
+		Epoch:          r.BaseRunner.BeaconNetwork.EstimatedEpochAtSlot(r.BaseRunner.State.StartingDuty.Slot),
+		ValidatorIndex: r.BaseRunner.State.StartingDuty.ValidatorIndex,
+	}
+}
+
+func (r *VoluntaryExitRunner) GetBaseRunner() *BaseRunner {
+	return r.BaseRunner
+}
+
+func (r *VoluntaryExitRunner) GetNetwork() specssv.Network {
+	return r.network
+}
+
+func (r *VoluntaryExitRunner) GetBeaconNode() specssv.BeaconNode {
+	return r.beacon
+}
+
+func (r *VoluntaryExitRunner) GetShare() *spectypes.Share {
+	return r.BaseRunner.Share
+}
+
+func (r *VoluntaryExitRunner) GetState() *State {
+	return r.BaseRunner.State
+}
+
+func (r *VoluntaryExitRunner) GetValCheckF() qbft.ProposedValueCheckF {
+	return r.valCheck
+}
+
+func (r *VoluntaryExitRunner) GetSigner() spectypes.KeyManager {
+	return r.signer
+}
+
+// Encode returns the encoded struct in bytes or error
+func (r *VoluntaryExitRunner) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// Decode returns error if decoding failed
+func (r *VoluntaryExitRunner) Decode(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
+
+// GetRoot returns the root used for signing and verification
+func (r *VoluntaryExitRunner) GetRoot() ([32]byte, error) {
+	marshaledRoot, err := r.Encode()
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not encode DutyRunnerState")
+	}
+	ret := sha256.Sum256(marshaledRoot)
+	return ret, nil
+}
@@ -0,0 +1,124 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	spectestingutils "github.com/bloxapp/ssv-spec/types/testingutils"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+var testNetwork = beacon.NewNetwork(spectypes.BeaconTestNetwork)
+
+// testSubmitter records the objects which the runners submit to the beacon node
+type testSubmitter struct {
+	beacon.BeaconNode
+
+	exits   []*phase0.SignedVoluntaryExit
+	changes []*capella.SignedBLSToExecutionChange
+}
+
+func (s *testSubmitter) DomainData(epoch phase0.Epoch, domain phase0.DomainType) (phase0.Domain, error) {
+	return spectestingutils.NewTestingBeaconNode().DomainData(epoch, domain)
+}
+
+func (s *testSubmitter) SubmitVoluntaryExit(exit *phase0.SignedVoluntaryExit) error {
+	s.exits = append(s.exits, exit)
+	return nil
+}
+
+func (s *testSubmitter) SubmitBLSToExecutionChange(change *capella.SignedBLSToExecutionChange) error {
+	s.changes = append(s.changes, change)
+	return nil
+}
+
+// operatorShare returns the share of the given operator in the key set
+func operatorShare(ks *spectestingutils.TestKeySet, operatorID spectypes.OperatorID) *spectypes.Share {
+	share := spectestingutils.TestingShare(ks)
+	share.OperatorID = operatorID
+	share.SharePubKey = ks.Shares[operatorID].GetPublicKey().Serialize()
+	return share
+}
+
+// collectPreConsensus starts the duty on a runner of every operator and returns the partial signatures they broadcast
+func collectPreConsensus(
+	t *testing.T,
+	ks *spectestingutils.TestKeySet,
+	duty *spectypes.Duty,
+	newRunner func(share *spectypes.Share, network *spectestingutils.TestingNetwork) Runner,
+) []*spectypes.SignedPartialSignatureMessage {
+	logger := logging.TestLogger(t)
+	var msgs []*spectypes.SignedPartialSignatureMessage
+	for id := spectypes.OperatorID(1); id <= spectypes.OperatorID(len(ks.Shares)); id++ {
+		network := spectestingutils.NewTestingNetwork()
+		r := newRunner(operatorShare(ks, id), network)
+		require.NoError(t, r.StartNewDuty(logger, duty))
+		require.Len(t, network.BroadcastedMsgs, 1)
+
+		msg := &spectypes.SignedPartialSignatureMessage{}
+		require.NoError(t, msg.Decode(network.BroadcastedMsgs[0].Data))
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+// verifyBeaconSig verifies that sig is the validator's signature over obj in the given domain
+func verifyBeaconSig(t *testing.T, ks *spectestingutils.TestKeySet, sig phase0.BLSSignature, obj ssz.HashRoot, domainType phase0.DomainType) {
+	domain, err := spectestingutils.NewTestingBeaconNode().DomainData(0, domainType)
+	require.NoError(t, err)
+	root, err := spectypes.ComputeETHSigningRoot(obj, domain)
+	require.NoError(t, err)
+
+	s := &bls.Sign{}
+	require.NoError(t, s.Deserialize(sig[:]))
+	require.True(t, s.VerifyByte(ks.ValidatorPK, root[:]))
+}
+
+func TestVoluntaryExitRunner(t *testing.T) {
+	logger := logging.TestLogger(t)
+	ks := spectestingutils.Testing4SharesSet()
+	duty := &spectypes.Duty{
+		PubKey:         spectestingutils.TestingValidatorPubKey,
+		Slot:           spectestingutils.TestingDutySlot,
+		ValidatorIndex: spectestingutils.TestingValidatorIndex,
+	}
+
+	msgs := collectPreConsensus(t, ks, duty, func(share *spectypes.Share, network *spectestingutils.TestingNetwork) Runner {
+		return NewVoluntaryExitRunner(testNetwork, share, &testSubmitter{}, network, spectestingutils.NewTestingKeyManager())
+	})
+
+	submitter := &testSubmitter{}
+	r := NewVoluntaryExitRunner(testNetwork, operatorShare(ks, 1), submitter, spectestingutils.NewTestingNetwork(), spectestingutils.NewTestingKeyManager())
+	require.NoError(t, r.StartNewDuty(logger, duty))
+
+	// no quorum until 3 of the 4 operators signed
+	require.NoError(t, r.ProcessPreConsensus(logger, msgs[0]))
+	require.NoError(t, r.ProcessPreConsensus(logger, msgs[1]))
+	require.Empty(t, submitter.exits)
+	require.False(t, r.GetBaseRunner().State.Finished)
+
+	require.NoError(t, r.ProcessPreConsensus(logger, msgs[2]))
+	require.Len(t, submitter.exits, 1)
+	require.True(t, r.GetBaseRunner().State.Finished)
+
+	exit := submitter.exits[0]
+	require.Equal(t, duty.ValidatorIndex, exit.Message.ValidatorIndex)
+	require.Equal(t, testNetwork.EstimatedEpochAtSlot(duty.Slot), exit.Message.Epoch)
+	verifyBeaconSig(t, ks, exit.Signature, exit.Message, spectypes.DomainVoluntaryExit)
+
+	// the exit is submitted once, late signatures are rejected since the duty finished
+	require.Error(t, r.ProcessPreConsensus(logger, msgs[3]))
+	require.Len(t, submitter.exits, 1)
+
+	t.Run("no consensus phase", func(t *testing.T) {
+		require.Error(t, r.ProcessConsensus(logger, nil))
+		require.Error(t, r.ProcessPostConsensus(logger, nil))
+	})
+}
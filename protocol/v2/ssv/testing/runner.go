@@ -8,6 +8,7 @@ import (
 	spectestingutils "github.com/bloxapp/ssv-spec/types/testingutils"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v2/qbft/testing"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/runner"
 )
@@ -82,7 +83,7 @@ var baseRunner = func(logger *zap.Logger, role spectypes.BeaconRole, valCheck sp
 	switch role {
 	case spectypes.BNRoleAttester:
 		return runner.NewAttesterRunnner(
-			spectypes.BeaconTestNetwork,
+			beacon.NewNetwork(spectypes.BeaconTestNetwork),
 			share,
 			contr,
 			spectestingutils.NewTestingBeaconNode(),
@@ -93,7 +94,7 @@ var baseRunner = func(logger *zap.Logger, role spectypes.BeaconRole, valCheck sp
 		)
 	case spectypes.BNRoleAggregator:
 		return runner.NewAggregatorRunner(
-			spectypes.BeaconTestNetwork,
+			beacon.NewNetwork(spectypes.BeaconTestNetwork),
 			share,
 			contr,
 			spectestingutils.NewTestingBeaconNode(),
@@ -104,7 +105,7 @@ var baseRunner = func(logger *zap.Logger, role spectypes.BeaconRole, valCheck sp
 		)
 	case spectypes.BNRoleProposer:
 		return runner.NewProposerRunner(
-			spectypes.BeaconTestNetwork,
+			beacon.NewNetwork(spectypes.BeaconTestNetwork),
 			share,
 			contr,
 			spectestingutils.NewTestingBeaconNode(),
@@ -115,7 +116,7 @@ var baseRunner = func(logger *zap.Logger, role spectypes.BeaconRole, valCheck sp
 		)
 	case spectypes.BNRoleSyncCommittee:
 		return runner.NewSyncCommitteeRunner(
-			spectypes.BeaconTestNetwork,
+			beacon.NewNetwork(spectypes.BeaconTestNetwork),
 			share,
 			contr,
 			spectestingutils.NewTestingBeaconNode(),
@@ -126,7 +127,7 @@ var baseRunner = func(logger *zap.Logger, role spectypes.BeaconRole, valCheck sp
 		)
 	case spectypes.BNRoleSyncCommitteeContribution:
 		return runner.NewSyncCommitteeAggregatorRunner(
-			spectypes.BeaconTestNetwork,
+			beacon.NewNetwork(spectypes.BeaconTestNetwork),
 			share,
 			contr,
 			spectestingutils.NewTestingBeaconNode(),
@@ -137,7 +138,7 @@ var baseRunner = func(logger *zap.Logger, role spectypes.BeaconRole, valCheck sp
 		)
 	case spectypes.BNRoleValidatorRegistration:
 		return runner.NewValidatorRegistrationRunner(
-			spectypes.BeaconTestNetwork,
+			beacon.NewNetwork(spectypes.BeaconTestNetwork),
 			share,
 			contr,
 			spectestingutils.NewTestingBeaconNode(),
@@ -146,7 +147,7 @@ var baseRunner = func(logger *zap.Logger, role spectypes.BeaconRole, valCheck sp
 		)
 	case spectestingutils.UnknownDutyType:
 		ret := runner.NewAttesterRunnner(
-			spectypes.BeaconTestNetwork,
+			beacon.NewNetwork(spectypes.BeaconTestNetwork),
 			share,
 			contr,
 			spectestingutils.NewTestingBeaconNode(),
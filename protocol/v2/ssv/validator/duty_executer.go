@@ -5,6 +5,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/runner"
 	"github.com/bloxapp/ssv/protocol/v2/types"
 )
 
@@ -16,6 +17,14 @@ func (v *Validator) OnExecuteDuty(logger *zap.Logger, msg types.EventMsg) error
 
 	logger = logger.With(fields.Slot(executeDutyData.Duty.Slot), fields.Role(executeDutyData.Duty.Type))
 
+	if executeDutyData.ExecutionAddress != nil {
+		changeRunner, ok := v.DutyRunners[executeDutyData.Duty.Type].(*runner.BLSToExecutionChangeRunner)
+		if !ok {
+			return errors.New("execution address is only applicable to bls to execution change duties")
+		}
+		changeRunner.SetExecutionAddress(*executeDutyData.ExecutionAddress)
+	}
+
 	// force the validator to be started (subscribed to validator's topic and synced)
 	if err := v.Start(logger); err != nil {
 		return errors.Wrap(err, "could not start validator")
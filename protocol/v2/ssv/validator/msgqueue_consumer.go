@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 
@@ -11,6 +12,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/protocol/v2/dutytrace"
 	"github.com/bloxapp/ssv/protocol/v2/message"
 	"github.com/bloxapp/ssv/protocol/v2/qbft/instance"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
@@ -32,6 +34,8 @@ func (v *Validator) HandleMessage(logger *zap.Logger, msg *spectypes.SSVMessage)
 	v.mtx.RLock() // read v.Queues
 	defer v.mtx.RUnlock()
 
+	v.capture.Message(msg)
+
 	// logger.Debug("📬 handling SSV message",
 	// 	zap.Uint64("type", uint64(msg.MsgType)),
 	// 	fields.Role(msg.MsgID.GetRoleType()))
@@ -46,7 +50,9 @@ func (v *Validator) HandleMessage(logger *zap.Logger, msg *spectypes.SSVMessage)
 			)
 			return
 		}
+		v.traceMessage(decodedMsg, dutytrace.StageReceived)
 		if pushed := q.Q.TryPush(decodedMsg); !pushed {
+			v.traceMessage(decodedMsg, dutytrace.StageDropped)
 			msgID := msg.MsgID.String()
 			logger.Warn("❗ dropping message because the queue is full",
 				zap.String("msg_type", message.MsgTypeToString(msg.MsgType)),
@@ -58,6 +64,29 @@ func (v *Validator) HandleMessage(logger *zap.Logger, msg *spectypes.SSVMessage)
 	}
 }
 
+// traceMessage records a message in the trace of its duty, along with its signers
+func (v *Validator) traceMessage(msg *queue.DecodedSSVMessage, stage dutytrace.Stage) {
+	if v.dutyTracer == nil {
+		return
+	}
+	event := dutytrace.Event{Stage: stage}
+	var slot phase0.Slot
+	switch m := msg.Body.(type) {
+	case *specqbft.SignedMessage:
+		slot = phase0.Slot(m.Message.Height)
+		event.Message = message.QBFTMsgTypeToString(m.Message.MsgType)
+		event.Round = m.Message.Round
+		event.Signers = m.Signers
+	case *spectypes.SignedPartialSignatureMessage:
+		slot = m.Message.Slot
+		event.Message = message.PartialSigMsgTypeToString(m.Message.Type)
+		event.Signers = []spectypes.OperatorID{m.Signer}
+	default:
+		return
+	}
+	v.dutyTracer.Record(msg.MsgID, slot, event)
+}
+
 // StartQueueConsumer start ConsumeQueue with handler
 func (v *Validator) StartQueueConsumer(logger *zap.Logger, msgID spectypes.MessageID, handler MessageHandler) {
 	ctx, cancel := context.WithCancel(v.ctx)
@@ -97,51 +126,13 @@ func (v *Validator) ConsumeQueue(logger *zap.Logger, msgID spectypes.MessageID,
 	lens := make([]int, 0, 10)
 
 	for ctx.Err() == nil {
-		// Construct a representation of the current state.
-		state := *q.queueState
-		runner := v.DutyRunners.DutyRunnerForMsgID(msgID)
-		if runner == nil {
-			return fmt.Errorf("could not get duty runner for msg ID %v", msgID)
-		}
-		var runningInstance *instance.Instance
-		if runner.HasRunningDuty() {
-			runningInstance = runner.GetBaseRunner().State.RunningInstance
-			if runningInstance != nil {
-				decided, _ := runningInstance.IsDecided()
-				state.HasRunningInstance = !decided
-			}
-		}
-		state.Height = v.GetLastHeight(msgID)
-		state.Round = v.GetLastRound(msgID)
-		state.Quorum = v.Share.Quorum
-
-		filter := queue.FilterAny
-		if !runner.HasRunningDuty() {
-			// If no duty is running, pop only ExecuteDuty messages.
-			filter = func(m *queue.DecodedSSVMessage) bool {
-				e, ok := m.Body.(*types.EventMsg)
-				if !ok {
-					return false
-				}
-				return e.Type == types.ExecuteDuty
-			}
-		} else if runningInstance != nil && runningInstance.State.ProposalAcceptedForCurrentRound == nil {
-			// If no proposal was accepted for the current round, skip prepare & commit messages
-			// for the current height and round.
-			filter = func(m *queue.DecodedSSVMessage) bool {
-				sm, ok := m.Body.(*specqbft.SignedMessage)
-				if !ok {
-					return true
-				}
-				if sm.Message.Height != state.Height || sm.Message.Round != state.Round {
-					return true
-				}
-				return sm.Message.MsgType != specqbft.PrepareMsgType && sm.Message.MsgType != specqbft.CommitMsgType
-			}
+		state, filter, err := v.queueFilter(msgID, q)
+		if err != nil {
+			return err
 		}
 
 		// Pop the highest priority message for the current state.
-		msg := q.Q.Pop(ctx, queue.NewMessagePrioritizer(&state), filter)
+		msg := q.Q.Pop(ctx, v.newPrioritizer(state), filter)
 		if ctx.Err() != nil {
 			break
 		}
@@ -169,6 +160,84 @@ func (v *Validator) ConsumeQueue(logger *zap.Logger, msgID spectypes.MessageID,
 	return nil
 }
 
+// queueFilter returns the state of the queue and the filter of the messages which can be processed in it
+func (v *Validator) queueFilter(msgID spectypes.MessageID, q queueContainer) (*queue.State, queue.Filter, error) {
+	// Construct a representation of the current state.
+	state := *q.queueState
+	runner := v.DutyRunners.DutyRunnerForMsgID(msgID)
+	if runner == nil {
+		return nil, nil, fmt.Errorf("could not get duty runner for msg ID %v", msgID)
+	}
+	if runnerState := runner.GetBaseRunner().State; runnerState != nil && runnerState.StartingDuty != nil {
+		state.Slot = runnerState.StartingDuty.Slot
+	}
+	var runningInstance *instance.Instance
+	if runner.HasRunningDuty() {
+		runningInstance = runner.GetBaseRunner().State.RunningInstance
+		if runningInstance != nil {
+			decided, _ := runningInstance.IsDecided()
+			state.HasRunningInstance = !decided
+		}
+	}
+	state.Height = v.GetLastHeight(msgID)
+	state.Round = v.GetLastRound(msgID)
+	state.Quorum = v.Share.Quorum
+
+	filter := queue.FilterAny
+	if !runner.HasRunningDuty() {
+		// If no duty is running, pop only ExecuteDuty messages.
+		filter = func(m *queue.DecodedSSVMessage) bool {
+			e, ok := m.Body.(*types.EventMsg)
+			if !ok {
+				return false
+			}
+			return e.Type == types.ExecuteDuty
+		}
+	} else if runningInstance != nil && runningInstance.State.ProposalAcceptedForCurrentRound == nil {
+		// If no proposal was accepted for the current round, skip prepare & commit messages
+		// for the current height and round.
+		filter = func(m *queue.DecodedSSVMessage) bool {
+			sm, ok := m.Body.(*specqbft.SignedMessage)
+			if !ok {
+				return true
+			}
+			if sm.Message.Height != state.Height || sm.Message.Round != state.Round {
+				return true
+			}
+			return sm.Message.MsgType != specqbft.PrepareMsgType && sm.Message.MsgType != specqbft.CommitMsgType
+		}
+	}
+	return &state, filter, nil
+}
+
+// ProcessQueue handles the queued messages of the given message ID which can be processed in the current state,
+// in the order ConsumeQueue would, until none is left. Unlike ConsumeQueue it doesn't wait for messages,
+// so that a validator can be driven deterministically, e.g. on replays.
+func (v *Validator) ProcessQueue(logger *zap.Logger, msgID spectypes.MessageID, handler MessageHandler) error {
+	v.mtx.RLock() // read v.Queues
+	q, ok := v.Queues[msgID.GetRoleType()]
+	v.mtx.RUnlock()
+	if !ok {
+		return errors.New(fmt.Sprintf("queue not found for role %s", msgID.GetRoleType().String()))
+	}
+
+	for {
+		state, filter, err := v.queueFilter(msgID, q)
+		if err != nil {
+			return err
+		}
+		msg := q.Q.TryPop(v.newPrioritizer(state), filter)
+		if msg == nil {
+			return nil
+		}
+		if err := handler(logger, msg); err != nil {
+			v.logMsg(logger, msg, "❗ could not handle message",
+				fields.MessageType(msg.SSVMessage.MsgType),
+				zap.Error(err))
+		}
+	}
+}
+
 func (v *Validator) logMsg(logger *zap.Logger, msg *queue.DecodedSSVMessage, logMsg string, withFields ...zap.Field) {
 	baseFields := []zap.Field{}
 	switch msg.SSVMessage.MsgType {
@@ -1,12 +1,17 @@
 package validator
 
 import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	specssv "github.com/bloxapp/ssv-spec/ssv"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 
 	"github.com/bloxapp/ssv/ibft/storage"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/capture"
+	"github.com/bloxapp/ssv/protocol/v2/dutytrace"
 	qbftctrl "github.com/bloxapp/ssv/protocol/v2/qbft/controller"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/runner"
 	"github.com/bloxapp/ssv/protocol/v2/types"
 )
@@ -19,23 +24,43 @@ const (
 type Options struct {
 	Network           specqbft.Network
 	Beacon            specssv.BeaconNode
-	BeaconNetwork     spectypes.BeaconNetwork
+	BeaconNetwork     beacon.Network
 	Storage           *storage.QBFTStores
 	SSVShare          *types.SSVShare
 	Signer            spectypes.KeyManager
 	DutyRunners       runner.DutyRunners
 	NewDecidedHandler qbftctrl.NewDecidedHandler
+	DutyTracer        *dutytrace.Recorder
+	Capture           *capture.Recorder
+	DutyGuard         DutyGuard
 	FullNode          bool
 	Exporter          bool
 	BuilderProposals  bool
+	ShadowMode        bool
 	QueueSize         int
+	QueuePrioritizer  queue.PrioritizerFactory
+	QueueDropPolicy   queue.DropPolicy
 	GasLimit          uint64
 }
 
+// DutyGuard holds back the duties of started validators which may be signing elsewhere
+type DutyGuard interface {
+	// StartObserving is called when the validator starts, clusterDecided reports whether
+	// the validator's cluster decided a duty in the given epoch or later
+	StartObserving(index phase0.ValidatorIndex, clusterDecided func(epoch phase0.Epoch) bool)
+	// StopObserving is called when the validator stops
+	StopObserving(index phase0.ValidatorIndex)
+	// CanSign returns true if the validator is cleared to execute duties
+	CanSign(index phase0.ValidatorIndex) bool
+}
+
 func (o *Options) defaults() {
 	if o.QueueSize == 0 {
 		o.QueueSize = DefaultQueueSize
 	}
+	if o.QueuePrioritizer == nil {
+		o.QueuePrioritizer = queue.NewMessagePrioritizer
+	}
 	if o.GasLimit == 0 {
 		o.GasLimit = spectypes.DefaultGasLimit
 	}
@@ -5,6 +5,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv-spec/p2p"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/bloxapp/ssv/logging"
@@ -54,6 +55,9 @@ func (v *Validator) Start(logger *zap.Logger) error {
 			go v.StartQueueConsumer(logger, identifier, v.ProcessMessage)
 			go v.sync(logger, identifier)
 		}
+		if v.dutyGuard != nil && v.Share.HasBeaconMetadata() {
+			v.dutyGuard.StartObserving(v.Share.BeaconMetadata.Index, v.clusterDecided)
+		}
 	}
 	return nil
 }
@@ -62,6 +66,9 @@ func (v *Validator) Start(logger *zap.Logger) error {
 func (v *Validator) Stop() {
 	if atomic.CompareAndSwapUint32(&v.state, uint32(Started), uint32(NotStarted)) {
 		v.cancel()
+		if v.dutyGuard != nil && v.Share.HasBeaconMetadata() {
+			v.dutyGuard.StopObserving(v.Share.BeaconMetadata.Index)
+		}
 
 		v.mtx.Lock() // write-lock for v.Queues
 		defer v.mtx.Unlock()
@@ -71,6 +78,25 @@ func (v *Validator) Stop() {
 	}
 }
 
+// clusterDecided returns true if the cluster decided an attestation in the given epoch or later,
+// so that liveness of the validator can be attributed to the cluster
+func (v *Validator) clusterDecided(epoch phase0.Epoch) bool {
+	r, ok := v.DutyRunners[spectypes.BNRoleAttester]
+	if !ok || r.GetBaseRunner().QBFTController == nil {
+		return false
+	}
+	identifier := spectypes.NewMsgID(types.GetDefaultDomain(), v.Share.ValidatorPubKey, spectypes.BNRoleAttester)
+	highestInstance, err := r.GetBaseRunner().QBFTController.LoadHighestInstance(identifier[:])
+	if err != nil || highestInstance == nil {
+		return false
+	}
+	decidedValue := &spectypes.ConsensusData{}
+	if err := decidedValue.Decode(highestInstance.State.DecidedValue); err != nil {
+		return false
+	}
+	return r.GetBaseRunner().BeaconNetwork.EstimatedEpochAtSlot(decidedValue.Duty.Slot) >= epoch
+}
+
 // sync performs highest decided sync
 func (v *Validator) sync(logger *zap.Logger, mid spectypes.MessageID) {
 	ctx, cancel := context.WithCancel(v.ctx)
@@ -40,6 +40,7 @@ func (v *Validator) onTimeout(logger *zap.Logger, identifier spectypes.MessageID
 			logger.Debug("❌ failed to decode timer msg", zap.Error(err))
 			return
 		}
+		v.capture.Message(msg)
 
 		if pushed := v.Queues[identifier.GetRoleType()].Q.TryPush(dec); !pushed {
 			logger.Warn("❗️ dropping timeout message because the queue is full",
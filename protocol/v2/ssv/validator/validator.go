@@ -13,6 +13,8 @@ import (
 
 	"github.com/bloxapp/ssv/ibft/storage"
 	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/protocol/v2/capture"
+	"github.com/bloxapp/ssv/protocol/v2/dutytrace"
 	"github.com/bloxapp/ssv/protocol/v2/message"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/runner"
@@ -35,6 +37,11 @@ type Validator struct {
 	Storage *storage.QBFTStores
 	Queues  map[spectypes.BeaconRole]queueContainer
 
+	dutyTracer     *dutytrace.Recorder
+	capture        *capture.Recorder
+	dutyGuard      DutyGuard
+	newPrioritizer queue.PrioritizerFactory
+
 	// dutyIDs is a map for logging a unique ID for a given duty
 	dutyIDs *hashmap.Map[spectypes.BeaconRole, string]
 
@@ -46,17 +53,21 @@ func NewValidator(pctx context.Context, cancel func(), options Options) *Validat
 	options.defaults()
 
 	v := &Validator{
-		mtx:         &sync.RWMutex{},
-		ctx:         pctx,
-		cancel:      cancel,
-		DutyRunners: options.DutyRunners,
-		Network:     options.Network,
-		Storage:     options.Storage,
-		Share:       options.SSVShare,
-		Signer:      options.Signer,
-		Queues:      make(map[spectypes.BeaconRole]queueContainer),
-		state:       uint32(NotStarted),
-		dutyIDs:     hashmap.New[spectypes.BeaconRole, string](),
+		mtx:            &sync.RWMutex{},
+		ctx:            pctx,
+		cancel:         cancel,
+		DutyRunners:    options.DutyRunners,
+		Network:        options.Network,
+		Storage:        options.Storage,
+		Share:          options.SSVShare,
+		Signer:         options.Signer,
+		Queues:         make(map[spectypes.BeaconRole]queueContainer),
+		state:          uint32(NotStarted),
+		dutyIDs:        hashmap.New[spectypes.BeaconRole, string](),
+		dutyTracer:     options.DutyTracer,
+		capture:        options.Capture,
+		dutyGuard:      options.DutyGuard,
+		newPrioritizer: options.QueuePrioritizer,
 	}
 
 	for _, dutyRunner := range options.DutyRunners {
@@ -67,8 +78,15 @@ func NewValidator(pctx context.Context, cancel func(), options Options) *Validat
 		role := dutyRunner.GetBaseRunner().BeaconRoleType
 		msgID := spectypes.NewMsgID(types.GetDefaultDomain(), options.SSVShare.ValidatorPubKey, role).String()
 
+		// messages evicted to make room for higher priority ones are dropped like rejected pushes
+		metrics := queue.NewPrometheusMetrics(msgID)
+		q := queue.NewWithPolicy(options.QueueSize, options.QueueDropPolicy, func(msg *queue.DecodedSSVMessage) {
+			metrics.Dropped()
+			v.traceMessage(msg, dutytrace.StageDropped)
+		})
+
 		v.Queues[role] = queueContainer{
-			Q: queue.WithMetrics(queue.New(options.QueueSize), queue.NewPrometheusMetrics(msgID)),
+			Q: queue.WithMetrics(q, metrics),
 			queueState: &queue.State{
 				HasRunningInstance: false,
 				Height:             0,
@@ -78,6 +96,8 @@ func NewValidator(pctx context.Context, cancel func(), options Options) *Validat
 		}
 	}
 
+	v.capture.Share(options.SSVShare)
+
 	return v
 }
 
@@ -87,6 +107,9 @@ func (v *Validator) StartDuty(logger *zap.Logger, duty *spectypes.Duty) error {
 	if dutyRunner == nil {
 		return errors.Errorf("no runner for duty type %s", duty.Type.String())
 	}
+	if v.dutyGuard != nil && !v.dutyGuard.CanSign(v.Share.BeaconMetadata.Index) {
+		return errors.Errorf("validator isn't cleared by doppelganger protection, skipping %s duty", duty.Type.String())
+	}
 
 	// Log with duty ID.
 	baseRunner := dutyRunner.GetBaseRunner()
@@ -99,6 +122,7 @@ func (v *Validator) StartDuty(logger *zap.Logger, duty *spectypes.Duty) error {
 	}
 
 	logger.Info("ℹ️ starting duty processing")
+	v.capture.Duty(duty)
 
 	return dutyRunner.StartNewDuty(logger, duty)
 }
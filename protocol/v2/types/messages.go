@@ -3,8 +3,13 @@ package types
 import (
 	"encoding/json"
 
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv-spec/qbft"
 	"github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v2/message"
 )
 
 type EventType int
@@ -38,6 +43,8 @@ type TimeoutData struct {
 
 type ExecuteDutyData struct {
 	Duty *types.Duty
+	// ExecutionAddress is the address the withdrawal credentials are changed to by a bls to execution change duty
+	ExecutionAddress *bellatrix.ExecutionAddress `json:",omitempty"`
 }
 
 func (m *EventMsg) GetTimeoutData() (*TimeoutData, error) {
@@ -65,3 +72,30 @@ func (msg *EventMsg) Encode() ([]byte, error) {
 func (msg *EventMsg) Decode(data []byte) error {
 	return json.Unmarshal(data, &msg)
 }
+
+// CreateDutyExecuteMsg returns ssvMsg with event type of duty execute
+func CreateDutyExecuteMsg(duty *types.Duty, pubKey phase0.BLSPubKey, domain types.DomainType) (*types.SSVMessage, error) {
+	return CreateExecuteMsg(&ExecuteDutyData{Duty: duty}, pubKey, domain)
+}
+
+// CreateExecuteMsg returns ssvMsg with event type of duty execute, carrying the given duty data
+func CreateExecuteMsg(executeDutyData *ExecuteDutyData, pubKey phase0.BLSPubKey, domain types.DomainType) (*types.SSVMessage, error) {
+	duty := executeDutyData.Duty
+	edd, err := json.Marshal(executeDutyData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal execute duty data")
+	}
+	msg := EventMsg{
+		Type: ExecuteDuty,
+		Data: edd,
+	}
+	data, err := msg.Encode()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode event msg")
+	}
+	return &types.SSVMessage{
+		MsgType: message.SSVEventMsgType,
+		MsgID:   types.NewMsgID(domain, pubKey[:], duty.Type),
+		Data:    data,
+	}, nil
+}
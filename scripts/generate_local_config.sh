@@ -6,8 +6,8 @@ function extract_pubkey() {
 }
 
 function extract_privkey() {
-    LOGFILE=$1
-    grep "generated private key (base64)" "$LOGFILE" | grep -E -o '(\{.+\})' | jq -r ".sk"
+    KEYFILE=$1
+    cat "$KEYFILE"
 }
 
 function create_operators() {
@@ -19,17 +19,18 @@ function create_operators() {
   mkdir -p config
 
   for ((i=1;i<=OP_SIZE;i++)); do
-    docker run --rm -it 'bloxstaking/ssv-node:latest' /go/bin/ssvnode generate-operator-keys > tmp.log
+    docker run --rm -it -v "$PWD":/out 'bloxstaking/ssv-node:latest' /go/bin/ssvnode generate-operator-keys \
+      --private-key-file /out/tmp.key > tmp.log
     PUB="$(extract_pubkey "tmp.log")"
     val="$PUB" yq e '.publicKeys += [env(val)]' -i "./operators.yaml"
-    PRIV="$(extract_privkey "tmp.log")"
+    PRIV="$(extract_privkey "tmp.key")"
     touch "./config/share$i.yaml"
     val="./data/db/$i" yq e '.db.Path = env(val)' -n | tee "./config/share$i.yaml" > /dev/null \
       && val="1500$i" yq e '.MetricsAPIPort = env(val)' -i "./config/share$i.yaml" \
       &&  val="$PRIV" yq e '.OperatorPrivateKey = env(val)' -i "./config/share$i.yaml"
   done
   echo "share.yaml(s) generated"
-  rm tmp.log
+  rm tmp.log tmp.key
 }
 
 OP_SIZE=$1
@@ -0,0 +1,178 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+const (
+	// FormatVersion is the version of the backup layout
+	FormatVersion = 1
+
+	manifestFile = "manifest.json"
+	dataFile     = "db.backup"
+)
+
+// Manifest describes a backup
+type Manifest struct {
+	FormatVersion  int                       `json:"format_version"`
+	Network        string                    `json:"network"`
+	ForkVersion    forksprotocol.ForkVersion `json:"fork_version"`
+	MigrationLevel int                       `json:"migration_level"`
+	NodeVersion    string                    `json:"node_version"`
+	CreatedAt      time.Time                 `json:"created_at"`
+	// Size is the size of the data file in bytes
+	Size int64 `json:"size"`
+	// Checksum is the hex encoded sha256 of the data file
+	Checksum string `json:"checksum"`
+}
+
+// Expectations are the properties a backup must match in order to be restored
+type Expectations struct {
+	Network string
+	// MaxMigrationLevel is the latest migration level known to the restoring node
+	MaxMigrationLevel int
+}
+
+// Create writes a consistent backup of the given db into dir, together with its manifest.
+// The network, fork version, migration level and node version of the manifest must be filled by the caller.
+func Create(db basedb.IDb, dir string, manifest Manifest) (*Manifest, error) {
+	backuper, ok := db.(basedb.Backuper)
+	if !ok {
+		return nil, errors.New("db does not support backups")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "could not create backup directory")
+	}
+	if _, err := os.Stat(filepath.Join(dir, manifestFile)); err == nil {
+		return nil, errors.Errorf("backup already exists in %s", dir)
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, dataFile), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create data file")
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(f, hash)}
+	if err := backuper.Backup(counter); err != nil {
+		return nil, errors.Wrap(err, "could not backup db")
+	}
+	if err := f.Sync(); err != nil {
+		return nil, errors.Wrap(err, "could not sync data file")
+	}
+
+	manifest.FormatVersion = FormatVersion
+	manifest.CreatedAt = time.Now().UTC()
+	manifest.Size = counter.n
+	manifest.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	raw, err := json.MarshalIndent(&manifest, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal manifest")
+	}
+	// the manifest is written last, so a backup without a manifest is known to be incomplete
+	if err := os.WriteFile(filepath.Join(dir, manifestFile), raw, 0600); err != nil {
+		return nil, errors.Wrap(err, "could not write manifest")
+	}
+	return &manifest, nil
+}
+
+// Inspect reads the manifest of the backup in dir and verifies the checksum of its data
+func Inspect(dir string) (*Manifest, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read manifest")
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(raw, manifest); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal manifest")
+	}
+	if manifest.FormatVersion != FormatVersion {
+		return nil, errors.Errorf("unsupported backup format version %d", manifest.FormatVersion)
+	}
+
+	f, err := os.Open(filepath.Join(dir, dataFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open data file")
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	n, err := io.Copy(hash, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read data file")
+	}
+	if n != manifest.Size {
+		return nil, errors.Errorf("data file size mismatch: expected %d, got %d", manifest.Size, n)
+	}
+	if checksum := hex.EncodeToString(hash.Sum(nil)); checksum != manifest.Checksum {
+		return nil, errors.Errorf("data file checksum mismatch: expected %s, got %s", manifest.Checksum, checksum)
+	}
+	return manifest, nil
+}
+
+// Restore verifies the backup in dir against the given expectations and loads it into the given db,
+// which must be empty.
+func Restore(db basedb.IDb, dir string, expect Expectations) (*Manifest, error) {
+	backuper, ok := db.(basedb.Backuper)
+	if !ok {
+		return nil, errors.New("db does not support backups")
+	}
+	manifest, err := Inspect(dir)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Network != expect.Network {
+		return nil, errors.Errorf("backup is of network %q, expected %q", manifest.Network, expect.Network)
+	}
+	if manifest.MigrationLevel > expect.MaxMigrationLevel {
+		return nil, errors.Errorf("backup migration level %d is newer than the supported level %d", manifest.MigrationLevel, expect.MaxMigrationLevel)
+	}
+	if err := requireEmpty(db); err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(filepath.Join(dir, dataFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open data file")
+	}
+	defer f.Close()
+
+	if err := backuper.Restore(f); err != nil {
+		return nil, errors.Wrap(err, "could not restore db")
+	}
+	return manifest, nil
+}
+
+func requireEmpty(db basedb.IDb) error {
+	it := db.NewIterator(basedb.IteratorOptions{KeysOnly: true, Limit: 1})
+	defer it.Close()
+	if it.Next() {
+		return fmt.Errorf("db is not empty")
+	}
+	return it.Err()
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,90 @@
+package backup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestBackupRestore(t *testing.T) {
+	logger := logging.TestLogger(t)
+	newDB := func() basedb.IDb {
+		db, err := kv.New(logger, basedb.Options{Type: "badger-memory"})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close(logger) })
+		return db
+	}
+
+	src := newDB()
+	prefix := []byte("prefix")
+	for i := 0; i < 100; i++ {
+		require.NoError(t, src.Set(prefix, []byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i))))
+	}
+
+	dir := filepath.Join(t.TempDir(), "backup")
+	created, err := Create(src, dir, Manifest{Network: "testnet", ForkVersion: "v0", MigrationLevel: 3})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.Checksum)
+	require.Positive(t, created.Size)
+
+	_, err = Create(src, dir, Manifest{Network: "testnet"})
+	require.ErrorContains(t, err, "already exists")
+
+	inspected, err := Inspect(dir)
+	require.NoError(t, err)
+	require.Equal(t, created.Checksum, inspected.Checksum)
+	require.Equal(t, "testnet", inspected.Network)
+	require.Equal(t, 3, inspected.MigrationLevel)
+
+	t.Run("different network", func(t *testing.T) {
+		_, err := Restore(newDB(), dir, Expectations{Network: "mainnet", MaxMigrationLevel: 3})
+		require.ErrorContains(t, err, "network")
+	})
+
+	t.Run("newer schema", func(t *testing.T) {
+		_, err := Restore(newDB(), dir, Expectations{Network: "testnet", MaxMigrationLevel: 2})
+		require.ErrorContains(t, err, "migration level")
+	})
+
+	t.Run("non empty db", func(t *testing.T) {
+		_, err := Restore(src, dir, Expectations{Network: "testnet", MaxMigrationLevel: 3})
+		require.ErrorContains(t, err, "not empty")
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		dst := newDB()
+		_, err := Restore(dst, dir, Expectations{Network: "testnet", MaxMigrationLevel: 4})
+		require.NoError(t, err)
+
+		count := 0
+		require.NoError(t, dst.GetAll(logger, prefix, func(int, basedb.Obj) error {
+			count++
+			return nil
+		}))
+		require.Equal(t, 100, count)
+		obj, found, err := dst.Get(prefix, []byte("key42"))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, "value42", string(obj.Value))
+	})
+
+	t.Run("corrupted", func(t *testing.T) {
+		f, err := os.OpenFile(filepath.Join(dir, dataFile), os.O_WRONLY, 0600)
+		require.NoError(t, err)
+		_, err = f.WriteAt([]byte{0xff, 0xff, 0xff}, 0)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		_, err = Inspect(dir)
+		require.ErrorContains(t, err, "checksum")
+		_, err = Restore(newDB(), dir, Expectations{Network: "testnet", MaxMigrationLevel: 3})
+		require.ErrorContains(t, err, "checksum")
+	})
+}
@@ -2,6 +2,7 @@ package basedb
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"go.uber.org/zap"
@@ -16,12 +17,64 @@ type Options struct {
 	Ctx        context.Context
 }
 
+// ReadTxn interface for read-only transaction like functions
+type ReadTxn interface {
+	Get(prefix []byte, key []byte) (Obj, bool, error)
+	// NewIterator creates an iterator over the transaction's view, the iterator must be closed after use.
+	// Read-write transactions support a single open iterator at a time.
+	NewIterator(opts IteratorOptions) Iterator
+}
+
 // Txn interface for badger transaction like functions
 type Txn interface {
+	ReadTxn
 	Set(prefix []byte, key []byte, value []byte) error
-	Get(prefix []byte, key []byte) (Obj, bool, error)
 	Delete(prefix []byte, key []byte) error
-	// TODO: add iterator
+}
+
+// Snapshot is a read-only consistent view of the db at the time of its creation,
+// it must be discarded after use.
+type Snapshot interface {
+	ReadTxn
+	Discard()
+}
+
+// IteratorOptions configures an Iterator
+type IteratorOptions struct {
+	// Prefix bounds the iteration to keys with the given prefix,
+	// the keys of the returned objects are relative to it.
+	Prefix []byte
+	// Seek is the key (relative to Prefix) to start the iteration at.
+	// Iteration starts at the first key greater than or equal to Seek,
+	// or at the last key lower than or equal to Seek when Reverse is set.
+	Seek []byte
+	// Reverse iterates in descending key order.
+	Reverse bool
+	// KeysOnly skips reading values, the returned objects have nil values.
+	KeysOnly bool
+	// Limit is the maximum amount of objects to iterate over, 0 means no limit.
+	Limit int
+}
+
+// Iterator iterates over objects in key order.
+//
+//	it := db.NewIterator(basedb.IteratorOptions{Prefix: prefix})
+//	defer it.Close()
+//	for it.Next() {
+//		obj := it.Obj()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator interface {
+	// Next moves to the next object, returns false when the iteration is done or failed.
+	Next() bool
+	// Obj returns the current object, it remains valid after the iterator moves.
+	Obj() Obj
+	// Err returns the error that stopped the iteration, if any.
+	Err() error
+	// Close releases the iterator.
+	Close()
 }
 
 // IDb interface for all db kind
@@ -36,6 +89,10 @@ type IDb interface {
 	CountByCollection(prefix []byte) (int64, error)
 	RemoveAllByCollection(prefix []byte) error
 	Update(fn func(Txn) error) error
+	// NewIterator creates an iterator over a consistent view of the db, the iterator must be closed after use.
+	NewIterator(opts IteratorOptions) Iterator
+	// Snapshot creates a read-only consistent view of the db.
+	Snapshot() Snapshot
 	Close(logger *zap.Logger) error
 }
 
@@ -50,6 +107,15 @@ type GarbageCollector interface {
 	FullGC(context.Context) error
 }
 
+// Backuper is an interface implemented by storage engines which support consistent online backups.
+type Backuper interface {
+	// Backup writes a consistent copy of the db to the given writer, while the db remains in use.
+	Backup(w io.Writer) error
+
+	// Restore loads a copy which was written by Backup into the db.
+	Restore(r io.Reader) error
+}
+
 // Obj struct for getting key/value from storage
 type Obj struct {
 	Key   []byte
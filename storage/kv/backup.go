@@ -0,0 +1,19 @@
+package kv
+
+import (
+	"io"
+)
+
+// maxPendingRestoreWrites is the maximum amount of pending writes while restoring a backup
+const maxPendingRestoreWrites = 256
+
+// Backup writes a consistent copy of the db to the given writer, while the db remains in use
+func (b *BadgerDb) Backup(w io.Writer) error {
+	_, err := b.db.Backup(w, 0)
+	return err
+}
+
+// Restore loads a copy which was written by Backup into the db
+func (b *BadgerDb) Restore(r io.Reader) error {
+	return b.db.Load(r, maxPendingRestoreWrites)
+}
@@ -298,6 +298,16 @@ func (b *BadgerDb) Update(fn func(basedb.Txn) error) error {
 	})
 }
 
+// NewIterator creates an iterator over a read-only transaction, which is discarded when the iterator is closed
+func (b *BadgerDb) NewIterator(opts basedb.IteratorOptions) basedb.Iterator {
+	return newBadgerIterator(b.db.NewTransaction(false), opts, true)
+}
+
+// Snapshot creates a read-only consistent view of the db
+func (b *BadgerDb) Snapshot() basedb.Snapshot {
+	return badgerSnapshot{badgerTxn{txn: b.db.NewTransaction(false)}}
+}
+
 func isNotFoundError(err error) bool {
 	return err != nil && (err.Error() == "not found" || err.Error() == "Key not found")
 }
@@ -332,3 +342,7 @@ func (t badgerTxn) Get(prefix []byte, key []byte) (obj basedb.Obj, found bool, e
 func (t badgerTxn) Delete(prefix []byte, key []byte) error {
 	return t.txn.Delete(append(prefix, key...))
 }
+
+func (t badgerTxn) NewIterator(opts basedb.IteratorOptions) basedb.Iterator {
+	return newBadgerIterator(t.txn, opts, false)
+}
@@ -3,8 +3,11 @@ package kv
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/binary"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -212,3 +215,38 @@ func getAllTest(t *testing.T, n int, db basedb.IDb) {
 	require.NoError(t, err)
 	require.Equal(t, n, count)
 }
+
+func TestBadgerRemoveAllByCollectionFromDisk(t *testing.T) {
+	path := t.TempDir()
+	db, err := New(logging.TestLogger(t), basedb.Options{Type: "badger-db", Path: path, Ctx: context.Background()})
+	require.NoError(t, err)
+
+	// random so that it isn't compressed
+	secret := make([]byte, 1700)
+	_, err = rand.Read(secret)
+	require.NoError(t, err)
+	require.NoError(t, db.Set([]byte("prefix/"), []byte("secret"), secret))
+	require.NoError(t, db.Set([]byte("prefix/"), []byte("other"), []byte("value")))
+
+	// reopens so that the value is flushed into a table
+	require.NoError(t, db.Close(logging.TestLogger(t)))
+	db, err = New(logging.TestLogger(t), basedb.Options{Type: "badger-db", Path: path, Ctx: context.Background()})
+	require.NoError(t, err)
+	require.NoError(t, db.RemoveAllByCollection([]byte("prefix/secret")))
+	_, found, err := db.Get([]byte("prefix/"), []byte("secret"))
+	require.NoError(t, err)
+	require.False(t, found)
+	_, found, err = db.Get([]byte("prefix/"), []byte("other"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.NoError(t, db.Close(logging.TestLogger(t)))
+
+	// the removed value isn't left in any of the db files
+	files, err := os.ReadDir(path)
+	require.NoError(t, err)
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(path, f.Name()))
+		require.NoError(t, err)
+		require.False(t, bytes.Contains(data, secret[:64]), f.Name())
+	}
+}
@@ -0,0 +1,140 @@
+package kv
+
+import (
+	"bytes"
+
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// badgerIterator implements basedb.Iterator on top of a badger iterator
+type badgerIterator struct {
+	it   *badger.Iterator
+	opts basedb.IteratorOptions
+	// txn is discarded on Close when the iterator owns it
+	txn *badger.Txn
+
+	started bool
+	count   int
+	obj     basedb.Obj
+	err     error
+}
+
+func newBadgerIterator(txn *badger.Txn, opts basedb.IteratorOptions, ownTxn bool) *badgerIterator {
+	badgerOpts := badger.DefaultIteratorOptions
+	if !opts.Reverse {
+		// badger treats keys outside of the prefix as invalid, which breaks seeking from above the prefix range,
+		// so the prefix is only used for filtering tables in forward iteration
+		badgerOpts.Prefix = opts.Prefix
+	}
+	badgerOpts.Reverse = opts.Reverse
+	badgerOpts.PrefetchValues = !opts.KeysOnly
+
+	it := &badgerIterator{
+		it:   txn.NewIterator(badgerOpts),
+		opts: opts,
+	}
+	if ownTxn {
+		it.txn = txn
+	}
+	return it
+}
+
+// Next moves to the next object, returns false when the iteration is done or failed.
+func (it *badgerIterator) Next() bool {
+	if it.err != nil || (it.opts.Limit > 0 && it.count >= it.opts.Limit) {
+		return false
+	}
+	if !it.started {
+		it.started = true
+		it.seek()
+	} else {
+		it.it.Next()
+	}
+	if !it.it.ValidForPrefix(it.opts.Prefix) {
+		return false
+	}
+
+	item := it.it.Item()
+	obj := basedb.Obj{
+		Key: bytes.TrimPrefix(item.KeyCopy(nil), it.opts.Prefix),
+	}
+	if !it.opts.KeysOnly {
+		value, err := item.ValueCopy(nil)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		obj.Value = value
+	}
+	it.obj = obj
+	it.count++
+	return true
+}
+
+func (it *badgerIterator) seek() {
+	prefix := it.opts.Prefix
+	if len(it.opts.Seek) > 0 {
+		it.it.Seek(append(append([]byte{}, prefix...), it.opts.Seek...))
+		return
+	}
+	if !it.opts.Reverse || len(prefix) == 0 {
+		it.it.Rewind()
+		return
+	}
+
+	// in reverse, badger seeks to the last key lower than or equal to the given key,
+	// so we seek to the first key after the prefix range and skip it if it exists
+	upperBound := prefixUpperBound(prefix)
+	if upperBound == nil {
+		// the prefix consists of 0xff bytes only, so there are no keys after its range
+		it.it.Seek(append(append([]byte{}, prefix...), bytes.Repeat([]byte{0xff}, 64)...))
+		return
+	}
+	it.it.Seek(upperBound)
+	for it.it.Valid() && bytes.Compare(it.it.Item().Key(), upperBound) >= 0 {
+		it.it.Next()
+	}
+}
+
+// prefixUpperBound returns the smallest key which is greater than all keys with the given prefix,
+// or nil if there is no such key.
+func prefixUpperBound(prefix []byte) []byte {
+	upperBound := append([]byte{}, prefix...)
+	for i := len(upperBound) - 1; i >= 0; i-- {
+		if upperBound[i] < 0xff {
+			upperBound[i]++
+			return upperBound[:i+1]
+		}
+	}
+	return nil
+}
+
+// Obj returns the current object, it remains valid after the iterator moves.
+func (it *badgerIterator) Obj() basedb.Obj {
+	return it.obj
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *badgerIterator) Err() error {
+	return it.err
+}
+
+// Close releases the iterator, and the transaction if owned by the iterator.
+func (it *badgerIterator) Close() {
+	it.it.Close()
+	if it.txn != nil {
+		it.txn.Discard()
+	}
+}
+
+// badgerSnapshot implements basedb.Snapshot on top of a read-only badger transaction
+type badgerSnapshot struct {
+	badgerTxn
+}
+
+// Discard releases the snapshot.
+func (s badgerSnapshot) Discard() {
+	s.txn.Discard()
+}
@@ -0,0 +1,90 @@
+package kv
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+func TestIterator(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := New(logger, basedb.Options{Type: "badger-memory"})
+	require.NoError(t, err)
+	defer db.Close(logger)
+
+	prefix := []byte("prefix")
+	for i := 0; i < 5; i++ {
+		require.NoError(t, db.Set(prefix, []byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i))))
+	}
+	// keys right outside of the prefix range
+	require.NoError(t, db.Set([]byte("prefiw"), []byte("key"), []byte("value")))
+	require.NoError(t, db.Set([]byte("prefiy"), nil, []byte("value")))
+	require.NoError(t, db.Set([]byte("prefiy"), []byte("key"), []byte("value")))
+
+	collect := func(it basedb.Iterator) (keys []string, values []string) {
+		defer it.Close()
+		for it.Next() {
+			keys = append(keys, string(it.Obj().Key))
+			values = append(values, string(it.Obj().Value))
+		}
+		require.NoError(t, it.Err())
+		return keys, values
+	}
+
+	tests := []struct {
+		name string
+		opts basedb.IteratorOptions
+		keys []string
+	}{
+		{"prefix", basedb.IteratorOptions{Prefix: prefix}, []string{"key0", "key1", "key2", "key3", "key4"}},
+		{"seek", basedb.IteratorOptions{Prefix: prefix, Seek: []byte("key2")}, []string{"key2", "key3", "key4"}},
+		{"limit", basedb.IteratorOptions{Prefix: prefix, Seek: []byte("key1"), Limit: 2}, []string{"key1", "key2"}},
+		{"reverse", basedb.IteratorOptions{Prefix: prefix, Reverse: true}, []string{"key4", "key3", "key2", "key1", "key0"}},
+		{"reverse seek", basedb.IteratorOptions{Prefix: prefix, Seek: []byte("key2"), Reverse: true, Limit: 2}, []string{"key2", "key1"}},
+		{"no match", basedb.IteratorOptions{Prefix: []byte("none")}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keys, values := collect(db.NewIterator(test.opts))
+			require.Equal(t, test.keys, keys)
+			for i, key := range keys {
+				require.Equal(t, "value"+key[len("key"):], values[i])
+			}
+		})
+	}
+
+	t.Run("keys only", func(t *testing.T) {
+		keys, values := collect(db.NewIterator(basedb.IteratorOptions{Prefix: prefix, KeysOnly: true, Limit: 1}))
+		require.Equal(t, []string{"key0"}, keys)
+		require.Equal(t, []string{""}, values)
+	})
+
+	t.Run("txn", func(t *testing.T) {
+		require.NoError(t, db.Update(func(txn basedb.Txn) error {
+			require.NoError(t, txn.Set(prefix, []byte("key5"), []byte("value5")))
+			keys, _ := collect(txn.NewIterator(basedb.IteratorOptions{Prefix: prefix, Reverse: true, Limit: 1}))
+			require.Equal(t, []string{"key5"}, keys)
+			return nil
+		}))
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		snapshot := db.Snapshot()
+		defer snapshot.Discard()
+
+		require.NoError(t, db.Set(prefix, []byte("key6"), []byte("value6")))
+
+		_, found, err := snapshot.Get(prefix, []byte("key6"))
+		require.NoError(t, err)
+		require.False(t, found)
+		keys, _ := collect(snapshot.NewIterator(basedb.IteratorOptions{Prefix: prefix, Seek: []byte("key5")}))
+		require.Equal(t, []string{"key5"}, keys)
+
+		keys, _ = collect(db.NewIterator(basedb.IteratorOptions{Prefix: prefix, Seek: []byte("key5")}))
+		require.Equal(t, []string{"key5", "key6"}, keys)
+	})
+}
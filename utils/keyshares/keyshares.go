@@ -0,0 +1,191 @@
+package keyshares
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+// Version is the version of the keyshares file format
+const Version = "v4"
+
+// EncryptedKeyLength is the length of an RSA encrypted share, as expected by ValidatorAdded event parsing
+const EncryptedKeyLength = 256
+
+// KeyShares is the keyshares file, holding the payload to register a validator with the SSV contract
+type KeyShares struct {
+	Version   string    `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	Data      Data      `json:"data"`
+	Payload   Payload   `json:"payload"`
+}
+
+// Data describes the validator and its operators
+type Data struct {
+	OwnerAddress string     `json:"ownerAddress"`
+	OwnerNonce   uint64     `json:"ownerNonce"`
+	PublicKey    string     `json:"publicKey"`
+	Operators    []Operator `json:"operators"`
+}
+
+// Operator is an operator of the validator
+type Operator struct {
+	ID          spectypes.OperatorID `json:"id"`
+	OperatorKey string               `json:"operatorKey"`
+}
+
+// Payload is the input of the registerValidator contract call
+type Payload struct {
+	PublicKey   string                 `json:"publicKey"`
+	OperatorIDs []spectypes.OperatorID `json:"operatorIds"`
+	SharesData  string                 `json:"sharesData"`
+}
+
+// Share is the share of a single operator
+type Share struct {
+	OperatorID spectypes.OperatorID
+	// OperatorKey is the base64 encoded PEM public key of the operator
+	OperatorKey  string
+	PubKey       []byte
+	EncryptedKey []byte
+}
+
+// New creates keyshares of the given shares.
+// ownerSignature is the validator signature over OwnerNonceHash(owner, nonce).
+func New(validatorPK []byte, owner common.Address, nonce uint64, ownerSignature []byte, shares []Share) (*KeyShares, error) {
+	if len(validatorPK) != phase0.PublicKeyLength {
+		return nil, errors.Errorf("invalid validator public key length %d", len(validatorPK))
+	}
+	if len(ownerSignature) != phase0.SignatureLength {
+		return nil, errors.Errorf("invalid owner signature length %d", len(ownerSignature))
+	}
+	shares = append([]Share(nil), shares...)
+	sort.Slice(shares, func(i, j int) bool {
+		return shares[i].OperatorID < shares[j].OperatorID
+	})
+
+	sharesData := append([]byte{}, ownerSignature...)
+	operators := make([]Operator, len(shares))
+	operatorIDs := make([]spectypes.OperatorID, len(shares))
+	for i, share := range shares {
+		if i > 0 && shares[i-1].OperatorID == share.OperatorID {
+			return nil, errors.Errorf("duplicate operator %d", share.OperatorID)
+		}
+		if len(share.PubKey) != phase0.PublicKeyLength {
+			return nil, errors.Errorf("invalid share public key length of operator %d", share.OperatorID)
+		}
+		if len(share.EncryptedKey) != EncryptedKeyLength {
+			return nil, errors.Errorf("invalid encrypted key length of operator %d", share.OperatorID)
+		}
+		operators[i] = Operator{ID: share.OperatorID, OperatorKey: share.OperatorKey}
+		operatorIDs[i] = share.OperatorID
+		sharesData = append(sharesData, share.PubKey...)
+	}
+	for _, share := range shares {
+		sharesData = append(sharesData, share.EncryptedKey...)
+	}
+
+	return &KeyShares{
+		Version:   Version,
+		CreatedAt: time.Now().UTC(),
+		Data: Data{
+			OwnerAddress: owner.Hex(),
+			OwnerNonce:   nonce,
+			PublicKey:    hexutil.Encode(validatorPK),
+			Operators:    operators,
+		},
+		Payload: Payload{
+			PublicKey:   hexutil.Encode(validatorPK),
+			OperatorIDs: operatorIDs,
+			SharesData:  hexutil.Encode(sharesData),
+		},
+	}, nil
+}
+
+// Split splits a validator secret key between the operators, any quorum of them (2f+1 of 3f+1) can sign for it.
+// Each share is encrypted with the public key of its operator.
+func Split(sk *bls.SecretKey, owner common.Address, nonce uint64, operators []Operator) (*KeyShares, error) {
+	if !ValidCommitteeSize(len(operators)) {
+		return nil, errors.Errorf("invalid number of operators %d, must be one of 4, 7, 10 or 13", len(operators))
+	}
+	ids := make([]uint64, len(operators))
+	for i, op := range operators {
+		ids[i] = op.ID
+	}
+	quorum, _ := types.ComputeQuorumAndPartialQuorum(len(operators))
+	secretShares, err := threshold.CreateForIDs(sk.Serialize(), quorum, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not split secret key")
+	}
+
+	shares := make([]Share, len(operators))
+	for i, op := range operators {
+		encrypted, err := EncryptShare(op.OperatorKey, secretShares[op.ID])
+		if err != nil {
+			return nil, errors.Wrapf(err, "operator %d", op.ID)
+		}
+		shares[i] = Share{
+			OperatorID:   op.ID,
+			OperatorKey:  op.OperatorKey,
+			PubKey:       secretShares[op.ID].GetPublicKey().Serialize(),
+			EncryptedKey: encrypted,
+		}
+	}
+	ownerSignature := sk.SignByte(OwnerNonceHash(owner, nonce))
+	return New(sk.GetPublicKey().Serialize(), owner, nonce, ownerSignature.Serialize(), shares)
+}
+
+// ValidCommitteeSize returns whether a validator can be registered with the given number of operators
+func ValidCommitteeSize(n int) bool {
+	switch n {
+	case 4, 7, 10, 13:
+		return true
+	}
+	return false
+}
+
+// OwnerNonceHash returns the hash the validator key signs to prove its ownership when registered by owner with nonce
+func OwnerNonceHash(owner common.Address, nonce uint64) []byte {
+	return crypto.Keccak256([]byte(fmt.Sprintf("%s:%d", owner.String(), nonce)))
+}
+
+// EncryptShare encrypts a share secret key with the base64 encoded PEM public key of an operator
+func EncryptShare(operatorKey string, share *bls.SecretKey) ([]byte, error) {
+	pk, err := ParseOperatorKey(operatorKey)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := rsa.EncryptPKCS1v15(rand.Reader, pk, []byte(share.SerializeToHexStr()))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encrypt share")
+	}
+	return encrypted, nil
+}
+
+// ParseOperatorKey parses a base64 encoded PEM operator public key
+func ParseOperatorKey(operatorKey string) (*rsa.PublicKey, error) {
+	pem, err := base64.StdEncoding.DecodeString(operatorKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode operator public key")
+	}
+	pk, err := rsaencryption.ConvertPemToPublicKey(pem)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse operator public key")
+	}
+	return pk, nil
+}
@@ -0,0 +1,134 @@
+package keyshares
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+func TestKeyShares(t *testing.T) {
+	threshold.Init()
+
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	shareKeys, err := threshold.Create(sk.Serialize(), 3, 4)
+	require.NoError(t, err)
+
+	owner := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	ownerSig := sk.SignByte(OwnerNonceHash(owner, 7))
+
+	operatorKeys := map[spectypes.OperatorID][]byte{}
+	var shares []Share
+	for id := spectypes.OperatorID(4); id >= 1; id-- {
+		pk, skPem, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		operatorKeys[id] = skPem
+		operatorKey := base64.StdEncoding.EncodeToString(pk)
+		encrypted, err := EncryptShare(operatorKey, shareKeys[uint64(id)])
+		require.NoError(t, err)
+		shares = append(shares, Share{
+			OperatorID:   id,
+			OperatorKey:  operatorKey,
+			PubKey:       shareKeys[uint64(id)].GetPublicKey().Serialize(),
+			EncryptedKey: encrypted,
+		})
+	}
+
+	ks, err := New(sk.GetPublicKey().Serialize(), owner, 7, ownerSig.Serialize(), shares)
+	require.NoError(t, err)
+	require.Equal(t, []spectypes.OperatorID{1, 2, 3, 4}, ks.Payload.OperatorIDs)
+	require.Equal(t, owner.Hex(), ks.Data.OwnerAddress)
+
+	// parse the payload the way ValidatorAdded events are parsed
+	sharesData, err := hexutil.Decode(ks.Payload.SharesData)
+	require.NoError(t, err)
+	signatureOffset := phase0.SignatureLength
+	pubKeysOffset := phase0.PublicKeyLength*4 + signatureOffset
+	require.Len(t, sharesData, pubKeysOffset+EncryptedKeyLength*4)
+
+	sig := &bls.Sign{}
+	require.NoError(t, sig.Deserialize(sharesData[:signatureOffset]))
+	require.True(t, sig.VerifyByte(sk.GetPublicKey(), OwnerNonceHash(owner, 7)))
+
+	for i, id := range ks.Payload.OperatorIDs {
+		sharePK := sharesData[signatureOffset+i*phase0.PublicKeyLength : signatureOffset+(i+1)*phase0.PublicKeyLength]
+		encrypted := sharesData[pubKeysOffset+i*EncryptedKeyLength : pubKeysOffset+(i+1)*EncryptedKeyLength]
+
+		operatorSK, err := rsaencryption.ConvertPemToPrivateKey(string(operatorKeys[id]))
+		require.NoError(t, err)
+		decrypted, err := rsaencryption.DecodeKey(operatorSK, encrypted)
+		require.NoError(t, err)
+		share := &bls.SecretKey{}
+		require.NoError(t, share.SetHexString(string(decrypted)))
+		require.Equal(t, sharePK, share.GetPublicKey().Serialize())
+	}
+
+	t.Run("duplicate operator", func(t *testing.T) {
+		_, err := New(sk.GetPublicKey().Serialize(), owner, 7, ownerSig.Serialize(), append(shares, shares[0]))
+		require.ErrorContains(t, err, "duplicate operator")
+	})
+
+	t.Run("invalid encrypted key", func(t *testing.T) {
+		invalid := append([]Share(nil), shares...)
+		invalid[0].EncryptedKey = invalid[0].EncryptedKey[1:]
+		_, err := New(sk.GetPublicKey().Serialize(), owner, 7, ownerSig.Serialize(), invalid)
+		require.ErrorContains(t, err, "invalid encrypted key length")
+	})
+}
+
+func TestSplit(t *testing.T) {
+	threshold.Init()
+
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	owner := common.HexToAddress("0x1234567890123456789012345678901234567890")
+
+	operatorKeys := map[spectypes.OperatorID]*rsa.PrivateKey{}
+	var operators []Operator
+	for _, id := range []spectypes.OperatorID{12, 3, 40, 7, 95, 61, 23} {
+		pk, skPem, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		operatorKeys[id], err = rsaencryption.ConvertPemToPrivateKey(string(skPem))
+		require.NoError(t, err)
+		operators = append(operators, Operator{ID: id, OperatorKey: base64.StdEncoding.EncodeToString(pk)})
+	}
+
+	ks, err := Split(sk, owner, 2, operators)
+	require.NoError(t, err)
+	require.Equal(t, []spectypes.OperatorID{3, 7, 12, 23, 40, 61, 95}, ks.Payload.OperatorIDs)
+
+	sharesData, err := hexutil.Decode(ks.Payload.SharesData)
+	require.NoError(t, err)
+	sig := &bls.Sign{}
+	require.NoError(t, sig.Deserialize(sharesData[:phase0.SignatureLength]))
+	require.True(t, sig.VerifyByte(sk.GetPublicKey(), OwnerNonceHash(owner, 2)))
+
+	// a quorum of 5 out of 7 decrypted shares reconstructs a signature of the validator
+	message := []byte("message")
+	pubKeysOffset := phase0.SignatureLength + phase0.PublicKeyLength*len(operators)
+	partials := map[uint64][]byte{}
+	for i, id := range ks.Payload.OperatorIDs[:5] {
+		encrypted := sharesData[pubKeysOffset+i*EncryptedKeyLength : pubKeysOffset+(i+1)*EncryptedKeyLength]
+		decrypted, err := rsaencryption.DecodeKey(operatorKeys[id], encrypted)
+		require.NoError(t, err)
+		share := &bls.SecretKey{}
+		require.NoError(t, share.SetHexString(string(decrypted)))
+		partials[id] = share.SignByte(message).Serialize()
+	}
+	reconstructed, err := threshold.ReconstructSignatures(partials)
+	require.NoError(t, err)
+	require.True(t, reconstructed.VerifyByte(sk.GetPublicKey(), message))
+
+	_, err = Split(sk, owner, 2, operators[:5])
+	require.ErrorContains(t, err, "invalid number of operators")
+}
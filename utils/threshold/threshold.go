@@ -22,6 +22,16 @@ func Init() {
 // Create receives a bls.SecretKey hex and count.
 // Will split the secret key into count shares
 func Create(skBytes []byte, threshold uint64, count uint64) (map[uint64]*bls.SecretKey, error) {
+	ids := make([]uint64, count)
+	for i := range ids {
+		ids[i] = uint64(i + 1)
+	}
+	return CreateForIDs(skBytes, threshold, ids)
+}
+
+// CreateForIDs splits the secret key into shares evaluated at the given ids (e.g. operator ids),
+// any threshold of them can reconstruct it
+func CreateForIDs(skBytes []byte, threshold uint64, ids []uint64) (map[uint64]*bls.SecretKey, error) {
 	// master key Polynomial
 	msk := make([]bls.SecretKey, threshold)
 
@@ -38,12 +48,15 @@ func Create(skBytes []byte, threshold uint64, count uint64) (map[uint64]*bls.Sec
 		msk[i] = sk
 	}
 
-	// evaluate shares - starting from 1 because 0 is master key
+	// evaluate shares - ids must not be 0 because 0 is master key
 	shares := make(map[uint64]*bls.SecretKey)
-	for i := uint64(1); i <= count; i++ {
+	for _, id := range ids {
+		if id == 0 {
+			return nil, fmt.Errorf("invalid share id 0")
+		}
 		blsID := bls.ID{}
 
-		err := blsID.SetDecString(fmt.Sprintf("%d", i))
+		err := blsID.SetDecString(fmt.Sprintf("%d", id))
 		if err != nil {
 			return nil, err
 		}
@@ -55,7 +68,7 @@ func Create(skBytes []byte, threshold uint64, count uint64) (map[uint64]*bls.Sec
 			return nil, err
 		}
 
-		shares[i] = &sk
+		shares[id] = &sk
 	}
 	return shares, nil
 }
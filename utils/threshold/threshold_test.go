@@ -49,6 +49,29 @@ func TestSplitAndReconstruct(t *testing.T) {
 	require.True(t, shareSet.skSig.VerifyByte(shareSet.sk.GetPublicKey(), shareSet.message))
 }
 
+func TestCreateForIDs(t *testing.T) {
+	Init()
+	sk := bls.SecretKey{}
+	sk.SetByCSPRNG()
+	message := []byte("bloxRocks!")
+
+	shares, err := CreateForIDs(sk.Serialize(), 5, []uint64{3, 18, 22, 41, 57, 60, 102})
+	require.NoError(t, err)
+	require.Len(t, shares, 7)
+
+	// any 5 of the 7 shares reconstruct the signature
+	sigVec := make(map[uint64][]byte)
+	for _, id := range []uint64{3, 22, 57, 60, 102} {
+		sigVec[id] = shares[id].SignByte(message).Serialize()
+	}
+	sig, err := ReconstructSignatures(sigVec)
+	require.NoError(t, err)
+	require.True(t, sig.VerifyByte(sk.GetPublicKey(), message))
+
+	_, err = CreateForIDs(sk.Serialize(), 3, []uint64{0, 1, 2, 3})
+	require.Error(t, err)
+}
+
 func TestIncorrectShare(t *testing.T) {
 	Init()
 	shareSet, err := generateShares(4, 3, "bloxRocks!")